@@ -0,0 +1,113 @@
+package easyyaml
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// accessLog records the paths read from a document. It is shared by every
+// value retrieved from the document with Get, Path or Q.
+type accessLog struct {
+	sync.Mutex
+	owner *YAMLValue
+	read  map[string]bool
+}
+
+// TrackAccess starts recording which paths are read from this value with
+// Get, Path or Q, including through values retrieved from it, so
+// AccessedPaths and UnusedPaths can report them. Values reached by
+// iterating, such as with Keys, Values or Raw, are not recorded.
+// Usage: cfg.TrackAccess(); defer func() { log.Println(cfg.UnusedPaths()) }()
+func (yv *YAMLValue) TrackAccess() {
+	if yv.access == nil || yv.access.owner != yv {
+		yv.access = &accessLog{owner: yv, read: make(map[string]bool)}
+		yv.at = ""
+		// Cached lookups made before tracking would not record reads
+		yv.invalidate()
+	}
+}
+
+// AccessedPaths returns the sorted dot-separated paths read since
+// TrackAccess was called. Dots and backslashes within keys are escaped
+// with a backslash, e.g. "annotations.example\.com/team".
+func (yv *YAMLValue) AccessedPaths() []string {
+	if yv.access == nil {
+		return nil
+	}
+	yv.access.Lock()
+	defer yv.access.Unlock()
+	paths := make([]string, 0, len(yv.access.read))
+	for path := range yv.access.read {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// UnusedPaths returns the sorted paths of scalars and empty objects and
+// arrays that were never read since TrackAccess was called. An object or
+// array that was read without reading any of its children counts as read
+// in full, since it was presumably decoded or passed on as a whole.
+// Usage: for _, path := range cfg.UnusedPaths() { log.Printf("unused config key %s", path) }
+func (yv *YAMLValue) UnusedPaths() []string {
+	if yv.access == nil {
+		return nil
+	}
+	accessed := yv.AccessedPaths()
+	readInFull := func(path string) bool {
+		i := sort.SearchStrings(accessed, path)
+		if i == len(accessed) || accessed[i] != path {
+			return false
+		}
+		prefix := path + "."
+		j := sort.SearchStrings(accessed, prefix)
+		return j == len(accessed) || !strings.HasPrefix(accessed[j], prefix)
+	}
+
+	var unused []string
+	var visit func(path string, value *YAMLValue)
+	visit = func(path string, value *YAMLValue) {
+		if path != "" && readInFull(path) {
+			return
+		}
+		if value.Len() == 0 || !(value.IsObject() || value.IsArray()) {
+			if path != "" {
+				unused = append(unused, path)
+			}
+			return
+		}
+		value.eachChild(func(key string, child *YAMLValue) {
+			visit(childPath(path, key), child)
+		})
+	}
+	visit(yv.at, yv)
+	sort.Strings(unused)
+	return unused
+}
+
+// child wraps a value retrieved from yv by key, tracking its path when
+// access is tracked or the document has node details, and recording the
+// read
+func (yv *YAMLValue) child(data interface{}, key interface{}) *YAMLValue {
+	value := &YAMLValue{data: data, opts: yv.opts, cache: yv.cache, foldKeys: yv.foldKeys, coerceKeys: yv.coerceKeys, meta: yv.meta, parent: yv, key: key}
+	if yv.access == nil && yv.meta == nil {
+		return value
+	}
+	value.at = childPath(yv.at, key)
+	if yv.access != nil {
+		value.access = yv.access
+		yv.access.record(value.at)
+	}
+	return value
+}
+
+// record marks a path as read
+func (a *accessLog) record(path string) {
+	if a == nil {
+		return
+	}
+	a.Lock()
+	defer a.Unlock()
+	a.read[path] = true
+}
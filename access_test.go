@@ -0,0 +1,58 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrackAccess(t *testing.T) {
+	cfg, _ := Loads(`server:
+  host: localhost
+  port: 8080
+  time_out: 30
+database:
+  url: postgres://db
+  pool:
+    min: 1
+    max: 10
+features: [a, b]
+tags: {}
+`)
+	cfg.TrackAccess()
+
+	cfg.Path("server.host").AsString()
+	cfg.Get("server").Get("port").AsInt()
+	cfg.Q("database", "pool")
+	cfg.Path("server.timeout")
+	cfg.Get("features").Get(1)
+
+	accessed := []string{"database", "database.pool", "features", "features.1", "server", "server.host", "server.port"}
+	if got := cfg.AccessedPaths(); !reflect.DeepEqual(got, accessed) {
+		t.Errorf("Expected accessed %v, got %v", accessed, got)
+	}
+
+	unused := []string{"database.url", "features.0", "server.time_out", "tags"}
+	if got := cfg.UnusedPaths(); !reflect.DeepEqual(got, unused) {
+		t.Errorf("Expected unused %v, got %v", unused, got)
+	}
+}
+
+func TestTrackAccessWithPathCache(t *testing.T) {
+	cfg, _ := Loads("a:\n  b: 1\nc: 2\n")
+	cfg.EnablePathCache()
+	cfg.Path("a.b")
+	cfg.TrackAccess()
+	cfg.Path("a.b")
+
+	if got := cfg.UnusedPaths(); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("Expected cached reads to be recorded, got unused %v", got)
+	}
+}
+
+func TestUntrackedAccess(t *testing.T) {
+	cfg, _ := Loads("a: 1\n")
+	cfg.Get("a")
+	if cfg.AccessedPaths() != nil || cfg.UnusedPaths() != nil {
+		t.Error("Expected no access data without TrackAccess")
+	}
+}
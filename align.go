@@ -0,0 +1,80 @@
+package easyyaml
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithAlignedValues returns a view of the value that dumps with the values
+// of each mapping aligned at a common column
+func (yv *YAMLValue) WithAlignedValues() *YAMLValue {
+	opts := yv.options()
+	opts.AlignValues = true
+	return yv.WithOptions(opts)
+}
+
+// alignValues pads the block mappings of marshaled YAML so that scalar
+// values written on the same line as their key start in the same column
+func alignValues(out []byte) []byte {
+	var node yaml.Node
+	if err := yaml.Unmarshal(out, &node); err != nil {
+		return out
+	}
+	lines := strings.SplitAfter(string(out), "\n")
+
+	var visit func(n *yaml.Node)
+	visit = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode && n.Style&yaml.FlowStyle == 0 {
+			alignMapping(n, lines)
+		}
+		for _, child := range n.Content {
+			visit(child)
+		}
+	}
+	visit(&node)
+	return []byte(strings.Join(lines, ""))
+}
+
+// alignMapping rewrites the lines of one mapping's inline entries
+func alignMapping(n *yaml.Node, lines []string) {
+	type entry struct {
+		line  int
+		colon int
+		width int
+	}
+	var entries []entry
+	width := 0
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if value.Line != key.Line || key.Line < 1 || key.Line > len(lines) {
+			continue
+		}
+		start := key.Column - 1
+		text := lines[key.Line-1]
+		if start >= len(text) {
+			continue
+		}
+		colon := findKeyEnd(text[start:])
+		if colon < 0 {
+			continue
+		}
+		// Widths count characters so keys with multi-byte runes line up
+		keyWidth := utf8.RuneCountInString(text[start : start+colon])
+		entries = append(entries, entry{line: key.Line - 1, colon: start + colon, width: keyWidth})
+		if keyWidth > width {
+			width = keyWidth
+		}
+	}
+	if len(entries) < 2 {
+		return
+	}
+
+	for _, e := range entries {
+		text := lines[e.line]
+		value := strings.TrimLeft(text[e.colon+1:], " ")
+		padding := strings.Repeat(" ", width-e.width+1)
+		lines[e.line] = text[:e.colon+1] + padding + value
+	}
+}
@@ -0,0 +1,54 @@
+package easyyaml
+
+import "testing"
+
+func TestAlignValues(t *testing.T) {
+	yv, err := Loads(`
+env:
+  DEBUG: "false"
+  DATABASE_URL: postgres://db
+  PORT: 8080
+  nested:
+    a: 1
+    longer: 2
+items:
+  - name: x
+    description: first
+`)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	out, err := yv.WithIndent(2).WithAlignedValues().Dumps()
+	if err != nil {
+		t.Fatalf("Dumps failed: %v", err)
+	}
+	expected := `env:
+  DATABASE_URL: postgres://db
+  DEBUG:        "false"
+  PORT:         8080
+  nested:
+    a:      1
+    longer: 2
+items:
+  - description: first
+    name:        x
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	again, err := Loads(out)
+	if err != nil {
+		t.Fatalf("Aligned output does not parse: %v", err)
+	}
+	if again.Path("env.PORT").AsInt() != 8080 || again.Path("items.0.name").AsString() != "x" {
+		t.Errorf("Aligned output changed values: %v", again.Raw())
+	}
+
+	unicode, _ := Loads("größe: 1\nab: 2\n")
+	out, _ = unicode.WithAlignedValues().Dumps()
+	if out != "ab:    2\ngröße: 1\n" {
+		t.Errorf("Expected alignment by character width, got:\n%s", out)
+	}
+}
@@ -0,0 +1,357 @@
+// Package ansible provides helpers for auditing Ansible files loaded with
+// easyyaml: YAML inventories with their groups and hosts, the variables a
+// host ends up with from the inventory and group_vars/host_vars
+// directories, and the tasks of playbooks.
+//
+// Typical use:
+//
+//	inv, _ := ansible.LoadInventory("inventory/prod.yml")
+//	for _, host := range inv.GroupHosts("webservers") {
+//		vars, _ := inv.HostVars(host, "inventory")
+//		fmt.Println(host, vars.Get("http_port").AsInt())
+//	}
+package ansible
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// Group is an inventory group
+type Group struct {
+	Name string
+	// Hosts lists the hosts declared directly in the group
+	Hosts []string
+	// Children and Parents list the directly related groups
+	Children []string
+	Parents  []string
+	// Vars holds the group's inventory variables
+	Vars *easyyaml.YAMLValue
+}
+
+// Host is an inventory host
+type Host struct {
+	Name string
+	// Groups lists the groups that declare the host directly
+	Groups []string
+	// Vars holds the host's inventory variables
+	Vars *easyyaml.YAMLValue
+}
+
+// Inventory is a parsed YAML inventory. Every group is a descendant of
+// "all".
+type Inventory struct {
+	Groups map[string]*Group
+	Hosts  map[string]*Host
+}
+
+// LoadInventory reads a YAML inventory file. INI inventories are not
+// supported.
+// Usage: inv, err := ansible.LoadInventory("inventory/prod.yml")
+func LoadInventory(filename string) (*Inventory, error) {
+	doc, err := easyyaml.LoadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInventory(doc)
+}
+
+// ParseInventory builds an inventory from a YAML inventory document. Hosts
+// and variables declared for the same group or host in several places are
+// combined.
+func ParseInventory(doc *easyyaml.YAMLValue) (*Inventory, error) {
+	if !doc.IsObject() && !doc.IsNull() {
+		return nil, fmt.Errorf("%w: inventory must be an object", easyyaml.ErrNotAnObject)
+	}
+	inv := &Inventory{Groups: make(map[string]*Group), Hosts: make(map[string]*Host)}
+	inv.group("all")
+	for _, key := range doc.Keys() {
+		name := fmt.Sprintf("%v", key)
+		if err := inv.parseGroup(name, doc.Get(name), ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range inv.Groups {
+		if g.Name != "all" && len(g.Parents) == 0 {
+			inv.link("all", g.Name)
+		}
+	}
+	for _, g := range inv.Groups {
+		sort.Strings(g.Hosts)
+		sort.Strings(g.Children)
+		sort.Strings(g.Parents)
+	}
+	for _, h := range inv.Hosts {
+		sort.Strings(h.Groups)
+	}
+	return inv, nil
+}
+
+// parseGroup adds a group body with its hosts, vars and children
+func (inv *Inventory) parseGroup(name string, body *easyyaml.YAMLValue, parent string) error {
+	if !body.IsObject() && !body.IsNull() {
+		return fmt.Errorf("%w: group %s must be an object", easyyaml.ErrNotAnObject, name)
+	}
+	g := inv.group(name)
+	if parent != "" {
+		inv.link(parent, name)
+	}
+	if vars := body.Get("vars"); vars.IsObject() {
+		if err := g.Vars.Update(vars.Clone()); err != nil {
+			return err
+		}
+	}
+	hosts := body.Get("hosts")
+	for _, key := range hosts.Keys() {
+		hostName := fmt.Sprintf("%v", key)
+		h := inv.host(hostName)
+		if !contains(g.Hosts, hostName) {
+			g.Hosts = append(g.Hosts, hostName)
+			h.Groups = append(h.Groups, name)
+		}
+		if vars := hosts.Get(hostName); vars.IsObject() {
+			if err := h.Vars.Update(vars.Clone()); err != nil {
+				return err
+			}
+		}
+	}
+	children := body.Get("children")
+	for _, key := range children.Keys() {
+		child := fmt.Sprintf("%v", key)
+		if err := inv.parseGroup(child, children.Get(child), name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// group returns the named group, creating it if needed
+func (inv *Inventory) group(name string) *Group {
+	g, ok := inv.Groups[name]
+	if !ok {
+		g = &Group{Name: name, Vars: easyyaml.NewObject()}
+		inv.Groups[name] = g
+	}
+	return g
+}
+
+// host returns the named host, creating it if needed
+func (inv *Inventory) host(name string) *Host {
+	h, ok := inv.Hosts[name]
+	if !ok {
+		h = &Host{Name: name, Vars: easyyaml.NewObject()}
+		inv.Hosts[name] = h
+	}
+	return h
+}
+
+// link records child as a child group of parent
+func (inv *Inventory) link(parent, child string) {
+	p, c := inv.group(parent), inv.group(child)
+	if !contains(p.Children, child) {
+		p.Children = append(p.Children, child)
+		c.Parents = append(c.Parents, parent)
+	}
+}
+
+// GroupHosts returns the hosts of a group and its descendants, sorted
+func (inv *Inventory) GroupHosts(name string) []string {
+	seen := make(map[string]bool)
+	var hosts []string
+	visited := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		g, ok := inv.Groups[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, h := range g.Hosts {
+			if !seen[h] {
+				seen[h] = true
+				hosts = append(hosts, h)
+			}
+		}
+		for _, child := range g.Children {
+			visit(child)
+		}
+	}
+	if name == "all" {
+		for h := range inv.Hosts {
+			seen[h] = true
+			hosts = append(hosts, h)
+		}
+	} else {
+		visit(name)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// HostGroups returns every group a host belongs to, directly or through
+// child groups, in Ansible's variable precedence order: "all" first, then
+// by depth below "all", by the ansible_group_priority inventory variable
+// (1 when unset; higher comes later and wins) and by name
+func (inv *Inventory) HostGroups(host string) []string {
+	h, ok := inv.Hosts[host]
+	if !ok {
+		return nil
+	}
+	member := make(map[string]bool)
+	var climb func(name string)
+	climb = func(name string) {
+		if member[name] {
+			return
+		}
+		member[name] = true
+		for _, parent := range inv.Groups[name].Parents {
+			climb(parent)
+		}
+	}
+	for _, g := range h.Groups {
+		climb(g)
+	}
+	member["all"] = true
+
+	depth := inv.depths()
+	groups := make([]string, 0, len(member))
+	priority := make(map[string]int, len(member))
+	for g := range member {
+		groups = append(groups, g)
+		priority[g] = 1
+		if vars := inv.Groups[g].Vars; vars != nil && vars.Has("ansible_group_priority") {
+			priority[g] = vars.Get("ansible_group_priority").AsInt()
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if depth[groups[i]] != depth[groups[j]] {
+			return depth[groups[i]] < depth[groups[j]]
+		}
+		if priority[groups[i]] != priority[groups[j]] {
+			return priority[groups[i]] < priority[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+	return groups
+}
+
+// depths returns the length of the longest chain of parents from "all" to
+// each group
+func (inv *Inventory) depths() map[string]int {
+	depth := make(map[string]int)
+	var visit func(name string, d int, path map[string]bool)
+	visit = func(name string, d int, path map[string]bool) {
+		if seen, ok := depth[name]; path[name] || ok && seen >= d {
+			return
+		}
+		depth[name] = d
+		path[name] = true
+		for _, child := range inv.Groups[name].Children {
+			visit(child, d+1, path)
+		}
+		delete(path, name)
+	}
+	visit("all", 0, make(map[string]bool))
+	return depth
+}
+
+// HostVars returns the variables of a host with Ansible's precedence: the
+// vars of each of its groups in HostGroups order, then its own. For every
+// group and the host, inventory vars are followed by the files under
+// dir/group_vars or dir/host_vars: <name>.yml, <name>.yaml, <name>.json,
+// <name> or the .yml, .yaml, .json and extensionless files of a <name>
+// directory in name order, skipping hidden files. dir is usually
+// the inventory's directory; "" skips the files. Later sources replace
+// top-level variables of earlier ones, as with Ansible's default
+// hash_behaviour.
+// Usage: vars, err := inv.HostVars("web1", "inventory")
+func (inv *Inventory) HostVars(host, dir string) (*easyyaml.YAMLValue, error) {
+	h, ok := inv.Hosts[host]
+	if !ok {
+		return nil, fmt.Errorf("%w: host %s", easyyaml.ErrKeyNotFound, host)
+	}
+	vars := easyyaml.NewObject()
+	for _, name := range inv.HostGroups(host) {
+		if err := vars.Update(inv.Groups[name].Vars.Clone()); err != nil {
+			return nil, err
+		}
+		if err := mergeVarsFiles(vars, dir, "group_vars", name); err != nil {
+			return nil, err
+		}
+	}
+	if err := vars.Update(h.Vars.Clone()); err != nil {
+		return nil, err
+	}
+	if err := mergeVarsFiles(vars, dir, "host_vars", host); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// mergeVarsFiles updates vars with the files for name under dir/kind
+func mergeVarsFiles(vars *easyyaml.YAMLValue, dir, kind, name string) error {
+	if dir == "" {
+		return nil
+	}
+	base := filepath.Join(dir, kind, name)
+	var files []string
+	if info, err := os.Stat(base); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && varsFile(entry.Name()) {
+				files = append(files, filepath.Join(base, entry.Name()))
+			}
+		}
+	} else {
+		for _, ext := range []string{".yml", ".yaml", ".json", ""} {
+			if info, err := os.Stat(base + ext); err == nil && !info.IsDir() {
+				files = append(files, base+ext)
+			}
+		}
+	}
+	for _, file := range files {
+		doc, err := easyyaml.LoadFile(file)
+		if err != nil {
+			return err
+		}
+		if doc.IsNull() {
+			continue
+		}
+		if err := vars.Update(doc); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// varsFile reports whether a file in a group_vars or host_vars directory
+// holds variables, as Ansible decides: not hidden, and YAML, JSON or
+// without an extension
+func varsFile(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch filepath.Ext(name) {
+	case ".yml", ".yaml", ".json", "":
+		return true
+	}
+	return false
+}
+
+// contains reports whether s holds v
+func contains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package ansible
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const inventory = `all:
+  vars:
+    http_port: 80
+    ntp: pool.ntp.org
+  hosts:
+    bastion:
+  children:
+    webservers:
+      vars:
+        http_port: 8080
+      hosts:
+        web1:
+          http_port: 9090
+        web2:
+    prod:
+      children:
+        webservers:
+        dbservers:
+          hosts:
+            db1:
+databases:
+  hosts:
+    db1:
+`
+
+func writeInventory(t *testing.T) (string, *Inventory) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hosts.yml")
+	os.WriteFile(path, []byte(inventory), 0644)
+	os.MkdirAll(filepath.Join(dir, "group_vars", "prod"), 0755)
+	os.WriteFile(filepath.Join(dir, "group_vars", "prod", "10-main.yml"), []byte("env: prod\nntp: prod.ntp\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "group_vars", "prod", "20-extra.yml"), []byte("env: production\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "group_vars", "prod", "30-old.yml.bak"), []byte("env: backup\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "group_vars", "prod", ".40-swap.yml"), []byte("env: [\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "group_vars", "webservers.yaml"), []byte("ntp: web.ntp\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, "host_vars"), 0755)
+	os.WriteFile(filepath.Join(dir, "host_vars", "web2.yml"), []byte("http_port: 8443\n"), 0644)
+
+	inv, err := LoadInventory(path)
+	if err != nil {
+		t.Fatalf("LoadInventory failed: %v", err)
+	}
+	return dir, inv
+}
+
+func TestInventory(t *testing.T) {
+	_, inv := writeInventory(t)
+
+	if hosts := inv.GroupHosts("prod"); !reflect.DeepEqual(hosts, []string{"db1", "web1", "web2"}) {
+		t.Errorf("Unexpected prod hosts: %v", hosts)
+	}
+	if hosts := inv.GroupHosts("all"); len(hosts) != 4 {
+		t.Errorf("Expected 4 hosts in all, got %v", hosts)
+	}
+	if parents := inv.Groups["webservers"].Parents; !reflect.DeepEqual(parents, []string{"all", "prod"}) {
+		t.Errorf("Unexpected webservers parents: %v", parents)
+	}
+	if parents := inv.Groups["databases"].Parents; !reflect.DeepEqual(parents, []string{"all"}) {
+		t.Errorf("Expected a top-level group under all, got %v", parents)
+	}
+	if groups := inv.HostGroups("db1"); !reflect.DeepEqual(groups, []string{"all", "databases", "prod", "dbservers"}) {
+		t.Errorf("Unexpected db1 groups: %v", groups)
+	}
+}
+
+func TestHostVars(t *testing.T) {
+	dir, inv := writeInventory(t)
+
+	web1, err := inv.HostVars("web1", dir)
+	if err != nil {
+		t.Fatalf("HostVars failed: %v", err)
+	}
+	if web1.Get("http_port").AsInt() != 9090 || web1.Get("env").AsString() != "production" || web1.Get("ntp").AsString() != "web.ntp" {
+		t.Errorf("Unexpected web1 vars: %v", web1.Raw())
+	}
+	web2, _ := inv.HostVars("web2", dir)
+	if web2.Get("http_port").AsInt() != 8443 {
+		t.Errorf("Expected host_vars to win, got %v", web2.Raw())
+	}
+	bastion, _ := inv.HostVars("bastion", "")
+	if bastion.Get("http_port").AsInt() != 80 || bastion.Has("env") {
+		t.Errorf("Unexpected bastion vars: %v", bastion.Raw())
+	}
+	if inv.Groups["webservers"].Vars.Get("ntp").Exists() {
+		t.Error("Expected group vars unchanged by HostVars")
+	}
+	if _, err := inv.HostVars("missing", dir); err == nil {
+		t.Error("Expected an error for an unknown host")
+	}
+}
+
+func TestHostGroupsPriority(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.yml")
+	os.WriteFile(path, []byte(`all:
+  children:
+    alpha:
+      vars: {color: alpha, ansible_group_priority: 10}
+      hosts: {web1: }
+    beta:
+      vars: {color: beta}
+      hosts: {web1: }
+`), 0644)
+	inv, err := LoadInventory(path)
+	if err != nil {
+		t.Fatalf("LoadInventory failed: %v", err)
+	}
+	if groups := inv.HostGroups("web1"); !reflect.DeepEqual(groups, []string{"all", "beta", "alpha"}) {
+		t.Errorf("Expected alpha last by priority, got %v", groups)
+	}
+	vars, _ := inv.HostVars("web1", "")
+	if vars.Get("color").AsString() != "alpha" {
+		t.Errorf("Expected the higher priority group to win, got %v", vars.Raw())
+	}
+}
@@ -0,0 +1,125 @@
+package ansible
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// Task is one task of a playbook or task file
+type Task struct {
+	// Play is the name of the play, or its hosts if it has no name; it is
+	// "" in a task file
+	Play string
+	// Section is "pre_tasks", "tasks", "post_tasks" or "handlers", or ""
+	// in a task file
+	Section string
+	// Path is the dot-separated path of the task in the document, e.g.
+	// "0.tasks.2.block.0"
+	Path string
+	Name string
+	// Module is the module the task runs, e.g. "ansible.builtin.copy"
+	Module string
+	// Args is the value given to the module: an object, a string of
+	// key=value pairs, or null
+	Args *easyyaml.YAMLValue
+	// Spec is the task object
+	Spec *easyyaml.YAMLValue
+}
+
+// taskSections are the keys of a play that hold tasks, in run order
+var taskSections = []string{"pre_tasks", "tasks", "post_tasks", "handlers"}
+
+// taskKeywords are the keys of a task that are not its module
+var taskKeywords = map[string]bool{
+	"name": true, "when": true, "register": true, "loop": true, "loop_control": true,
+	"tags": true, "notify": true, "listen": true, "vars": true, "environment": true,
+	"args": true, "become": true, "become_user": true, "become_method": true,
+	"become_flags": true, "become_exe": true, "ignore_errors": true,
+	"ignore_unreachable": true, "changed_when": true, "failed_when": true,
+	"until": true, "retries": true, "delay": true, "delegate_to": true,
+	"delegate_facts": true, "run_once": true, "no_log": true, "check_mode": true,
+	"diff": true, "any_errors_fatal": true, "async": true, "poll": true,
+	"connection": true, "remote_user": true, "port": true, "timeout": true,
+	"throttle": true, "collections": true, "module_defaults": true,
+	"debugger": true, "block": true, "rescue": true, "always": true,
+}
+
+// Tasks lists the tasks of a playbook, or of a task file such as a role's
+// tasks/main.yml, in document order. Tasks inside block, rescue and always
+// are listed in place of their block; included and imported files are not
+// followed.
+// Usage: for _, task := range ansible.Tasks(playbook) { if task.Module == "shell" { fmt.Println(task.Path, task.Name) } }
+func Tasks(doc *easyyaml.YAMLValue) []Task {
+	var tasks []Task
+	for i, item := range doc.AsArray() {
+		path := strconv.Itoa(i)
+		if !item.Has("hosts") && !item.Has("import_playbook") {
+			tasks = appendTask(tasks, item, "", "", path)
+			continue
+		}
+		play := item.Get("name").AsString()
+		if play == "" {
+			play = fmt.Sprintf("%v", item.Get("hosts").Raw())
+		}
+		for _, section := range taskSections {
+			for j, task := range item.Get(section).AsArray() {
+				tasks = appendTask(tasks, task, play, section, path+"."+section+"."+strconv.Itoa(j))
+			}
+		}
+	}
+	return tasks
+}
+
+// LoadTasks reads a playbook or task file and lists its tasks
+// Usage: tasks, err := ansible.LoadTasks("site.yml")
+func LoadTasks(filename string) ([]Task, error) {
+	doc, err := easyyaml.LoadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if !doc.IsArray() && !doc.IsNull() {
+		return nil, fmt.Errorf("%w: %s must be a list of plays or tasks", easyyaml.ErrNotAnArray, filename)
+	}
+	return Tasks(doc), nil
+}
+
+// appendTask appends task, or the tasks of a block
+func appendTask(tasks []Task, task *easyyaml.YAMLValue, play, section, path string) []Task {
+	if task.Has("block") {
+		for _, part := range []string{"block", "rescue", "always"} {
+			for i, child := range task.Get(part).AsArray() {
+				tasks = appendTask(tasks, child, play, section, path+"."+part+"."+strconv.Itoa(i))
+			}
+		}
+		return tasks
+	}
+	t := Task{Play: play, Section: section, Path: path, Name: task.Get("name").AsString(), Spec: task}
+	for _, key := range task.Keys() {
+		name := fmt.Sprintf("%v", key)
+		if taskKeywords[name] || strings.HasPrefix(name, "with_") {
+			continue
+		}
+		t.Module, t.Args = name, task.Get(name)
+		if name == "action" || name == "local_action" {
+			t.Module, t.Args = actionModule(task.Get(name))
+		}
+		break
+	}
+	return append(tasks, t)
+}
+
+// actionModule splits the value of action or local_action into the module
+// and its arguments
+func actionModule(action *easyyaml.YAMLValue) (string, *easyyaml.YAMLValue) {
+	if action.IsObject() {
+		module := action.Get("module").AsString()
+		args := action.Clone()
+		args.Delete("module")
+		return module, args
+	}
+	module, args, _ := strings.Cut(strings.TrimSpace(action.AsString()), " ")
+	return module, easyyaml.New(strings.TrimSpace(args))
+}
@@ -0,0 +1,69 @@
+package ansible
+
+import (
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+func TestTasks(t *testing.T) {
+	playbook, _ := easyyaml.Loads(`- import_playbook: common.yml
+- name: Web
+  hosts: webservers
+  pre_tasks:
+    - ansible.builtin.apt: {update_cache: true}
+  tasks:
+    - name: Install nginx
+      apt:
+        name: nginx
+      become: true
+      when: ansible_os_family == "Debian"
+    - block:
+        - name: Deploy
+          copy: src=app dest=/srv/app
+      rescue:
+        - name: Roll back
+          action: shell ./rollback.sh
+  handlers:
+    - name: Restart nginx
+      service: {name: nginx, state: restarted}
+      listen: restart web
+- hosts: db
+  tasks:
+    - local_action:
+        module: uri
+        url: http://example.com
+`)
+	tasks := Tasks(playbook)
+	expected := []struct{ play, section, path, module string }{
+		{"Web", "pre_tasks", "1.pre_tasks.0", "ansible.builtin.apt"},
+		{"Web", "tasks", "1.tasks.0", "apt"},
+		{"Web", "tasks", "1.tasks.1.block.0", "copy"},
+		{"Web", "tasks", "1.tasks.1.rescue.0", "shell"},
+		{"Web", "handlers", "1.handlers.0", "service"},
+		{"db", "tasks", "2.tasks.0", "uri"},
+	}
+	if len(tasks) != len(expected) {
+		t.Fatalf("Expected %d tasks, got %+v", len(expected), tasks)
+	}
+	for i, want := range expected {
+		got := tasks[i]
+		if got.Play != want.play || got.Section != want.section || got.Path != want.path || got.Module != want.module {
+			t.Errorf("Task %d: expected %v, got %+v", i, want, got)
+		}
+	}
+	if tasks[1].Name != "Install nginx" || tasks[1].Args.Get("name").AsString() != "nginx" {
+		t.Errorf("Unexpected task: %+v", tasks[1])
+	}
+	if tasks[3].Args.AsString() != "./rollback.sh" || tasks[5].Args.Get("url").AsString() != "http://example.com" || tasks[5].Args.Has("module") {
+		t.Errorf("Unexpected action args: %v, %v", tasks[3].Args.Raw(), tasks[5].Args.Raw())
+	}
+	if !playbook.Path(tasks[2].Path).Exists() {
+		t.Errorf("Expected the path to address the task, got %s", tasks[2].Path)
+	}
+
+	taskFile, _ := easyyaml.Loads("- name: Ping\n  ping:\n")
+	if tasks := Tasks(taskFile); len(tasks) != 1 || tasks[0].Module != "ping" || tasks[0].Play != "" || tasks[0].Path != "0" {
+		t.Errorf("Unexpected task file tasks: %+v", tasks)
+	}
+}
@@ -0,0 +1,76 @@
+package easyyaml
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// AppendFile appends the document to a multi-document YAML file, creating
+// it if needed. The document is preceded by a "---" separator when the file
+// is not empty, and the file is locked while writing so concurrent
+// appenders, such as processes writing to a YAML audit log, do not interleave.
+// Usage: event.AppendFile("audit.yaml")
+func (yv *YAMLValue) AppendFile(filename string) error {
+	yamlBytes, err := yv.Dump()
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f, true); err != nil {
+		return fmt.Errorf("failed to lock file: %w", err)
+	}
+	defer unlockFile(f)
+
+	if err := appendDocument(f, yamlBytes, !yv.directives.isEmpty(), isGzipName(filename)); err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// appendDocument writes doc at the end of f, separating it from any
+// existing content. A document with directives already starts with "---",
+// so the previous document is closed with "..." instead. For gzip files the
+// document is written as a new gzip member.
+func appendDocument(f *os.File, doc []byte, hasDirectives bool, gzipped bool) error {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	var prefix string
+	if size > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, size-1); err != nil {
+			return err
+		}
+		// Compressed bytes say nothing about the text; dumps end in a newline
+		if last[0] != '\n' && !gzipped {
+			prefix = "\n"
+		}
+		if hasDirectives {
+			prefix += "...\n"
+		} else {
+			prefix += "---\n"
+		}
+	}
+
+	out := append([]byte(prefix), doc...)
+	if gzipped {
+		if out, err = compressFor(".gz", out); err != nil {
+			return err
+		}
+	}
+	if _, err := f.Write(out); err != nil {
+		return err
+	}
+	return nil
+}
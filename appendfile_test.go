@@ -0,0 +1,50 @@
+package easyyaml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAppendFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.yaml")
+
+	if err := Object(KV("event", "start")).AppendFile(filename); err != nil {
+		t.Fatalf("AppendFile failed: %v", err)
+	}
+	if err := Object(KV("event", "stop")).AppendFile(filename); err != nil {
+		t.Fatalf("AppendFile failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filename)
+	if string(content) != "event: start\n---\nevent: stop\n" {
+		t.Errorf("Unexpected file content:\n%s", content)
+	}
+}
+
+func TestAppendFileConcurrent(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.yaml")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doc := Object(KV("id", i), KV("message", fmt.Sprintf("event %d", i)))
+			if err := doc.AppendFile(filename); err != nil {
+				t.Errorf("AppendFile failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	docs, err := LoadAllFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to load appended stream: %v", err)
+	}
+	if docs.Len() != 20 {
+		t.Errorf("Expected 20 documents, got %d", docs.Len())
+	}
+}
@@ -0,0 +1,168 @@
+package easyyaml
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strings"
+)
+
+// DefaultMaxArchiveSize is the default limit on the decompressed bytes
+// LoadArchive reads
+const DefaultMaxArchiveSize = 256 << 20
+
+// WithMaxArchiveSize limits the decompressed bytes LoadArchive reads. A
+// value of 0 or less removes the limit.
+// Usage: files, err := easyyaml.LoadArchive("upload.tgz", easyyaml.WithMaxArchiveSize(10<<20))
+func WithMaxArchiveSize(n int64) LoadOption {
+	return func(opts *Options) {
+		opts.MaxArchiveSize = n
+		if n <= 0 {
+			opts.MaxArchiveSize = -1
+		}
+	}
+}
+
+// LoadArchive parses every .yaml and .yml file in a tar, tar.gz or zip
+// archive, keyed by its path inside the archive, with all the documents of
+// each file. Files that fail to parse, such as Helm templates, are left out
+// and reported together as a *MultiError alongside the files that parsed.
+// An archive that decompresses to more than the MaxArchiveSize option
+// fails with ErrArchiveTooLarge.
+// Usage: files, err := easyyaml.LoadArchive("mychart-1.0.0.tgz")
+func LoadArchive(filename string, opts ...LoadOption) (map[string]*Documents, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return loadArchive(data, opts)
+}
+
+// LoadArchiveReader is like LoadArchive but reads the archive from r
+func LoadArchiveReader(r io.Reader, opts ...LoadOption) (map[string]*Documents, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	return loadArchive(data, opts)
+}
+
+// archiveReader loads the YAML entries of an archive, collecting the
+// entries that fail to parse and counting decompressed bytes
+type archiveReader struct {
+	opts      []LoadOption
+	limit     int64
+	remaining int64
+	files     map[string]*Documents
+	c         ErrorCollector
+}
+
+// loadArchive detects the archive format from its header and parses it
+func loadArchive(data []byte, opts []LoadOption) (map[string]*Documents, error) {
+	ar := &archiveReader{opts: opts, limit: loadOptions(opts).MaxArchiveSize, files: make(map[string]*Documents)}
+	if ar.limit == 0 {
+		ar.limit = DefaultMaxArchiveSize
+	}
+	ar.remaining = ar.limit
+	if ar.limit < 0 {
+		ar.remaining = math.MaxInt64 - 1
+	}
+
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) || bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		err = ar.loadZip(data)
+	case len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b:
+		zr, zerr := gzip.NewReader(bytes.NewReader(data))
+		if zerr != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", zerr)
+		}
+		defer zr.Close()
+		err = ar.loadTar(zr)
+	default:
+		err = ar.loadTar(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ar.files, ar.c.Err()
+}
+
+// isYAMLName checks if an archive entry has a YAML file extension
+func isYAMLName(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// loadTar parses the YAML files of an uncompressed tar stream
+func (ar *archiveReader) loadTar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isYAMLName(hdr.Name) {
+			continue
+		}
+		if err := ar.load(hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// loadZip parses the YAML files of a zip archive
+func (ar *archiveReader) loadZip(data []byte) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isYAMLName(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			ar.c.Add("load_archive", f.Name, err)
+			continue
+		}
+		err = ar.load(f.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load reads and parses one entry. Only exceeding the size limit is
+// returned; other failures are collected.
+func (ar *archiveReader) load(name string, r io.Reader) error {
+	content, err := io.ReadAll(io.LimitReader(r, ar.remaining+1))
+	if err != nil {
+		ar.c.Add("load_archive", name, err)
+		return nil
+	}
+	if int64(len(content)) > ar.remaining {
+		return fmt.Errorf("%w: more than %d bytes decompressed", ErrArchiveTooLarge, ar.limit)
+	}
+	ar.remaining -= int64(len(content))
+
+	docs, err := LoadAll(content, ar.opts...)
+	if err != nil {
+		ar.c.Add("load_archive", name, err)
+		return nil
+	}
+	ar.files[name] = docs
+	return nil
+}
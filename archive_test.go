@@ -0,0 +1,93 @@
+package easyyaml
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var archiveFiles = map[string]string{
+	"chart/Chart.yaml":         "name: mychart\nversion: 1.0.0\n",
+	"chart/values.yml":         "replicas: 3\n",
+	"chart/README.md":          "# not yaml\n",
+	"chart/templates/svc.yaml": "kind: Service\n---\nkind: Deployment\n",
+	"chart/templates/cm.yaml":  "data:\n  {{- toYaml .Values.data | nindent 2 }}\n",
+}
+
+func TestLoadArchiveTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range archiveFiles {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	zw.Close()
+
+	filename := filepath.Join(t.TempDir(), "mychart-1.0.0.tgz")
+	os.WriteFile(filename, buf.Bytes(), 0644)
+
+	files, err := LoadArchive(filename)
+	checkArchiveFiles(t, files, err)
+}
+
+func TestLoadArchiveZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range archiveFiles {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+
+	files, err := LoadArchiveReader(&buf)
+	checkArchiveFiles(t, files, err)
+}
+
+func checkArchiveFiles(t *testing.T, files map[string]*Documents, err error) {
+	t.Helper()
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 1 || !strings.Contains(err.Error(), "chart/templates/cm.yaml") {
+		t.Fatalf("Expected one error for the template, got %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Expected 3 YAML files, got %d", len(files))
+	}
+	if files["chart/Chart.yaml"].Get(0).Get("name").AsString() != "mychart" {
+		t.Errorf("Unexpected Chart.yaml: %v", files["chart/Chart.yaml"].Get(0))
+	}
+	if files["chart/values.yml"].Get(0).Get("replicas").AsInt() != 3 {
+		t.Errorf("Unexpected values.yml: %v", files["chart/values.yml"].Get(0))
+	}
+	if svc := files["chart/templates/svc.yaml"]; svc.Len() != 2 || svc.Get(1).Get("kind").AsString() != "Deployment" {
+		t.Errorf("Expected both documents of svc.yaml, got %d", svc.Len())
+	}
+	if _, ok := files["chart/README.md"]; ok {
+		t.Error("Expected non-YAML files to be skipped")
+	}
+}
+
+func TestLoadArchiveSizeLimit(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	content := []byte("data: " + strings.Repeat("x", 4096) + "\n")
+	tw.WriteHeader(&tar.Header{Name: "big.yaml", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	tw.Write(content)
+	tw.Close()
+	zw.Close()
+
+	if _, err := LoadArchiveReader(bytes.NewReader(buf.Bytes()), WithMaxArchiveSize(1024)); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("Expected ErrArchiveTooLarge, got %v", err)
+	}
+	if _, err := LoadArchiveReader(bytes.NewReader(buf.Bytes()), WithMaxArchiveSize(0)); err != nil {
+		t.Errorf("Expected no limit, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Package bench measures easyyaml performance on the generated corpora from
+// easyyaml.BenchmarkCorpus, or on your own data.
+//
+// Run the suite with:
+//
+//	go test -bench . -benchmem ./bench
+package bench
+
+import (
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// Load benchmarks parsing data as a single document
+// Usage: fmt.Println(bench.Load(data, easyyaml.WithInterning()))
+func Load(data []byte, opts ...easyyaml.LoadOption) testing.BenchmarkResult {
+	return testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := easyyaml.Load(data, opts...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// LoadAll benchmarks parsing data as a multi-document stream
+func LoadAll(data []byte, opts ...easyyaml.LoadOption) testing.BenchmarkResult {
+	return testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := easyyaml.LoadAll(data, opts...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+func BenchmarkLoad(b *testing.B) {
+	for _, corpus := range easyyaml.BenchmarkCorpus() {
+		b.Run(corpus.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(corpus.Data)))
+			for i := 0; i < b.N; i++ {
+				var err error
+				if corpus.Stream {
+					_, err = easyyaml.LoadAll(corpus.Data)
+				} else {
+					_, err = easyyaml.Load(corpus.Data)
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDump(b *testing.B) {
+	for _, corpus := range easyyaml.BenchmarkCorpus() {
+		if corpus.Stream {
+			docs, err := easyyaml.LoadAll(corpus.Data)
+			if err != nil {
+				b.Fatal(err)
+			}
+			// Touch every document so DumpAll cannot reuse the source bytes
+			docs.Each(func(i int, doc *easyyaml.YAMLValue) {
+				doc.Set("bench", i)
+			})
+			b.Run(corpus.Name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := docs.DumpAll(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			continue
+		}
+
+		doc, err := easyyaml.Load(corpus.Data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(corpus.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := doc.Dump(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPath(b *testing.B) {
+	doc, err := easyyaml.Load(deepCorpus(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	path := "level_0.level_1.level_2.level_3.level_4.level_5.enabled_6"
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			doc.Path(path)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		cached := doc.Clone()
+		cached.EnablePathCache()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cached.Path(path)
+		}
+	})
+}
+
+func BenchmarkGetPaths(b *testing.B) {
+	doc, err := easyyaml.Load(deepCorpus(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	prefix := "level_0.level_1.level_2.level_3.level_4.level_5."
+	paths := []string{prefix + "enabled_6", prefix + "timeout_6", prefix + "level_6.enabled_7", prefix + "level_6.timeout_7"}
+
+	b.Run("path", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, path := range paths {
+				doc.Path(path)
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			doc.GetPaths(paths...)
+		}
+	})
+}
+
+func TestCorpusLoads(t *testing.T) {
+	for _, corpus := range easyyaml.BenchmarkCorpus() {
+		if corpus.Stream {
+			docs, err := easyyaml.LoadAll(corpus.Data)
+			if err != nil || docs.Len() == 0 {
+				t.Errorf("%s: expected documents, got %v", corpus.Name, err)
+			}
+			continue
+		}
+		if _, err := easyyaml.Load(corpus.Data); err != nil {
+			t.Errorf("%s: %v", corpus.Name, err)
+		}
+	}
+
+	doc, err := easyyaml.Load(deepCorpus(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !doc.Path("level_0.level_1.level_2.level_3.level_4.level_5.enabled_6").AsBool() {
+		t.Error("Expected deep path to resolve")
+	}
+}
+
+// deepCorpus returns the deep-config corpus
+func deepCorpus(tb testing.TB) []byte {
+	for _, corpus := range easyyaml.BenchmarkCorpus() {
+		if corpus.Name == "deep-config" {
+			return corpus.Data
+		}
+	}
+	tb.Fatal("deep-config corpus not found")
+	return nil
+}
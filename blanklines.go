@@ -0,0 +1,106 @@
+package easyyaml
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeNode decodes a parsed document into generic data, recording the
+// paths of mapping entries that were preceded by a blank line in source
+func decodeNode(node *yaml.Node, source []byte) (interface{}, map[string]bool, error) {
+	if node.Kind == 0 {
+		return nil, nil, nil
+	}
+	var data interface{}
+	if err := node.Decode(&data); err != nil {
+		return nil, nil, err
+	}
+	return data, recordBlankLines(node, source), nil
+}
+
+// recordBlankLines returns the paths of mapping entries that are separated
+// from the previous line by a blank line, looking past head comments
+func recordBlankLines(node *yaml.Node, source []byte) map[string]bool {
+	if !bytes.Contains(source, []byte("\n\n")) && !bytes.Contains(source, []byte("\n\r\n")) {
+		return nil
+	}
+	lines := strings.Split(string(source), "\n")
+
+	var blanks map[string]bool
+	eachMappingKey(node, "", func(path string, key *yaml.Node) {
+		for i := key.Line - 2; i > 0; i-- {
+			text := strings.TrimSpace(lines[i])
+			if text == "" {
+				if blanks == nil {
+					blanks = make(map[string]bool)
+				}
+				blanks[path] = true
+				return
+			}
+			if !strings.HasPrefix(text, "#") {
+				return
+			}
+		}
+	})
+	return blanks
+}
+
+// eachMappingKey calls fn with the path and key node of every mapping entry
+func eachMappingKey(node *yaml.Node, path string, fn func(path string, key *yaml.Node)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			eachMappingKey(child, path, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			fn(keyPath, key)
+			eachMappingKey(value, keyPath, fn)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			eachMappingKey(child, joinPath(path, strconv.Itoa(i)), fn)
+		}
+	}
+}
+
+// insertBlankLines adds a blank line above each mapping entry of the
+// marshaled document out whose path is in blanks
+func insertBlankLines(out []byte, blanks map[string]bool) []byte {
+	if len(blanks) == 0 {
+		return out
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(out, &node); err != nil {
+		return out
+	}
+
+	var at []int
+	eachMappingKey(&node, "", func(path string, key *yaml.Node) {
+		if blanks[path] && key.Line > 1 {
+			at = append(at, key.Line-1)
+		}
+	})
+	if len(at) == 0 {
+		return out
+	}
+	sort.Ints(at)
+
+	lines := strings.SplitAfter(string(out), "\n")
+	var sb strings.Builder
+	next := 0
+	for i, line := range lines {
+		for next < len(at) && at[next] == i {
+			sb.WriteString("\n")
+			next++
+		}
+		sb.WriteString(line)
+	}
+	return []byte(sb.String())
+}
@@ -0,0 +1,51 @@
+package easyyaml
+
+import "testing"
+
+func TestBlankLinesPreserved(t *testing.T) {
+	input := `database:
+    host: localhost
+    port: 5432
+
+# HTTP settings
+server:
+    port: 80
+
+    tls: true
+`
+	yv, err := Loads(input)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	yv.SetPath("server.port", 8080)
+
+	out, err := yv.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps failed: %v", err)
+	}
+	expected := `database:
+    host: localhost
+    port: 5432
+
+server:
+    port: 8080
+
+    tls: true
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	docs, err := LoadAlls("a: 1\n\nb: 2\n---\nc: 3\n")
+	if err != nil {
+		t.Fatalf("Failed to load stream: %v", err)
+	}
+	docs.Get(0).Set("a", 10)
+	stream, err := docs.DumpAlls()
+	if err != nil {
+		t.Fatalf("DumpAlls failed: %v", err)
+	}
+	if stream != "a: 10\n\nb: 2\n---\nc: 3\n" {
+		t.Errorf("Expected blank line kept in modified document, got:\n%s", stream)
+	}
+}
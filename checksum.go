@@ -0,0 +1,178 @@
+package easyyaml
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChecksumKey is the top-level key AddChecksum stores the checksum under
+const ChecksumKey = "x-easyyaml-checksum"
+
+// Checksum returns a digest of the document's canonical form as
+// "algo:hex". algo is "sha256" or "sha512". The canonical form is YAML with
+// sorted keys and an explicit tag on every scalar, so formatting, comments
+// and key order do not affect it while types do: 1 and 1.0, or true and
+// "true", give different checksums. The top-level ChecksumKey and
+// SignatureKey are ignored.
+func (yv *YAMLValue) Checksum(algo string) (string, error) {
+	var h hash.Hash
+	switch algo {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum algorithm %q", algo)
+	}
+
+	canonical, err := yv.canonical(ChecksumKey, SignatureKey)
+	if err != nil {
+		return "", err
+	}
+	h.Write(canonical)
+	return algo + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// AddChecksum stores the document's checksum under ChecksumKey
+// Usage: generated.AddChecksum("sha256"); generated.DumpFile("out.yaml")
+func (yv *YAMLValue) AddChecksum(algo string) error {
+	sum, err := yv.Checksum(algo)
+	if err != nil {
+		return err
+	}
+	return yv.Set(ChecksumKey, sum)
+}
+
+// VerifyChecksum recomputes the checksum and compares it with the one under
+// ChecksumKey. It returns ErrKeyNotFound when there is none and
+// ErrChecksumMismatch when the document was changed after it was added.
+func (yv *YAMLValue) VerifyChecksum() error {
+	stored, ok := yv.Lookup(ChecksumKey)
+	if !ok {
+		return opError("verify checksum", ChecksumKey, ErrKeyNotFound, "")
+	}
+	algo, _, found := strings.Cut(stored.AsString(), ":")
+	if !found {
+		return fmt.Errorf("malformed checksum %q", stored.AsString())
+	}
+	sum, err := yv.Checksum(algo)
+	if err != nil {
+		return err
+	}
+	if sum != stored.AsString() {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, stored.AsString(), sum)
+	}
+	return nil
+}
+
+// canonical returns the document as YAML with sorted keys and explicitly
+// tagged scalars, leaving out the given top-level keys
+func (yv *YAMLValue) canonical(exclude ...string) ([]byte, error) {
+	root := canonicalNode(yv.data, exclude)
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build canonical form: %w", err)
+	}
+	return out, nil
+}
+
+// canonicalNode builds the canonical node tree of data. Keys of a top-level
+// map named in exclude are left out.
+func canonicalNode(data interface{}, exclude []string) *yaml.Node {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		pairs := make([][2]*yaml.Node, 0, len(v))
+		for k, val := range v {
+			if !containsString(exclude, k) {
+				pairs = append(pairs, [2]*yaml.Node{canonicalNode(k, nil), canonicalNode(val, nil)})
+			}
+		}
+		return canonicalMapping(pairs)
+	case map[interface{}]interface{}:
+		pairs := make([][2]*yaml.Node, 0, len(v))
+		for k, val := range v {
+			if s, ok := k.(string); ok && containsString(exclude, s) {
+				continue
+			}
+			pairs = append(pairs, [2]*yaml.Node{canonicalNode(k, nil), canonicalNode(val, nil)})
+		}
+		return canonicalMapping(pairs)
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			node.Content = append(node.Content, canonicalNode(item, nil))
+		}
+		return node
+	case *YAMLValue:
+		return canonicalNode(v.data, exclude)
+	case nil:
+		return canonicalScalar("!!null", "null")
+	case string:
+		return canonicalScalar("!!str", v)
+	case bool:
+		return canonicalScalar("!!bool", strconv.FormatBool(v))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return canonicalScalar("!!int", fmt.Sprintf("%d", v))
+	case float32:
+		return canonicalNode(float64(v), nil)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return canonicalScalar("!!float", ".nan")
+		case math.IsInf(v, 1):
+			return canonicalScalar("!!float", ".inf")
+		case math.IsInf(v, -1):
+			return canonicalScalar("!!float", "-.inf")
+		}
+		return canonicalScalar("!!float", strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		return canonicalScalar("!!timestamp", v.UTC().Format(time.RFC3339Nano))
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(data); err != nil {
+		return canonicalScalar("!!str", fmt.Sprintf("%v", data))
+	}
+	return node
+}
+
+// canonicalScalar returns a scalar node that always shows its tag
+func canonicalScalar(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, Style: yaml.TaggedStyle | yaml.DoubleQuotedStyle}
+}
+
+// canonicalMapping returns a mapping node of key and value pairs sorted by
+// key tag, then key value
+func canonicalMapping(pairs [][2]*yaml.Node) *yaml.Node {
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i][0], pairs[j][0]
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		return a.Value < b.Value
+	})
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, pair := range pairs {
+		node.Content = append(node.Content, pair[0], pair[1])
+	}
+	return node
+}
+
+// containsString checks if list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	a, _ := Loads("# generated\nname: app\nports: [80, 443]\n")
+	b, _ := Loads("ports:\n  - 80\n  - 443\nname: 'app'\n")
+
+	sumA, err := a.Checksum("sha256")
+	if err != nil {
+		t.Fatalf("Checksum failed: %v", err)
+	}
+	sumB, _ := b.Checksum("sha256")
+	if sumA != sumB || !strings.HasPrefix(sumA, "sha256:") {
+		t.Errorf("Expected equal canonical checksums, got %s and %s", sumA, sumB)
+	}
+	if _, err := a.Checksum("md5"); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+
+	if err := a.VerifyChecksum(); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+	if err := a.AddChecksum("sha512"); err != nil {
+		t.Fatalf("AddChecksum failed: %v", err)
+	}
+
+	out, _ := a.Dumps()
+	reloaded, _ := Loads(out)
+	if err := reloaded.VerifyChecksum(); err != nil {
+		t.Errorf("Expected checksum to verify after round trip, got %v", err)
+	}
+
+	reloaded.Set("name", "tampered")
+	if err := reloaded.VerifyChecksum(); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func TestChecksumTypes(t *testing.T) {
+	pairs := [][2]string{
+		{"a: 1\n", "a: 1.0\n"},
+		{"1: x\n", "'1': x\n"},
+		{"true: x\n", "'true': x\n"},
+		{"a: null\n", "a: 'null'\n"},
+	}
+	for _, pair := range pairs {
+		a, _ := Loads(pair[0])
+		b, _ := Loads(pair[1])
+		sumA, _ := a.Checksum("sha256")
+		sumB, _ := b.Checksum("sha256")
+		if sumA == sumB {
+			t.Errorf("Expected %q and %q to have different checksums", pair[0], pair[1])
+		}
+	}
+
+	mixed := &YAMLValue{data: map[interface{}]interface{}{1: "int", "1": "str", "nested": map[string]interface{}{"b": 2, "a": []interface{}{1.5, true}}}}
+	first, _ := mixed.Checksum("sha256")
+	for i := 0; i < 20; i++ {
+		if sum, _ := mixed.Checksum("sha256"); sum != first {
+			t.Fatalf("Expected a stable checksum for int and string keys, got %s and %s", first, sum)
+		}
+	}
+}
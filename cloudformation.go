@@ -0,0 +1,134 @@
+package easyyaml
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cfnFunctions maps the short-form tags of CloudFormation intrinsic
+// functions to their long-form keys
+var cfnFunctions = map[string]string{
+	"!Ref": "Ref", "!Condition": "Condition",
+	"!And": "Fn::And", "!Base64": "Fn::Base64", "!Cidr": "Fn::Cidr",
+	"!Equals": "Fn::Equals", "!FindInMap": "Fn::FindInMap", "!GetAtt": "Fn::GetAtt",
+	"!GetAZs": "Fn::GetAZs", "!If": "Fn::If", "!ImportValue": "Fn::ImportValue",
+	"!Join": "Fn::Join", "!Length": "Fn::Length", "!Not": "Fn::Not", "!Or": "Fn::Or",
+	"!Select": "Fn::Select", "!Split": "Fn::Split", "!Sub": "Fn::Sub",
+	"!ToJsonString": "Fn::ToJsonString", "!Transform": "Fn::Transform",
+}
+
+// Intrinsic builds a CloudFormation intrinsic function in short form, so
+// Intrinsic("GetAtt", "Role.Arn") dumps as !GetAtt Role.Arn. Tagged values
+// of loaded templates, such as !Ref and !Sub, are kept as they are without
+// it. It returns an error wrapping ErrKeyNotFound for an unknown function.
+// Usage: fn, _ := easyyaml.Intrinsic("Join", []interface{}{"-", []interface{}{"app", env}}); tmpl.SetPath("Resources.Bucket.Properties.BucketName", fn)
+func Intrinsic(name string, arg interface{}) (*YAMLValue, error) {
+	tag := "!" + strings.TrimPrefix(name, "Fn::")
+	if _, ok := cfnFunctions[tag]; !ok {
+		return nil, opError("intrinsic", name, ErrKeyNotFound, "unknown intrinsic function")
+	}
+	data, err := normalize(arg)
+	if err != nil {
+		return nil, err
+	}
+	fn := &YAMLValue{data: data, meta: &docMeta{}}
+	fn.meta.tags = map[string]string{"": tag}
+	return fn, nil
+}
+
+// ExpandIntrinsics returns a copy of a CloudFormation template with the
+// short-form intrinsic functions written in long form, e.g. !Ref Env as
+// {Ref: Env} and !GetAtt Role.Arn as {Fn::GetAtt: [Role, Arn]}, as JSON
+// templates and ToJSON need
+// Usage: expanded, err := tmpl.ExpandIntrinsics(); j, err := expanded.ToJSON()
+func (yv *YAMLValue) ExpandIntrinsics() (*YAMLValue, error) {
+	return yv.rewriteNodes(expandIntrinsic)
+}
+
+// CompactIntrinsics returns a copy of a CloudFormation template with the
+// long-form intrinsic functions written in short form, reversing
+// ExpandIntrinsics
+// Usage: compact, err := easyyaml.FromJSON(template); compact, err = compact.CompactIntrinsics()
+func (yv *YAMLValue) CompactIntrinsics() (*YAMLValue, error) {
+	return yv.rewriteNodes(compactIntrinsic)
+}
+
+// rewriteNodes encodes the value with its styles and tags, applies rewrite
+// to every node bottom-up and decodes the result
+func (yv *YAMLValue) rewriteNodes(rewrite func(*yaml.Node)) (*YAMLValue, error) {
+	node, err := encodeOrdered(yv.data, yv.options())
+	if err != nil {
+		return nil, err
+	}
+	if yv.meta != nil {
+		yv.meta.apply(node, yv.at)
+	}
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		for _, child := range n.Content {
+			walk(child)
+		}
+		rewrite(n)
+	}
+	walk(node)
+	rewritten, err := FromNode(node)
+	if err != nil {
+		return nil, err
+	}
+	rewritten.meta.node = nil
+	rewritten.opts = yv.opts
+	return rewritten, nil
+}
+
+// expandIntrinsic replaces a short-form intrinsic function node with its
+// long form
+func expandIntrinsic(n *yaml.Node) {
+	long, ok := cfnFunctions[n.Tag]
+	if !ok {
+		return
+	}
+	arg := *n
+	arg.Style &^= yaml.TaggedStyle
+	switch arg.Kind {
+	case yaml.ScalarNode:
+		arg.Tag = "!!str"
+		if long == "Fn::GetAtt" {
+			resource, attribute, _ := strings.Cut(arg.Value, ".")
+			arg = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle, Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: resource},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: attribute},
+			}}
+		}
+	case yaml.SequenceNode:
+		arg.Tag = "!!seq"
+	case yaml.MappingNode:
+		arg.Tag = "!!map"
+	}
+	*n = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: long},
+		&arg,
+	}}
+}
+
+// compactIntrinsic replaces a long-form intrinsic function node, a mapping
+// with a single function key, with its short form
+func compactIntrinsic(n *yaml.Node) {
+	if n.Kind != yaml.MappingNode || len(n.Content) != 2 || n.Content[0].Kind != yaml.ScalarNode {
+		return
+	}
+	for short, long := range cfnFunctions {
+		if n.Content[0].Value != long {
+			continue
+		}
+		arg := *n.Content[1]
+		if long == "Fn::GetAtt" && arg.Kind == yaml.SequenceNode && len(arg.Content) == 2 &&
+			arg.Content[0].Kind == yaml.ScalarNode && arg.Content[1].Kind == yaml.ScalarNode {
+			arg = yaml.Node{Kind: yaml.ScalarNode, Value: arg.Content[0].Value + "." + arg.Content[1].Value}
+		}
+		arg.Tag = short
+		arg.Style |= yaml.TaggedStyle
+		*n = arg
+		return
+	}
+}
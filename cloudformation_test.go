@@ -0,0 +1,92 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+const cfnTemplate = `Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Join ["-", [!Ref Env, !Sub "${AWS::AccountId}-logs"]]
+      Role: !GetAtt Role.Arn
+      Zone: !Select
+        - 0
+        - !GetAZs ''
+`
+
+func TestCloudFormationRoundTrip(t *testing.T) {
+	tmpl, err := Loads(cfnTemplate)
+	if err != nil {
+		t.Fatalf("Loads failed: %v", err)
+	}
+	if got := tmpl.Path("Resources.Bucket.Properties.Role").Tag(); got != "!GetAtt" {
+		t.Errorf("Expected !GetAtt, got %s", got)
+	}
+
+	other, _ := Loads("Properties:\n  Policy: !Ref PolicyArn\n")
+	tmpl.Path("Resources.Bucket").Merge(other)
+	tmpl.Path("Resources.Bucket.Properties").Update(other.Get("Properties"))
+	out, _ := tmpl.Dumps()
+	expected := `Resources:
+    Bucket:
+        Properties:
+            BucketName: !Join
+                - "-"
+                - - !Ref Env
+                  - !Sub "${AWS::AccountId}-logs"
+            Policy: !Ref PolicyArn
+            Role: !GetAtt Role.Arn
+            Zone: !Select
+                - 0
+                - !GetAZs ''
+        Type: AWS::S3::Bucket
+`
+	if out != expected {
+		t.Errorf("Expected tags kept through Merge and Update:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestExpandIntrinsics(t *testing.T) {
+	tmpl, _ := Loads(cfnTemplate)
+	expanded, err := tmpl.ExpandIntrinsics()
+	if err != nil {
+		t.Fatalf("ExpandIntrinsics failed: %v", err)
+	}
+	props := expanded.Path("Resources.Bucket.Properties")
+	if props.Path("BucketName.Fn::Join.1.0.Ref").AsString() != "Env" {
+		t.Errorf("Unexpected BucketName: %v", props.Get("BucketName").Raw())
+	}
+	if role := props.Path("Role.Fn::GetAtt"); role.Len() != 2 || role.Get(1).AsString() != "Arn" {
+		t.Errorf("Unexpected Role: %v", props.Get("Role").Raw())
+	}
+	if props.Path("Zone.Fn::Select.1.Fn::GetAZs").Raw() != "" || props.Get("Role").Tag() != "!!map" {
+		t.Errorf("Unexpected Zone: %v", props.Get("Zone").Raw())
+	}
+
+	compact, err := expanded.CompactIntrinsics()
+	if err != nil {
+		t.Fatalf("CompactIntrinsics failed: %v", err)
+	}
+	before, _ := tmpl.Dumps()
+	after, _ := compact.Dumps()
+	if before != after {
+		t.Errorf("Expected the compacted template to match the original:\n%s\ngot:\n%s", before, after)
+	}
+}
+
+func TestIntrinsic(t *testing.T) {
+	tmpl, _ := Loads("Outputs: {}\n")
+	fn, err := Intrinsic("Fn::GetAtt", "Role.Arn")
+	if err != nil {
+		t.Fatalf("Intrinsic failed: %v", err)
+	}
+	tmpl.SetPath("Outputs.Arn.Value", fn)
+	if out, _ := tmpl.Dumps(); out != "Outputs:\n    Arn:\n        Value: !GetAtt Role.Arn\n" {
+		t.Errorf("Unexpected output: %q", out)
+	}
+	if _, err := Intrinsic("Nope", "x"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,447 @@
+// Command easyyaml exposes the easyyaml library on the command line.
+//
+// Usage:
+//
+//	easyyaml get file.yaml server.port
+//	easyyaml set [-i] file.yaml server.port 9090
+//	easyyaml delete [-i] file.yaml server.debug
+//	easyyaml eval file.yaml '.spec.containers[].image'
+//	easyyaml merge base.yaml override.yaml
+//	easyyaml to-json file.yaml
+//	easyyaml from-json file.json
+//	easyyaml validate [-schema [kind=]schema.yaml]... file.yaml...
+//	easyyaml diff a.yaml b.yaml
+//	easyyaml pretty [-color] [-indent n] file.yaml
+//	easyyaml fmt [-w] [-indent n] [-doc-start] file.yaml...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/javanhut/easyjson"
+	"github.com/javanhut/easyyaml"
+)
+
+const usage = `usage: easyyaml <command> [arguments]
+
+commands:
+  get <file> <path>               print the value at a dot-separated path
+  set [-i] <file> <path> <value>  set a value (parsed as YAML) at a path
+  delete [-i] <file> <path>       remove the value at a path
+  eval <file> <expr>              evaluate a yq-style expression
+  merge <file> <file>...          deep-merge files left to right
+  to-json <file>                  convert YAML to JSON
+  from-json <file>                convert JSON to YAML
+  validate [-schema [kind=]<schema>]... <file>...
+                                  check that files parse as YAML and, with
+                                  -schema, match a JSON Schema; kind= picks
+                                  the schema by document kind
+  diff <file> <file>              show differing paths between two files
+  pretty [-color] [-indent n] <file>
+                                  print a file with optional syntax highlighting
+  fmt [-w] [-indent n] [-doc-start] <file>...
+                                  normalize formatting, preserving comments
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a command and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func([]string, io.Writer) error{
+		"get":       cmdGet,
+		"set":       cmdSet,
+		"delete":    cmdDelete,
+		"eval":      cmdEval,
+		"merge":     cmdMerge,
+		"to-json":   cmdToJSON,
+		"from-json": cmdFromJSON,
+		"validate":  cmdValidate,
+		"diff":      cmdDiff,
+		"pretty":    cmdPretty,
+		"fmt":       cmdFmt,
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "easyyaml: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	if err := cmd(args[1:], stdout); err != nil {
+		if err == errDiffFound {
+			return 1
+		}
+		fmt.Fprintf(stderr, "easyyaml %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// parseArgs parses command flags and checks the positional argument count
+func parseArgs(fs *flag.FlagSet, args []string, min int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() < min {
+		return nil, fmt.Errorf("expected at least %d arguments, got %d", min, fs.NArg())
+	}
+	return fs.Args(), nil
+}
+
+// writeYAML prints a value as YAML, or writes it back to file when inPlace is set
+func writeYAML(yv *easyyaml.YAMLValue, file string, inPlace bool, stdout io.Writer) error {
+	if inPlace {
+		return yv.DumpFile(file)
+	}
+	out, err := yv.Dumps()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(stdout, out)
+	return err
+}
+
+// printValue prints scalars plainly and composites as YAML
+func printValue(yv *easyyaml.YAMLValue, stdout io.Writer) error {
+	if yv.IsObject() || yv.IsArray() {
+		return writeYAML(yv, "", false, stdout)
+	}
+	if yv.IsNull() {
+		_, err := fmt.Fprintln(stdout, "null")
+		return err
+	}
+	_, err := fmt.Fprintln(stdout, yv.AsString())
+	return err
+}
+
+func cmdGet(args []string, stdout io.Writer) error {
+	args, err := parseArgs(flag.NewFlagSet("get", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	value := doc.Path(args[1])
+	if !value.Exists() {
+		return fmt.Errorf("path %q not found", args[1])
+	}
+	return printValue(value, stdout)
+}
+
+func cmdSet(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("set", flag.ContinueOnError)
+	inPlace := fs.Bool("i", false, "edit the file in place")
+	args, err := parseArgs(fs, args, 3)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	value, err := easyyaml.Loads(args[2])
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+	if err := doc.SetPath(args[1], value.Raw()); err != nil {
+		return err
+	}
+	return writeYAML(doc, args[0], *inPlace, stdout)
+}
+
+func cmdDelete(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	inPlace := fs.Bool("i", false, "edit the file in place")
+	args, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	parent := doc
+	key := args[1]
+	if idx := strings.LastIndex(args[1], "."); idx >= 0 {
+		parent = doc.Path(args[1][:idx])
+		key = args[1][idx+1:]
+	}
+	if parent.IsArray() {
+		var index int
+		if _, err := fmt.Sscanf(key, "%d", &index); err != nil {
+			return fmt.Errorf("invalid array index %q", key)
+		}
+		if err := parent.Delete(index); err != nil {
+			return err
+		}
+	} else if err := parent.Delete(key); err != nil {
+		return err
+	}
+	return writeYAML(doc, args[0], *inPlace, stdout)
+}
+
+func cmdEval(args []string, stdout io.Writer) error {
+	args, err := parseArgs(flag.NewFlagSet("eval", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	results, err := doc.Eval(args[1])
+	if err != nil {
+		return err
+	}
+	for _, value := range results.Values() {
+		if err := printValue(value, stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cmdMerge(args []string, stdout io.Writer) error {
+	args, err := parseArgs(flag.NewFlagSet("merge", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	merged, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	for _, file := range args[1:] {
+		other, err := easyyaml.LoadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := merged.Merge(other); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return writeYAML(merged, "", false, stdout)
+}
+
+func cmdToJSON(args []string, stdout io.Writer) error {
+	args, err := parseArgs(flag.NewFlagSet("to-json", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	jv, err := doc.ToJSON()
+	if err != nil {
+		return err
+	}
+	out, err := jv.DumpsIndent("  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, out)
+	return err
+}
+
+func cmdFromJSON(args []string, stdout io.Writer) error {
+	args, err := parseArgs(flag.NewFlagSet("from-json", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	jv, err := easyjson.Load(data)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.FromJSON(jv)
+	if err != nil {
+		return err
+	}
+	return writeYAML(doc, "", false, stdout)
+}
+
+func cmdValidate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	registry := easyyaml.NewSchemaRegistry()
+	var fallback *easyyaml.YAMLValue
+	kinds := 0
+	fs.Func("schema", "JSON Schema file, or kind=file to apply it by document kind (repeatable)", func(value string) error {
+		kind, file, keyed := strings.Cut(value, "=")
+		if !keyed {
+			file = value
+		}
+		schema, err := easyyaml.LoadFile(file)
+		if err != nil {
+			return err
+		}
+		if keyed {
+			registry.Register(kind, schema)
+			kinds++
+		} else {
+			fallback = schema
+		}
+		return nil
+	})
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	validate := func(doc *easyyaml.YAMLValue) error {
+		if schema, _, ok := registry.Lookup(doc); ok {
+			return doc.Validate(schema)
+		}
+		if fallback != nil {
+			return doc.Validate(fallback)
+		}
+		if kinds > 0 {
+			return registry.Validate(doc)
+		}
+		return nil
+	}
+	failed := 0
+	for _, file := range args {
+		docs, err := easyyaml.LoadAllFile(file)
+		if err == nil {
+			for i, doc := range docs.All() {
+				if err = validate(doc); err != nil {
+					if docs.Len() > 1 {
+						err = fmt.Errorf("document %d: %w", i, err)
+					}
+					break
+				}
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", file, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: ok\n", file)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files invalid", failed, len(args))
+	}
+	return nil
+}
+
+func cmdPretty(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("pretty", flag.ContinueOnError)
+	color := fs.Bool("color", false, "highlight syntax with ANSI colors")
+	indent := fs.Int("indent", 2, "spaces per indentation level")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	doc, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	return doc.Pretty(stdout, easyyaml.PrettyOptions{Color: *color, Indent: *indent})
+}
+
+func cmdFmt(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	write := fs.Bool("w", false, "write result back to the source files")
+	indent := fs.Int("indent", 2, "spaces per indentation level")
+	docStart := fs.Bool("doc-start", false, "emit a leading --- marker for every document")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	opts := easyyaml.FormatOptions{Indent: *indent, DocumentStart: *docStart}
+	for _, file := range args {
+		if *write {
+			if err := easyyaml.FormatFile(file, opts); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			continue
+		}
+		input, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		out, err := easyyaml.Format(input, opts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if _, err := stdout.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errDiffFound signals that diff found differences, mirroring diff(1)'s exit status
+var errDiffFound = fmt.Errorf("files differ")
+
+func cmdDiff(args []string, stdout io.Writer) error {
+	args, err := parseArgs(flag.NewFlagSet("diff", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	left, err := easyyaml.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+	right, err := easyyaml.LoadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	leftLeaves := leaves(left)
+	rightLeaves := leaves(right)
+
+	differ := false
+	for _, path := range leftLeaves.Paths() {
+		l := left.Path(path)
+		r := right.Path(path)
+		if !r.Exists() {
+			fmt.Fprintf(stdout, "- %s: %s\n", path, l.AsString())
+			differ = true
+		} else if r.IsObject() || r.IsArray() || l.AsString() != r.AsString() {
+			fmt.Fprintf(stdout, "~ %s: %s -> %s\n", path, l.AsString(), strings.TrimSpace(r.String()))
+			differ = true
+		}
+	}
+	for _, path := range rightLeaves.Paths() {
+		l := left.Path(path)
+		if !l.Exists() || ((l.IsObject() || l.IsArray()) && l.Len() > 0) {
+			fmt.Fprintf(stdout, "+ %s: %s\n", path, right.Path(path).AsString())
+			differ = true
+		}
+	}
+
+	if differ {
+		return errDiffFound
+	}
+	return nil
+}
+
+// leaves returns every scalar or empty collection in a document
+func leaves(doc *easyyaml.YAMLValue) *easyyaml.Results {
+	return doc.FindAll(func(path string, value *easyyaml.YAMLValue) bool {
+		if path == "" {
+			return false
+		}
+		return (!value.IsObject() && !value.IsArray()) || value.Len() == 0
+	})
+}
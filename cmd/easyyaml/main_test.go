@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func runCmd(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGetSetDelete(t *testing.T) {
+	file := writeTemp(t, "config.yaml", "server:\n  host: localhost\n  port: 8080\ntags:\n  - a\n  - b\n")
+
+	code, out, _ := runCmd("get", file, "server.port")
+	if code != 0 || out != "8080\n" {
+		t.Errorf("get returned %d %q", code, out)
+	}
+
+	if code, _, _ := runCmd("get", file, "server.missing"); code == 0 {
+		t.Error("Expected get on missing path to fail")
+	}
+
+	if code, _, _ := runCmd("set", "-i", file, "server.port", "9090"); code != 0 {
+		t.Fatalf("set returned %d", code)
+	}
+	if _, out, _ := runCmd("get", file, "server.port"); out != "9090\n" {
+		t.Errorf("Expected port 9090 after set, got %q", out)
+	}
+
+	if code, _, _ := runCmd("delete", "-i", file, "tags.0"); code != 0 {
+		t.Fatalf("delete returned %d", code)
+	}
+	if _, out, _ := runCmd("get", file, "tags"); out != "- b\n" {
+		t.Errorf("Expected remaining tag 'b', got %q", out)
+	}
+}
+
+func TestMergeAndDiff(t *testing.T) {
+	base := writeTemp(t, "base.yaml", "server:\n  host: localhost\n  port: 8080\n")
+	override := writeTemp(t, "override.yaml", "server:\n  port: 9090\n")
+
+	code, out, _ := runCmd("merge", base, override)
+	if code != 0 || !strings.Contains(out, "host: localhost") || !strings.Contains(out, "port: 9090") {
+		t.Errorf("merge returned %d %q", code, out)
+	}
+
+	code, out, _ = runCmd("diff", base, override)
+	if code != 1 {
+		t.Errorf("Expected diff to exit 1, got %d", code)
+	}
+	if !strings.Contains(out, "~ server.port: 8080 -> 9090") || !strings.Contains(out, "- server.host: localhost") {
+		t.Errorf("Unexpected diff output %q", out)
+	}
+
+	if code, _, _ := runCmd("diff", base, base); code != 0 {
+		t.Errorf("Expected identical files to exit 0, got %d", code)
+	}
+}
+
+func TestJSONConversion(t *testing.T) {
+	yamlFile := writeTemp(t, "data.yaml", "name: app\nport: 8080\n")
+	code, out, _ := runCmd("to-json", yamlFile)
+	if code != 0 || !strings.Contains(out, `"name": "app"`) {
+		t.Errorf("to-json returned %d %q", code, out)
+	}
+
+	jsonFile := writeTemp(t, "data.json", `{"name": "app"}`)
+	code, out, _ = runCmd("from-json", jsonFile)
+	if code != 0 || out != "name: app\n" {
+		t.Errorf("from-json returned %d %q", code, out)
+	}
+}
+
+func TestValidateAndUnknown(t *testing.T) {
+	good := writeTemp(t, "good.yaml", "a: 1\n")
+	bad := writeTemp(t, "bad.yaml", "a: [1\n")
+
+	if code, _, _ := runCmd("validate", good); code != 0 {
+		t.Errorf("Expected valid file to pass, got %d", code)
+	}
+	if code, _, _ := runCmd("validate", good, bad); code != 1 {
+		t.Errorf("Expected invalid file to fail, got %d", code)
+	}
+	if code, _, _ := runCmd("bogus"); code != 2 {
+		t.Errorf("Expected unknown command to exit 2, got %d", code)
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	schema := writeTemp(t, "schema.yaml", "type: object\nrequired: [port]\nproperties:\n  port: {type: integer}\n")
+	svcSchema := writeTemp(t, "svc.yaml", "type: object\nrequired: [spec]\n")
+	good := writeTemp(t, "good.yaml", "port: 80\n")
+	bad := writeTemp(t, "bad.yaml", "port: http\n")
+	stream := writeTemp(t, "stream.yaml", "kind: Service\nspec: {}\n---\nkind: Service\n")
+
+	if code, out, _ := runCmd("validate", "-schema", schema, good); code != 0 {
+		t.Errorf("Expected a matching file to pass, got %d %q", code, out)
+	}
+	code, out, _ := runCmd("validate", "--schema", schema, good, bad)
+	if code != 1 || !strings.Contains(out, "bad.yaml: ") || !strings.Contains(out, "port") {
+		t.Errorf("Expected a schema violation for bad.yaml, got %d %q", code, out)
+	}
+	code, out, _ = runCmd("validate", "-schema", "Service="+svcSchema, stream)
+	if code != 1 || !strings.Contains(out, "document 1") {
+		t.Errorf("Expected the second document to fail its kind's schema, got %d %q", code, out)
+	}
+}
+
+func TestFmt(t *testing.T) {
+	file := writeTemp(t, "config.yaml", "# comment\na:\n    b: 'x'\n")
+
+	code, out, _ := runCmd("fmt", "-doc-start", file)
+	if code != 0 || out != "---\n# comment\na:\n  b: x\n" {
+		t.Errorf("fmt returned %d %q", code, out)
+	}
+
+	if code, _, _ := runCmd("fmt", "-w", file); code != 0 {
+		t.Fatalf("fmt -w returned %d", code)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "# comment\na:\n  b: x\n" {
+		t.Errorf("Unexpected formatted file %q", data)
+	}
+}
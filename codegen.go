@@ -0,0 +1,161 @@
+package easyyaml
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GoStructOptions controls the output of GenerateGoStruct
+type GoStructOptions struct {
+	// Package emits a package clause when set
+	Package string
+	// Inline nests object types as anonymous structs instead of named types
+	Inline bool
+	// OmitEmpty adds ",omitempty" to every yaml tag
+	OmitEmpty bool
+}
+
+// commonInitialisms are rendered in upper case in generated field names
+var commonInitialisms = map[string]bool{
+	"API": true, "CPU": true, "DNS": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "TCP": true, "TLS": true,
+	"TTL": true, "UDP": true, "UI": true, "URI": true, "URL": true,
+	"UUID": true, "YAML": true,
+}
+
+// GenerateGoStruct emits gofmt-ed Go struct definitions with yaml tags
+// matching the document's shape, rooted at a type called name
+// Usage: src, err := data.GenerateGoStruct("Config", easyyaml.GoStructOptions{})
+func (yv *YAMLValue) GenerateGoStruct(name string, opts GoStructOptions) (string, error) {
+	if !yv.IsObject() {
+		return "", fmt.Errorf("can only generate structs from an object")
+	}
+
+	g := &goStructGenerator{opts: opts, used: make(map[string]bool)}
+	g.used[name] = true
+	g.typeFor(name, inferSchema(yv.data), true)
+
+	var sb strings.Builder
+	if opts.Package != "" {
+		fmt.Fprintf(&sb, "package %s\n\n", opts.Package)
+	}
+	for i := len(g.decls) - 1; i >= 0; i-- {
+		sb.WriteString(g.decls[i])
+		sb.WriteString("\n")
+	}
+
+	src, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return string(src), nil
+}
+
+type goStructGenerator struct {
+	opts  GoStructOptions
+	used  map[string]bool
+	decls []string
+}
+
+// typeFor returns the Go type for a schema, declaring named structs as needed
+func (g *goStructGenerator) typeFor(name string, schema map[string]interface{}, root bool) string {
+	t, _ := schema["type"].(string)
+	switch t {
+	case "object":
+		body := g.structBody(name, schema)
+		if g.opts.Inline && !root {
+			return body
+		}
+		if !root {
+			name = g.uniqueName(name)
+		}
+		g.decls = append(g.decls, fmt.Sprintf("type %s %s\n", name, body))
+		return name
+	case "array":
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return "[]interface{}"
+		}
+		return "[]" + g.typeFor(name+"Item", items, false)
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "interface{}"
+}
+
+// structBody renders the fields of an object schema as a struct literal type
+func (g *goStructGenerator) structBody(name string, schema map[string]interface{}) string {
+	props, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("struct {\n")
+	fieldNames := make(map[string]bool)
+	for _, key := range keys {
+		field := goFieldName(key)
+		for base, n := field, 2; fieldNames[field]; n++ {
+			field = fmt.Sprintf("%s%d", base, n)
+		}
+		fieldNames[field] = true
+
+		fieldType := g.typeFor(name+field, props[key].(map[string]interface{}), false)
+		tag := key
+		if g.opts.OmitEmpty {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&sb, "%s %s `yaml:%q`\n", field, fieldType, tag)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// uniqueName returns name, or name with a numeric suffix if already declared
+func (g *goStructGenerator) uniqueName(name string) string {
+	candidate := name
+	for n := 2; g.used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s%d", name, n)
+	}
+	g.used[candidate] = true
+	return candidate
+}
+
+// goFieldName converts a YAML key such as "max_items" or "api-url" into an
+// exported Go identifier such as "MaxItems" or "APIURL"
+func goFieldName(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+	for _, word := range words {
+		if commonInitialisms[strings.ToUpper(word)] {
+			sb.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	name := sb.String()
+	if name == "" {
+		return "Field"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "F" + name
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateGoStruct(t *testing.T) {
+	yv, err := Loads(`
+app_name: demo
+api-url: http://localhost
+server:
+  port: 8080
+  ratio: 0.5
+users:
+  - id: 1
+    name: alice
+`)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	src, err := yv.GenerateGoStruct("Config", GoStructOptions{Package: "config"})
+	if err != nil {
+		t.Fatalf("Failed to generate struct: %v", err)
+	}
+
+	expected := []string{
+		"package config",
+		"type Config struct {",
+		"APIURL  string            `yaml:\"api-url\"`",
+		"AppName string            `yaml:\"app_name\"`",
+		"Server  ConfigServer      `yaml:\"server\"`",
+		"Users   []ConfigUsersItem `yaml:\"users\"`",
+		"type ConfigServer struct {",
+		"Ratio float64 `yaml:\"ratio\"`",
+		"type ConfigUsersItem struct {",
+		"ID   int    `yaml:\"id\"`",
+	}
+	for _, e := range expected {
+		if !strings.Contains(src, e) {
+			t.Errorf("Expected generated code to contain %q, got:\n%s", e, src)
+		}
+	}
+
+	if strings.Index(src, "type Config struct") > strings.Index(src, "type ConfigServer struct") {
+		t.Error("Expected root type to be declared first")
+	}
+}
+
+func TestGenerateGoStructInline(t *testing.T) {
+	yv, err := Loads("server:\n  port: 8080\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	src, err := yv.GenerateGoStruct("Config", GoStructOptions{Inline: true, OmitEmpty: true})
+	if err != nil {
+		t.Fatalf("Failed to generate struct: %v", err)
+	}
+
+	if strings.Contains(src, "ConfigServer") {
+		t.Errorf("Expected nested struct to be inlined, got:\n%s", src)
+	}
+	if !strings.Contains(src, "`yaml:\"port,omitempty\"`") {
+		t.Errorf("Expected omitempty tag, got:\n%s", src)
+	}
+
+	if _, err := NewArray().GenerateGoStruct("Config", GoStructOptions{}); err == nil {
+		t.Error("Expected error for non-object value")
+	}
+}
@@ -0,0 +1,173 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CoerceTypes converts string scalars to the types declared by a JSON
+// Schema, e.g. "8080" to an integer where the schema says "type: integer", or
+// a "format: date-time" string to a time.Time. It follows properties,
+// additionalProperties and items, converts everything it can and returns a
+// *MultiError listing each value that could not be coerced, with its path.
+// Usage: err := cfg.CoerceTypes(schema)
+func (yv *YAMLValue) CoerceTypes(schema *YAMLValue) error {
+	logMutation("coerce_types")
+	if err := yv.checkFrozen("coerce_types", nil); err != nil {
+		return err
+	}
+	yv.invalidate()
+	c := &ErrorCollector{}
+	yv.data = coerceData(yv.data, schema, "", c)
+	return c.Err()
+}
+
+// CoerceTypeMap is like CoerceTypes with a simple map from dot-separated
+// paths, which may use "*" wildcards as in Query, to type names: "integer",
+// "number", "boolean", "string", "null", "date" or "date-time"
+// Usage: cfg.CoerceTypeMap(map[string]string{"server.port": "integer", "features.*": "boolean"})
+func (yv *YAMLValue) CoerceTypeMap(types map[string]string) error {
+	logMutation("coerce_types", "paths", len(types))
+	if err := yv.checkFrozen("coerce_types", nil); err != nil {
+		return err
+	}
+	c := &ErrorCollector{}
+	for _, pattern := range sortedStrings(types) {
+		typ, format := types[pattern], ""
+		if typ == "date" || typ == "date-time" {
+			typ, format = "string", types[pattern]
+		}
+		yv.Query(pattern).Each(func(path string, value *YAMLValue) {
+			coerced, err := coerceScalar(value.data, typ, format)
+			if err != nil {
+				c.Add("coerce", path, err)
+				return
+			}
+			if err := yv.SetPath(path, coerced); err != nil {
+				c.Add("coerce", path, err)
+			}
+		})
+	}
+	return c.Err()
+}
+
+// coerceData converts data to match schema, recording failures in c
+func coerceData(data interface{}, schema *YAMLValue, path string, c *ErrorCollector) interface{} {
+	schema = schemaVariant(schema)
+	value := &YAMLValue{data: data}
+
+	switch typ := schemaType(schema); {
+	case typ == "object" && value.IsObject():
+		properties := schema.Get("properties")
+		additional := schema.Get("additionalProperties")
+		for _, item := range value.ItemsOrdered() {
+			key := fmt.Sprintf("%v", item.Key)
+			child, ok := properties.Lookup(key)
+			if !ok {
+				if !additional.IsObject() {
+					continue
+				}
+				child = additional
+			}
+			value.set(item.Key, coerceData(item.Value.data, child, joinPath(path, key), c))
+		}
+		return value.data
+	case typ == "array" && value.IsArray():
+		items := schema.Get("items")
+		if !items.IsObject() {
+			return data
+		}
+		arr := value.data.([]interface{})
+		for i, element := range arr {
+			arr[i] = coerceData(element, items, joinPath(path, strconv.Itoa(i)), c)
+		}
+		return arr
+	case typ == "" || typ == "object" || typ == "array":
+		return data
+	default:
+		coerced, err := coerceScalar(data, typ, schema.Get("format").AsString())
+		if err != nil {
+			c.Add("coerce", path, err)
+			return data
+		}
+		return coerced
+	}
+}
+
+// coerceScalar converts a string scalar to typ, or checks that a non-string
+// value already has it. Nulls are left alone.
+func coerceScalar(data interface{}, typ, format string) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	str, isString := data.(string)
+	if !isString {
+		if scalarMatches(data, typ) {
+			return data, nil
+		}
+		if value := (&YAMLValue{data: data}); typ == "string" && !value.IsObject() && !value.IsArray() {
+			return value.AsString(), nil
+		}
+		return nil, fmt.Errorf("%w: cannot coerce %s to %s", ErrTypeMismatch, typeName(data), typ)
+	}
+
+	trimmed := strings.TrimSpace(str)
+	switch typ {
+	case "string":
+		switch format {
+		case "date-time":
+			if t, err := time.Parse(time.RFC3339Nano, trimmed); err == nil {
+				return t, nil
+			}
+			return nil, fmt.Errorf("%w: %q is not an RFC 3339 date-time", ErrTypeMismatch, str)
+		case "date":
+			if t, err := time.Parse(time.DateOnly, trimmed); err == nil {
+				return t, nil
+			}
+			return nil, fmt.Errorf("%w: %q is not a date", ErrTypeMismatch, str)
+		}
+		return str, nil
+	case "integer":
+		if n, err := strconv.Atoi(trimmed); err == nil {
+			return n, nil
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return f, nil
+		}
+	case "boolean":
+		switch strings.ToLower(trimmed) {
+		case "true", "yes", "on", "1":
+			return true, nil
+		case "false", "no", "off", "0":
+			return false, nil
+		}
+	case "null":
+		switch strings.ToLower(trimmed) {
+		case "", "~", "null":
+			return nil, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	return nil, fmt.Errorf("%w: cannot coerce %q to %s", ErrTypeMismatch, str, typ)
+}
+
+// scalarMatches checks if a non-string scalar already has the JSON Schema type
+func scalarMatches(data interface{}, typ string) bool {
+	actual := scalarSchemaType(data)
+	return actual == typ || typ == "number" && actual == "integer"
+}
+
+// sortedStrings returns the keys of m in sorted order
+func sortedStrings(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
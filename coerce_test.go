@@ -0,0 +1,97 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCoerceTypes(t *testing.T) {
+	schema, _ := Loads(`
+type: object
+properties:
+  port: {type: integer}
+  ratio: {type: number}
+  debug: {type: boolean}
+  version: {type: string}
+  released: {type: string, format: date}
+  hosts:
+    type: array
+    items: {type: string}
+  limits:
+    type: object
+    additionalProperties: {type: integer}
+`)
+	yv, _ := Loads(`
+port: "8080"
+ratio: "0.5"
+debug: "yes"
+version: 2
+released: "2024-03-01"
+hosts: [a, 1]
+limits:
+  cpu: "4"
+  memory: lots
+`)
+
+	err := yv.CoerceTypes(schema)
+	if yv.Get("port").Raw() != 8080 {
+		t.Errorf("Expected port 8080, got %#v", yv.Get("port").Raw())
+	}
+	if yv.Get("ratio").Raw() != 0.5 || yv.Get("debug").Raw() != true {
+		t.Errorf("Expected coerced ratio and debug, got %v", yv.Raw())
+	}
+	if yv.Get("version").Raw() != "2" || yv.Path("hosts.1").Raw() != "1" {
+		t.Errorf("Expected numbers coerced to strings, got %v", yv.Raw())
+	}
+	if released, ok := yv.Get("released").Raw().(time.Time); !ok || released.Month() != time.March {
+		t.Errorf("Expected a date, got %#v", yv.Get("released").Raw())
+	}
+	if yv.Path("limits.cpu").Raw() != 4 {
+		t.Errorf("Expected additionalProperties to coerce, got %#v", yv.Path("limits.cpu").Raw())
+	}
+
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 1 {
+		t.Fatalf("Expected one coercion error, got %v", err)
+	}
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+	if yv.Path("limits.memory").AsString() != "lots" {
+		t.Errorf("Expected failed value to be left alone, got %v", yv.Path("limits.memory").Raw())
+	}
+}
+
+func TestCoerceTypeMap(t *testing.T) {
+	yv, _ := Loads(`
+server:
+  port: "8080"
+features:
+  a: "true"
+  b: "off"
+created: "2024-03-01T10:00:00Z"
+`)
+
+	err := yv.CoerceTypeMap(map[string]string{
+		"server.port": "integer",
+		"features.*":  "boolean",
+		"created":     "date-time",
+	})
+	if err != nil {
+		t.Fatalf("CoerceTypeMap failed: %v", err)
+	}
+	if yv.Path("server.port").Raw() != 8080 {
+		t.Errorf("Expected port 8080, got %#v", yv.Path("server.port").Raw())
+	}
+	if yv.Path("features.a").Raw() != true || yv.Path("features.b").Raw() != false {
+		t.Errorf("Expected coerced booleans, got %v", yv.Get("features").Raw())
+	}
+	if _, ok := yv.Get("created").Raw().(time.Time); !ok {
+		t.Errorf("Expected a timestamp, got %#v", yv.Get("created").Raw())
+	}
+
+	if err := yv.CoerceTypeMap(map[string]string{"server.port": "boolean"}); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+}
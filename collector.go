@@ -0,0 +1,73 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorCollector gathers every problem found by a batch operation, each
+// with the path it occurred at, instead of stopping at the first. A nil
+// *ErrorCollector makes operations stop at the first error as usual.
+// Usage: c := &easyyaml.ErrorCollector{}; base.MergeCollect(overlay, c); err := c.Err()
+type ErrorCollector struct {
+	errs []error
+}
+
+// Add records err for path as an *OpError. An *OpError passed in is
+// re-keyed to path rather than nested. The root path "" is recorded with a
+// nil Key.
+func (c *ErrorCollector) Add(op string, path string, err error) {
+	if err == nil {
+		return
+	}
+	var opErr *OpError
+	if errors.As(err, &opErr) {
+		err = opErr.Err
+	}
+	var key interface{}
+	if path != "" {
+		key = path
+	}
+	c.errs = append(c.errs, &OpError{Op: op, Key: key, Err: err})
+}
+
+// Len returns the number of collected errors
+func (c *ErrorCollector) Len() int {
+	return len(c.errs)
+}
+
+// Errors returns the collected errors in the order they were found
+func (c *ErrorCollector) Errors() []error {
+	errs := make([]error, len(c.errs))
+	copy(errs, c.errs)
+	return errs
+}
+
+// Err returns nil if nothing was collected, or a *MultiError holding every
+// collected error
+func (c *ErrorCollector) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: c.Errors()}
+}
+
+// MultiError is a list of errors reported together. errors.Is and errors.As
+// match against every error in the list.
+type MultiError struct {
+	Errors []error
+}
+
+// Error implements error, listing one error per line
+func (e *MultiError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the collected errors
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
@@ -0,0 +1,53 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMergeCollect(t *testing.T) {
+	base, err := Loads("name: app\nserver:\n  port: 80\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	// Integer keys cannot go into the string-keyed maps produced by Load
+	overlay := New(map[interface{}]interface{}{
+		1:      "one",
+		"name": "web",
+		"server": map[interface{}]interface{}{
+			2:      "two",
+			"port": 8080,
+		},
+	})
+
+	if err := base.Merge(overlay); err == nil {
+		t.Fatal("Expected Merge to fail on the first bad key")
+	}
+
+	base, _ = Loads("name: app\nserver:\n  port: 80\n")
+	c := &ErrorCollector{}
+	err = base.MergeCollect(overlay, c)
+	if c.Len() != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", c.Len(), err)
+	}
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected MultiError to match ErrTypeMismatch, got %v", err)
+	}
+	var opErr *OpError
+	if !errors.As(c.Errors()[1], &opErr) || opErr.Key != "server.2" {
+		t.Errorf("Expected error at server.2, got %v", c.Errors()[1])
+	}
+	if !strings.Contains(err.Error(), "merge 1:") || !strings.Contains(err.Error(), "merge server.2:") {
+		t.Errorf("Expected every path in the message, got:\n%s", err)
+	}
+
+	// Valid keys are still merged
+	if base.Get("name").AsString() != "web" || base.Path("server.port").AsInt() != 8080 {
+		t.Errorf("Expected valid keys to merge, got %v", base.Raw())
+	}
+
+	if err := (&ErrorCollector{}).Err(); err != nil {
+		t.Errorf("Expected nil error from empty collector, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package easyyaml
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
+
+// isGzipName checks if a file name has a .gz extension, e.g. "out.yaml.gz"
+func isGzipName(filename string) bool {
+	return strings.HasSuffix(filename, ".gz")
+}
+
+// decompress returns data unchanged, or gunzipped if it starts with the
+// gzip magic number. Concatenated gzip members are read as one stream.
+func decompress(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// compressFor gzips data when filename has a .gz extension
+func compressFor(filename string, data []byte) ([]byte, error) {
+	if !isGzipName(filename) {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readFile reads a file, transparently decompressing gzip content
+func readFile(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(data)
+}
+
+// writeFile writes a file, gzipping it when the name ends in .gz
+func writeFile(filename string, data []byte) error {
+	data, err := compressFor(filename, data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
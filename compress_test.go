@@ -0,0 +1,40 @@
+package easyyaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGzipFiles(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yaml.gz")
+
+	if err := Object(KV("name", "app")).DumpFile(filename); err != nil {
+		t.Fatalf("DumpFile failed: %v", err)
+	}
+	raw, _ := os.ReadFile(filename)
+	if len(raw) < 2 || raw[0] != 0x1f || raw[1] != 0x8b {
+		t.Fatalf("Expected gzip output, got %q", raw)
+	}
+
+	yv, err := LoadFile(filename)
+	if err != nil || yv.Get("name").AsString() != "app" {
+		t.Fatalf("Expected to load gzip file, got %v", err)
+	}
+
+	// Gzip content is detected by its header, whatever the file name
+	renamed := filepath.Join(dir, "config.yaml")
+	os.Rename(filename, renamed)
+	if yv, err := LoadFileLocked(renamed); err != nil || yv.Get("name").AsString() != "app" {
+		t.Errorf("Expected to detect gzip content, got %v", err)
+	}
+
+	stream := filepath.Join(dir, "events.yaml.gz")
+	Object(KV("id", 1)).AppendFile(stream)
+	Object(KV("id", 2)).AppendFile(stream)
+	docs, err := LoadAllFile(stream)
+	if err != nil || docs.Len() != 2 || docs.Get(1).Get("id").AsInt() != 2 {
+		t.Errorf("Expected 2 appended gzip documents, got %v", err)
+	}
+}
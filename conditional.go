@@ -0,0 +1,36 @@
+package easyyaml
+
+// SetIfAbsent sets a dot-separated path with SetPath unless it already
+// exists, and reports whether it did. A key holding an explicit null
+// exists and is left alone.
+// Usage: added, err := cfg.SetIfAbsent("server.port", 8080)
+func (yv *YAMLValue) SetIfAbsent(path string, value interface{}) (bool, error) {
+	if yv.Path(path).Exists() {
+		return false, nil
+	}
+	if err := yv.SetPath(path, value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompareAndSet sets a dot-separated path to value only if it currently
+// holds a value equal to old, and reports whether it did. Values are
+// compared after normalizing old as Set would, and numbers by value, so
+// an int matches the same float. A missing path never matches; nil
+// matches an explicit null. It returns false when old cannot be
+// normalized or the set fails, e.g. on a frozen document. It is not
+// atomic: like the rest of YAMLValue it is not safe for concurrent use,
+// so goroutines sharing a document must hold their own lock around it.
+// Usage: if !cfg.CompareAndSet("spec.replicas", 3, 5) { /* edited by hand; keep that value */ }
+func (yv *YAMLValue) CompareAndSet(path string, old, value interface{}) bool {
+	current := yv.Path(path)
+	if !current.Exists() {
+		return false
+	}
+	expected, err := normalize(old)
+	if err != nil || !valuesEqual(jsonCompatible(current.data), jsonCompatible(expected)) {
+		return false
+	}
+	return yv.SetPath(path, value) == nil
+}
@@ -0,0 +1,42 @@
+package easyyaml
+
+import "testing"
+
+func TestSetIfAbsent(t *testing.T) {
+	yv, _ := Loads("server:\n  host: localhost\n  proxy: null\n")
+	if added, err := yv.SetIfAbsent("server.port", 8080); !added || err != nil {
+		t.Errorf("Expected port to be added, got %v, %v", added, err)
+	}
+	if added, err := yv.SetIfAbsent("server.host", "example.com"); added || err != nil {
+		t.Errorf("Expected host to be kept, got %v, %v", added, err)
+	}
+	if added, _ := yv.SetIfAbsent("server.proxy", "squid"); added {
+		t.Error("Expected explicit null to count as present")
+	}
+	if yv.Path("server.port").AsInt() != 8080 || yv.Path("server.host").AsString() != "localhost" {
+		t.Errorf("Unexpected document: %s", yv)
+	}
+	yv.Freeze()
+	if added, err := yv.SetIfAbsent("server.tls", true); added || err == nil {
+		t.Error("Expected an error on a frozen document")
+	}
+}
+
+func TestCompareAndSet(t *testing.T) {
+	yv, _ := Loads("spec:\n  replicas: 3\n  labels: {app: web}\n  paused: null\n")
+	if !yv.CompareAndSet("spec.replicas", 3.0, 5) || yv.Path("spec.replicas").AsInt() != 5 {
+		t.Error("Expected replicas to be swapped")
+	}
+	if yv.CompareAndSet("spec.replicas", 3, 7) || yv.Path("spec.replicas").AsInt() != 5 {
+		t.Error("Expected a stale compare to fail")
+	}
+	if !yv.CompareAndSet("spec.labels", map[string]string{"app": "web"}, map[string]string{"app": "api"}) {
+		t.Error("Expected objects to compare equal")
+	}
+	if !yv.CompareAndSet("spec.paused", nil, true) || !yv.Path("spec.paused").AsBool() {
+		t.Error("Expected nil to match an explicit null")
+	}
+	if yv.CompareAndSet("spec.missing", nil, 1) || yv.Path("spec.missing").Exists() {
+		t.Error("Expected a missing path not to match")
+	}
+}
@@ -0,0 +1,89 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Mismatch is a place where a document does not have the structure of a
+// shape, as reported by Conforms
+type Mismatch struct {
+	// Path is the dot-separated path of the value
+	Path string
+	// Expected is the type the shape has there, e.g. "integer" or "object"
+	Expected string
+	// Actual is the type the document has there, or "missing"
+	Actual string
+}
+
+// String formats the mismatch as "path: expected X, got Y"
+func (m Mismatch) String() string {
+	path := m.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", path, m.Expected, m.Actual)
+}
+
+// Conforms checks that the value has at least the structure of shape, whose
+// values are exemplars of the types expected, and returns the mismatches in
+// path order, or nil if there are none. Every key of a shape object must be
+// present with a conforming value; other keys are allowed. Every item of an
+// array must conform to the first item of the shape's array, and an empty
+// shape array accepts any items. A null in the shape accepts any value,
+// reported as "any" when the key is missing, an integer accepts only
+// integers and a float accepts any number.
+// Usage: shape, _ := easyyaml.Loads("id: 1\nname: x\ntags: [x]"); if m := resp.Conforms(shape); m != nil { t.Errorf("%v", m) }
+func (yv *YAMLValue) Conforms(shape *YAMLValue) []Mismatch {
+	var mismatches []Mismatch
+	conforms(yv, shape, "", &mismatches)
+	return mismatches
+}
+
+// conforms checks value against shape at path, appending mismatches
+func conforms(value, shape *YAMLValue, path string, mismatches *[]Mismatch) {
+	expected, actual := exemplarType(shape.data), shapeType(value.data)
+	switch {
+	case expected == "any":
+		return
+	case expected == "number" && actual == "integer":
+	case expected != actual:
+		*mismatches = append(*mismatches, Mismatch{Path: path, Expected: expected, Actual: actual})
+		return
+	}
+	switch {
+	case shape.IsObject():
+		for _, item := range shape.ItemsOrdered() {
+			keyPath := joinPath(path, fmt.Sprintf("%v", item.Key))
+			child, ok := value.Lookup(item.Key)
+			if !ok {
+				*mismatches = append(*mismatches, Mismatch{Path: keyPath, Expected: exemplarType(item.Value.data), Actual: "missing"})
+				continue
+			}
+			conforms(child, item.Value, keyPath, mismatches)
+		}
+	case shape.IsArray() && shape.Len() > 0:
+		exemplar := shape.Get(0)
+		for i, item := range value.AsArray() {
+			conforms(item, exemplar, joinPath(path, strconv.Itoa(i)), mismatches)
+		}
+	}
+}
+
+// shapeType names the type of a raw value, telling integers apart from
+// other numbers
+func shapeType(v interface{}) string {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "integer"
+	}
+	return typeName(v)
+}
+
+// exemplarType names the type a shape value expects, "any" for null
+func exemplarType(v interface{}) string {
+	if v == nil {
+		return "any"
+	}
+	return shapeType(v)
+}
@@ -0,0 +1,57 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConforms(t *testing.T) {
+	shape, _ := Loads(`id: 1
+name: x
+score: 1.5
+extra: null
+owner:
+  email: x
+tags: [x]
+items:
+  - sku: x
+    qty: 1
+`)
+	doc, _ := Loads(`id: 42
+name: widget
+score: 3
+extra: [anything]
+unknown: allowed
+owner:
+  email: a@example.com
+tags: [a, b]
+items:
+  - sku: A1
+    qty: 2
+  - sku: B2
+    qty: "3"
+`)
+	if m := doc.Conforms(shape); m != nil {
+		if len(m) != 1 || m[0] != (Mismatch{Path: "items.1.qty", Expected: "integer", Actual: "string"}) {
+			t.Errorf("Unexpected mismatches: %v", m)
+		}
+	} else {
+		t.Error("Expected a mismatch for items.1.qty")
+	}
+
+	bad, _ := Loads("id: 1.5\nname: [x]\ntags: x\nitems: []\n")
+	expected := []Mismatch{
+		{Path: "extra", Expected: "any", Actual: "missing"},
+		{Path: "id", Expected: "integer", Actual: "number"},
+		{Path: "name", Expected: "string", Actual: "array"},
+		{Path: "owner", Expected: "object", Actual: "missing"},
+		{Path: "score", Expected: "number", Actual: "missing"},
+		{Path: "tags", Expected: "array", Actual: "string"},
+	}
+	if got := bad.Conforms(shape); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := New("x").Conforms(shape); len(got) != 1 || got[0].String() != "(root): expected object, got string" {
+		t.Errorf("Unexpected root mismatch: %v", got)
+	}
+}
@@ -0,0 +1,134 @@
+package easyyaml
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// ConstrainWith validates the value against the structure of a Go type,
+// given as a value or pointer of that type. Field names follow yaml tags
+// (or yaml.v3's lower-cased default), unknown fields are rejected and fields
+// tagged validate:"required" must be present. Violations are reported like
+// Validate's.
+// Usage: err := cfg.ConstrainWith(ServerConfig{})
+func (yv *YAMLValue) ConstrainWith(example interface{}) error {
+	return yv.ConstrainWithContext(context.Background(), example)
+}
+
+// SchemaFromStruct derives the JSON Schema used by ConstrainWith from the
+// type of example
+func SchemaFromStruct(example interface{}) *YAMLValue {
+	return &YAMLValue{data: typeSchema(reflect.TypeOf(example), map[reflect.Type]bool{})}
+}
+
+// typeSchema builds the schema for t; seen guards against recursive types
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t, nullable = t.Elem(), true
+	}
+
+	var schema map[string]interface{}
+	switch {
+	case t == timeType:
+		schema = map[string]interface{}{}
+	case t == durationType:
+		schema = map[string]interface{}{"type": []interface{}{"string", "integer"}}
+	default:
+		switch t.Kind() {
+		case reflect.Bool:
+			schema = map[string]interface{}{"type": "boolean"}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			schema = map[string]interface{}{"type": "integer"}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schema = map[string]interface{}{"type": "integer", "minimum": 0}
+		case reflect.Float32, reflect.Float64:
+			schema = map[string]interface{}{"type": "number"}
+		case reflect.String:
+			schema = map[string]interface{}{"type": "string"}
+		case reflect.Slice, reflect.Array:
+			schema = map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), seen)}
+		case reflect.Map:
+			schema = map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+		case reflect.Struct:
+			if seen[t] {
+				return map[string]interface{}{}
+			}
+			seen[t] = true
+			schema = structSchema(t, seen)
+			delete(seen, t)
+		default:
+			schema = map[string]interface{}{}
+		}
+	}
+
+	if typ, ok := schema["type"].(string); ok && nullable {
+		schema["type"] = []interface{}{typ, "null"}
+	}
+	return schema
+}
+
+// structSchema builds an object schema from the exported fields of t,
+// flattening fields tagged ",inline"
+func structSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []interface{}
+
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" && !field.Anonymous {
+				continue
+			}
+			tag := field.Tag.Get("yaml")
+			if tag == "-" {
+				continue
+			}
+			name, flags, _ := strings.Cut(tag, ",")
+			if strings.Contains(flags, "inline") {
+				ft := field.Type
+				for ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					addFields(ft)
+				}
+				continue
+			}
+			if field.PkgPath != "" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			properties[name] = typeSchema(field.Type, seen)
+			for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+				if rule == "required" {
+					required = append(required, name)
+				}
+			}
+		}
+	}
+	addFields(t)
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,62 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type constrainTLS struct {
+	Cert string `yaml:"cert" validate:"required"`
+	Key  string `yaml:"key"`
+}
+
+type constrainBase struct {
+	Name string `yaml:"name" validate:"required"`
+}
+
+type constrainConfig struct {
+	constrainBase `yaml:",inline"`
+	Port          uint16            `yaml:"port" validate:"required"`
+	Timeout       time.Duration     `yaml:"timeout"`
+	Ratio         float64           `yaml:"ratio"`
+	Hosts         []string          `yaml:"hosts"`
+	Labels        map[string]string `yaml:"labels"`
+	TLS           *constrainTLS     `yaml:"tls"`
+	Debug         bool
+	Ignored       string `yaml:"-"`
+	internal      string
+}
+
+func TestConstrainWith(t *testing.T) {
+	valid, _ := Loads(`
+name: api
+port: 8080
+timeout: 5s
+ratio: 1
+hosts: [a, b]
+labels: {team: x}
+tls: null
+debug: true
+`)
+	if err := valid.ConstrainWith(&constrainConfig{}); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+
+	invalid, _ := Loads(`
+port: -1
+hosts: [a, 2]
+tls: {key: k}
+Ignored: x
+`)
+	expected := []string{
+		"validate name: schema violation: required property is missing",
+		"validate Ignored: schema violation: additional property is not allowed",
+		"validate hosts.1: schema violation: expected string, got integer",
+		"validate port: schema violation: -1 is less than the minimum 0",
+		"validate tls.cert: schema violation: required property is missing",
+	}
+	if err := invalid.ConstrainWith(constrainConfig{}); err == nil || err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%v", strings.Join(expected, "\n"), err)
+	}
+}
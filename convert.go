@@ -0,0 +1,82 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ToMap returns an object as a deep copy built only from
+// map[string]interface{}, []interface{} and scalars, so it can be handed to
+// other libraries without sharing the document's data. Keys are formatted
+// as strings; it returns an error wrapping ErrKeyExists if two keys format
+// the same, such as 1 and "1", and one wrapping ErrTypeMismatch if the
+// value is not an object.
+// Usage: m, err := cfg.ToMap(); tmpl.Execute(w, m)
+func (yv *YAMLValue) ToMap() (map[string]interface{}, error) {
+	if !yv.IsObject() {
+		return nil, fmt.Errorf("%w: cannot convert %s to map", ErrTypeMismatch, typeName(yv.data))
+	}
+	plain, err := toPlain(yv.data, "")
+	if err != nil {
+		return nil, err
+	}
+	return plain.(map[string]interface{}), nil
+}
+
+// ToSlice returns an array as a deep copy built the same way as ToMap
+// Usage: items, err := cfg.Get("items").ToSlice()
+func (yv *YAMLValue) ToSlice() ([]interface{}, error) {
+	if !yv.IsArray() {
+		return nil, fmt.Errorf("%w: cannot convert %s to slice", ErrTypeMismatch, typeName(yv.data))
+	}
+	plain, err := toPlain(yv.data, "")
+	if err != nil {
+		return nil, err
+	}
+	return plain.([]interface{}), nil
+}
+
+// toPlain deep-copies data into string-keyed maps, slices and scalars
+func toPlain(data interface{}, path string) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			plain, err := toPlain(val, joinPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			m[k] = plain
+		}
+		return m, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			key := fmt.Sprintf("%v", k)
+			if _, dup := m[key]; dup {
+				return nil, opError("convert", joinPath(path, key), ErrKeyExists, "keys of different types format the same")
+			}
+			plain, err := toPlain(val, joinPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			m[key] = plain
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			plain, err := toPlain(val, joinPath(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			s[i] = plain
+		}
+		return s, nil
+	case []byte:
+		return append([]byte(nil), v...), nil
+	case *YAMLValue:
+		return toPlain(v.data, path)
+	}
+	return data, nil
+}
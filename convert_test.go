@@ -0,0 +1,56 @@
+package easyyaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestToMap(t *testing.T) {
+	yv, _ := Loads("name: app\n8080: http\nnested:\n  list: [1, {a: b}]\n")
+	m, err := yv.ToMap()
+	if err != nil {
+		t.Fatalf("ToMap failed: %v", err)
+	}
+	expected := map[string]interface{}{
+		"name":   "app",
+		"8080":   "http",
+		"nested": map[string]interface{}{"list": []interface{}{1, map[string]interface{}{"a": "b"}}},
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("Expected %v, got %v", expected, m)
+	}
+
+	m["nested"].(map[string]interface{})["list"].([]interface{})[0] = 99
+	m["name"] = "changed"
+	if yv.Path("nested.list.0").AsInt() != 1 || yv.Get("name").AsString() != "app" {
+		t.Error("Expected ToMap to return a copy")
+	}
+
+	if _, err := yv.Get("name").ToMap(); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for a string, got %v", err)
+	}
+	clash := New(map[interface{}]interface{}{1: "a", "1": "b"})
+	if _, err := clash.ToMap(); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Expected ErrKeyExists for clashing keys, got %v", err)
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	yv, _ := Loads("- {1: one}\n- [x]\n- 3\n")
+	s, err := yv.ToSlice()
+	if err != nil {
+		t.Fatalf("ToSlice failed: %v", err)
+	}
+	expected := []interface{}{map[string]interface{}{"1": "one"}, []interface{}{"x"}, 3}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("Expected %v, got %v", expected, s)
+	}
+	s[1].([]interface{})[0] = "y"
+	if yv.Path("1.0").AsString() != "x" {
+		t.Error("Expected ToSlice to return a copy")
+	}
+	if _, err := NewObject().ToSlice(); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for an object, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Corpus is a named, generated YAML input used to measure performance
+type Corpus struct {
+	// Name identifies the corpus, e.g. "k8s-bundle"
+	Name string
+	// Stream reports whether Data holds several documents for LoadAll
+	Stream bool
+	// Data is the YAML input
+	Data []byte
+}
+
+// BenchmarkCorpus returns the inputs used by the bench package: a large
+// Kubernetes manifest bundle, a deeply nested config and a wide array. The
+// data is generated deterministically, so results are comparable across runs.
+func BenchmarkCorpus() []Corpus {
+	return []Corpus{
+		{Name: "k8s-bundle", Stream: true, Data: k8sBundleCorpus(500)},
+		{Name: "deep-config", Data: deepConfigCorpus(64)},
+		{Name: "wide-array", Data: wideArrayCorpus(10000)},
+	}
+}
+
+// k8sBundleCorpus generates a stream of n Deployment manifests
+func k8sBundleCorpus(n int) []byte {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: service-%d
+  namespace: production
+  labels:
+    app: service-%d
+    tier: backend
+spec:
+  replicas: %d
+  selector:
+    matchLabels:
+      app: service-%d
+  template:
+    spec:
+      containers:
+        - name: app
+          image: registry.example.com/service-%d:1.%d.0
+          ports:
+            - containerPort: 8080
+              protocol: TCP
+          env:
+            - name: LOG_LEVEL
+              value: info
+            - name: REGION
+              value: eu-west-1
+          resources:
+            limits:
+              cpu: 500m
+              memory: 256Mi
+`, i, i, i%5+1, i, i, i%10)
+	}
+	return []byte(sb.String())
+}
+
+// deepConfigCorpus generates a config nested depth levels deep
+func deepConfigCorpus(depth int) []byte {
+	var sb strings.Builder
+	for i := 0; i < depth; i++ {
+		indent := strings.Repeat("  ", i)
+		fmt.Fprintf(&sb, "%senabled_%d: true\n%stimeout_%d: %ds\n%slevel_%d:\n", indent, i, indent, i, i, indent, i)
+	}
+	fmt.Fprintf(&sb, "%sleaf: value\n", strings.Repeat("  ", depth))
+	return []byte(sb.String())
+}
+
+// wideArrayCorpus generates an object holding an array of n small records
+func wideArrayCorpus(n int) []byte {
+	var sb strings.Builder
+	sb.WriteString("items:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "  - {id: %d, name: item-%d, active: %t, score: %d.5}\n", i, i, i%2 == 0, i%100)
+	}
+	return []byte(sb.String())
+}
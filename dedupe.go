@@ -0,0 +1,140 @@
+package easyyaml
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DumpDeduped is like Dump but writes each object or array that occurs more
+// than once, and has at least minNodes nodes, only at its first occurrence
+// with an anchor and as an alias everywhere else. Loading the output gives
+// back the same data.
+// Usage: out, err := bundle.DumpDeduped(8)
+func (yv *YAMLValue) DumpDeduped(minNodes int) ([]byte, error) {
+	start := time.Now()
+	opts := yv.options()
+	node, err := encodeOrdered(yv.data, opts)
+	var out []byte
+	if err == nil {
+		dedupeNode(node, minNodes)
+		out, err = marshalIndent(node, opts.Indent)
+	}
+	if err == nil {
+		if opts.AlignValues {
+			out = alignValues(out)
+		}
+		out = yv.directives.prepend(insertBlankLines(out, yv.blanks))
+	}
+	recordMetrics(OpDump, 1, len(out), start, err)
+	logError("easyyaml: dump failed", err)
+	return out, err
+}
+
+// DumpsDeduped is like DumpDeduped but returns a string
+func (yv *YAMLValue) DumpsDeduped(minNodes int) (string, error) {
+	out, err := yv.DumpDeduped(minNodes)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// dedupeNode anchors the first occurrence of each repeated collection of at
+// least minNodes nodes and turns later occurrences into aliases
+func dedupeNode(root *yaml.Node, minNodes int) {
+	hashes := make(map[*yaml.Node][32]byte)
+	sizes := make(map[*yaml.Node]int)
+	counts := make(map[[32]byte]int)
+	hashNode(root, hashes, sizes, counts)
+
+	// Decide in document order, skipping the insides of aliased subtrees,
+	// which are never written
+	firsts := make(map[[32]byte]*yaml.Node)
+	names := make(map[*yaml.Node]string)
+	aliases := make(map[*yaml.Node]*yaml.Node)
+	used := make(map[*yaml.Node]bool)
+	var order, aliased []*yaml.Node
+	var visit func(n *yaml.Node, name string)
+	visit = func(n *yaml.Node, name string) {
+		if n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode {
+			if h := hashes[n]; sizes[n] >= minNodes && counts[h] > 1 {
+				if first, ok := firsts[h]; ok {
+					aliases[n] = first
+					aliased = append(aliased, n)
+					used[first] = true
+					return
+				}
+				firsts[h] = n
+				names[n] = name
+				order = append(order, n)
+			}
+		}
+		switch n.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range n.Content {
+				visit(child, name)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				visit(n.Content[i+1], n.Content[i].Value)
+			}
+		}
+	}
+	visit(root, "")
+
+	taken := make(map[string]bool)
+	for _, n := range order {
+		if used[n] {
+			n.Anchor = anchorName(names[n], taken)
+		}
+	}
+	for _, n := range aliased {
+		first := aliases[n]
+		*n = yaml.Node{Kind: yaml.AliasNode, Alias: first, Value: first.Anchor}
+	}
+}
+
+// hashNode computes a structural hash and node count for every node under n
+func hashNode(n *yaml.Node, hashes map[*yaml.Node][32]byte, sizes map[*yaml.Node]int, counts map[[32]byte]int) [32]byte {
+	h := sha256.New()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(n.Kind))
+	h.Write(buf[:])
+	fmt.Fprintf(h, "%d:%s%d:%s", len(n.Tag), n.Tag, len(n.Value), n.Value)
+	size := 1
+	for _, child := range n.Content {
+		sum := hashNode(child, hashes, sizes, counts)
+		h.Write(sum[:])
+		size += sizes[child]
+	}
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	hashes[n] = sum
+	sizes[n] = size
+	counts[sum]++
+	return sum
+}
+
+// anchorName derives an unused anchor name from a mapping key
+func anchorName(key string, taken map[string]bool) string {
+	base := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+	if base == "" {
+		base = "ref"
+	}
+	name := base
+	for i := 2; taken[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	taken[name] = true
+	return name
+}
@@ -0,0 +1,55 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDumpDeduped(t *testing.T) {
+	yv, _ := Loads(`
+web:
+  resources: {limits: {cpu: 1, memory: 1Gi}, requests: {cpu: 1, memory: 1Gi}}
+  ports: [80]
+worker:
+  resources: {limits: {cpu: 1, memory: 1Gi}, requests: {cpu: 1, memory: 1Gi}}
+  ports: [81]
+batch:
+  resources: {limits: {cpu: 1, memory: 1Gi}, requests: {cpu: 1, memory: 1Gi}}
+`)
+
+	out, err := yv.WithIndent(2).DumpsDeduped(5)
+	if err != nil {
+		t.Fatalf("DumpsDeduped failed: %v", err)
+	}
+	expected := `batch:
+  resources: &resources
+    limits: &limits
+      cpu: 1
+      memory: 1Gi
+    requests: *limits
+web:
+  ports:
+    - 80
+  resources: *resources
+worker:
+  ports:
+    - 81
+  resources: *resources
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	again, err := Loads(out)
+	if err != nil {
+		t.Fatalf("Deduped output does not parse: %v", err)
+	}
+	if !reflect.DeepEqual(again.Raw(), yv.Raw()) {
+		t.Errorf("Expected the same data back, got %v", again.Raw())
+	}
+
+	out, _ = yv.WithIndent(2).DumpsDeduped(100)
+	if plain, _ := yv.WithIndent(2).Dumps(); out != plain {
+		t.Errorf("Expected no anchors above the size limit, got:\n%s", out)
+	}
+}
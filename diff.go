@@ -0,0 +1,170 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffOp is one line of an edit script: ' ' kept, '-' removed, '+' added
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// maxDiffCells caps the size of the LCS table diffLines builds for the
+// changed region; larger regions are diffed as a whole replacement
+const maxDiffCells = 1 << 22
+
+// diffLines computes an edit script turning lines x into lines y, based on
+// their longest common subsequence
+func diffLines(x, y []string) []diffOp {
+	// Strip the common prefix and suffix so the quadratic table only covers
+	// the changed region
+	var head, tail []diffOp
+	for len(x) > 0 && len(y) > 0 && x[0] == y[0] {
+		head = append(head, diffOp{' ', x[0]})
+		x, y = x[1:], y[1:]
+	}
+	for len(x) > 0 && len(y) > 0 && x[len(x)-1] == y[len(y)-1] {
+		tail = append([]diffOp{{' ', x[len(x)-1]}}, tail...)
+		x, y = x[:len(x)-1], y[:len(y)-1]
+	}
+
+	if len(x)*len(y) > maxDiffCells {
+		ops := head
+		for _, line := range x {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range y {
+			ops = append(ops, diffOp{'+', line})
+		}
+		return append(ops, tail...)
+	}
+
+	// lcs[i][j] is the LCS length of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := head
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			ops = append(ops, diffOp{' ', x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', x[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		ops = append(ops, diffOp{'-', x[i]})
+	}
+	for ; j < len(y); j++ {
+		ops = append(ops, diffOp{'+', y[j]})
+	}
+	return append(ops, tail...)
+}
+
+// splitLines splits text into lines without their newlines
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// lineDiff returns the lines removed from a ("-") and added in b ("+")
+func lineDiff(a, b string) string {
+	var sb strings.Builder
+	for _, op := range diffLines(splitLines(a), splitLines(b)) {
+		if op.kind != ' ' {
+			sb.WriteString(string(op.kind) + op.line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// unifiedDiff returns a unified diff from a to b with three lines of
+// context, or "" when they are equal
+func unifiedDiff(a, b, nameA, nameB string) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+	const context = 3
+
+	var sb strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are at most 2*context lines apart
+		last := start
+		for k := start; k < len(ops) && k-last <= 2*context; k++ {
+			if ops[k].kind != ' ' {
+				last = k
+			}
+		}
+		lo := max(start-context, 0)
+		hi := min(last+1+context, len(ops))
+
+		// Line numbers of the hunk start in a and b
+		lineA, lineB := 1, 1
+		for _, op := range ops[:lo] {
+			if op.kind != '+' {
+				lineA++
+			}
+			if op.kind != '-' {
+				lineB++
+			}
+		}
+		countA, countB := 0, 0
+		for _, op := range ops[lo:hi] {
+			if op.kind != '+' {
+				countA++
+			}
+			if op.kind != '-' {
+				countB++
+			}
+		}
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", nameA, nameB)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(lineA, countA), hunkRange(lineB, countB))
+		for _, op := range ops[lo:hi] {
+			sb.WriteString(string(op.kind) + op.line + "\n")
+		}
+		start = hi
+	}
+	return sb.String()
+}
+
+// hunkRange formats the start,count of a unified diff hunk header
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
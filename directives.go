@@ -0,0 +1,119 @@
+package easyyaml
+
+import (
+	"bytes"
+	"strings"
+)
+
+// TagDirective is a %TAG directive mapping a handle such as "!e!" to a prefix
+type TagDirective struct {
+	Handle string
+	Prefix string
+}
+
+// Directives holds the %YAML and %TAG directives of a document
+type Directives struct {
+	// Version is the %YAML version, e.g. "1.2", or empty for none
+	Version string
+	// Tags lists %TAG directives in declaration order
+	Tags []TagDirective
+}
+
+// Directives returns a copy of the document's directives
+func (yv *YAMLValue) Directives() Directives {
+	if yv.directives == nil {
+		return Directives{}
+	}
+	d := Directives{Version: yv.directives.Version}
+	d.Tags = append(d.Tags, yv.directives.Tags...)
+	return d
+}
+
+// SetVersion sets the %YAML version directive emitted on dump, or removes
+// it when version is empty
+func (yv *YAMLValue) SetVersion(version string) {
+	if yv.directives == nil {
+		yv.directives = &Directives{}
+	}
+	yv.directives.Version = version
+}
+
+// SetTagHandle adds or replaces a %TAG directive emitted on dump
+func (yv *YAMLValue) SetTagHandle(handle, prefix string) {
+	if yv.directives == nil {
+		yv.directives = &Directives{}
+	}
+	for i, tag := range yv.directives.Tags {
+		if tag.Handle == handle {
+			yv.directives.Tags[i].Prefix = prefix
+			return
+		}
+	}
+	yv.directives.Tags = append(yv.directives.Tags, TagDirective{Handle: handle, Prefix: prefix})
+}
+
+// ClearDirectives removes all directives from the document
+func (yv *YAMLValue) ClearDirectives() {
+	yv.directives = nil
+}
+
+// isEmpty checks if there are no directives to emit
+func (d *Directives) isEmpty() bool {
+	return d == nil || (d.Version == "" && len(d.Tags) == 0)
+}
+
+// prepend adds the directive lines and a document start marker to dumped YAML
+func (d *Directives) prepend(body []byte) []byte {
+	if d.isEmpty() {
+		return body
+	}
+
+	var buf bytes.Buffer
+	if d.Version != "" {
+		buf.WriteString("%YAML " + d.Version + "\n")
+	}
+	for _, tag := range d.Tags {
+		buf.WriteString("%TAG " + tag.Handle + " " + tag.Prefix + "\n")
+	}
+	buf.WriteString("---\n")
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// extractDirectives reads the directives preceding the first document. The
+// %YAML line is blanked out before parsing because the underlying parser
+// only accepts version 1.1; %TAG lines are left for the parser to resolve.
+func extractDirectives(input []byte) ([]byte, *Directives) {
+	if !bytes.Contains(input, []byte("%")) {
+		return input, nil
+	}
+
+	var directives *Directives
+	lines := bytes.SplitAfter(input, []byte("\n"))
+	for i, line := range lines {
+		text := strings.TrimSpace(string(line))
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if !strings.HasPrefix(text, "%") {
+			break
+		}
+		if directives == nil {
+			directives = &Directives{}
+		}
+
+		fields := strings.Fields(text)
+		switch {
+		case fields[0] == "%YAML" && len(fields) >= 2:
+			directives.Version = fields[1]
+			lines[i] = []byte("\n")
+		case fields[0] == "%TAG" && len(fields) >= 3:
+			directives.Tags = append(directives.Tags, TagDirective{Handle: fields[1], Prefix: fields[2]})
+		}
+	}
+
+	if directives == nil {
+		return input, nil
+	}
+	return bytes.Join(lines, nil), directives
+}
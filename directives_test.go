@@ -0,0 +1,57 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDirectivesRoundTrip(t *testing.T) {
+	input := "%YAML 1.2\n%TAG !e! tag:example.com,2000:\n---\nname: app\n"
+
+	yv, err := Loads(input)
+	if err != nil {
+		t.Fatalf("Failed to load YAML with directives: %v", err)
+	}
+
+	d := yv.Directives()
+	if d.Version != "1.2" {
+		t.Errorf("Expected version 1.2, got %q", d.Version)
+	}
+	if len(d.Tags) != 1 || d.Tags[0].Handle != "!e!" || d.Tags[0].Prefix != "tag:example.com,2000:" {
+		t.Errorf("Unexpected tag directives: %v", d.Tags)
+	}
+
+	out, err := yv.Dumps()
+	if err != nil {
+		t.Fatalf("Failed to dump YAML: %v", err)
+	}
+	if out != input {
+		t.Errorf("Expected round trip to preserve directives, got:\n%s", out)
+	}
+
+	if cloned, _ := yv.Clone().Dumps(); cloned != input {
+		t.Errorf("Expected clone to keep directives, got:\n%s", cloned)
+	}
+}
+
+func TestSetDirectives(t *testing.T) {
+	yv := NewObject()
+	yv.Set("a", 1)
+
+	yv.SetVersion("1.2")
+	yv.SetTagHandle("!x!", "tag:one:")
+	yv.SetTagHandle("!x!", "tag:two:")
+
+	out, err := yv.Dumps()
+	if err != nil {
+		t.Fatalf("Failed to dump YAML: %v", err)
+	}
+	if !strings.HasPrefix(out, "%YAML 1.2\n%TAG !x! tag:two:\n---\n") {
+		t.Errorf("Unexpected directives in output:\n%s", out)
+	}
+
+	yv.ClearDirectives()
+	if out, _ := yv.Dumps(); out != "a: 1\n" {
+		t.Errorf("Expected no directives after clearing, got:\n%s", out)
+	}
+}
@@ -0,0 +1,329 @@
+package easyyaml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Documents holds the documents of a multi-document YAML stream
+type Documents struct {
+	docs []*YAMLValue
+
+	// Original stream layout, kept when loaded so DumpAll can reproduce
+	// unchanged documents and the comments between documents exactly
+	prefix     string
+	directives Directives
+	layouts    []*docLayout
+
+	warnings []Warning
+}
+
+// NewDocuments creates a Documents container from the given documents
+func NewDocuments(docs ...*YAMLValue) *Documents {
+	return &Documents{docs: append([]*YAMLValue{}, docs...)}
+}
+
+// LoadAll parses every document in a YAML stream
+func LoadAll(yamlBytes []byte, opts ...LoadOption) (*Documents, error) {
+	return LoadAllContext(context.Background(), yamlBytes, opts...)
+}
+
+// loadAll implements LoadAll without tracing
+func loadAll(yamlBytes []byte, opts Options) (docs *Documents, err error) {
+	if opts.Recover {
+		defer recoverError(&err)
+	}
+	start := time.Now()
+	var interned interner
+	if opts.Intern {
+		interned = interner{}
+	}
+	normalized, err := normalizeEncoding(yamlBytes, opts.StrictEncoding)
+	if err != nil {
+		recordMetrics(OpParse, 0, len(yamlBytes), start, err)
+		logError("easyyaml: parse failed", err, parseLogArgs(opts.sourceName, len(yamlBytes))...)
+		return nil, err
+	}
+	yamlBytes, warnings := repairTabs(normalized, opts.TabWidth)
+	stripped, directives := extractDirectives(yamlBytes)
+
+	dec := yaml.NewDecoder(bytes.NewReader(stripped))
+	docs = &Documents{warnings: warnings}
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var data interface{}
+		var blanks map[string]bool
+		var found []Warning
+		if err == nil {
+			found = inspectNode(&node, opts)
+			data, blanks, err = decodeNode(&node, stripped)
+		}
+		if err == nil && opts.Strict {
+			err = checkStringKeys(data)
+		}
+		if err != nil {
+			err = fmt.Errorf("document %d: %w", len(docs.docs), err)
+			recordMetrics(OpParse, len(docs.docs), len(yamlBytes), start, err)
+			logError("easyyaml: parse failed", err, parseLogArgs(opts.sourceName, len(yamlBytes))...)
+			return nil, err
+		}
+		if interned != nil {
+			data = interned.internAll(data)
+		}
+		doc := &YAMLValue{data: data, blanks: blanks, warnings: found, foldKeys: opts.FoldKeys, coerceKeys: opts.CoerceKeys, meta: recordMeta(&node)}
+		if opts.Provenance {
+			doc.origins = recordOrigins(&node, opts.sourceName)
+		}
+		if opts.KeepNode {
+			doc.meta.node = &node
+		}
+		docs.docs = append(docs.docs, doc)
+	}
+	recordMetrics(OpParse, len(docs.docs), len(yamlBytes), start, nil)
+
+	if len(docs.docs) > 0 {
+		docs.docs[0].directives = directives
+		docs.directives = docs.docs[0].Directives()
+	}
+	if prefix, layouts, ok := splitStream(yamlBytes, docs.docs); ok {
+		docs.prefix = prefix
+		docs.layouts = layouts
+	}
+	if opts.Recover {
+		for _, doc := range docs.docs {
+			exercise(doc)
+		}
+	}
+	return docs, nil
+}
+
+// LoadAlls parses every document in a YAML string
+func LoadAlls(yamlStr string, opts ...LoadOption) (*Documents, error) {
+	return LoadAll([]byte(yamlStr), opts...)
+}
+
+// LoadAllFile parses every document in a YAML file
+func LoadAllFile(filename string, opts ...LoadOption) (*Documents, error) {
+	yamlBytes, err := readFile(filename)
+	if err != nil {
+		logError("easyyaml: read failed", err, "file", filename)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return LoadAll(yamlBytes, append(opts, WithSourceName(filename))...)
+}
+
+// Warnings returns the advisories recorded when the stream was loaded,
+// including those of each document, in line order
+func (d *Documents) Warnings() []Warning {
+	warnings := append([]Warning(nil), d.warnings...)
+	for _, doc := range d.docs {
+		warnings = append(warnings, doc.warnings...)
+	}
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Line < warnings[j].Line })
+	return warnings
+}
+
+// Len returns the number of documents
+func (d *Documents) Len() int {
+	return len(d.docs)
+}
+
+// Get returns the document at index i, or a missing value if out of range
+func (d *Documents) Get(i int) *YAMLValue {
+	if i < 0 || i >= len(d.docs) {
+		return missingValue()
+	}
+	return d.docs[i]
+}
+
+// All returns the documents as a slice
+func (d *Documents) All() []*YAMLValue {
+	docs := make([]*YAMLValue, len(d.docs))
+	copy(docs, d.docs)
+	return docs
+}
+
+// Append adds a document to the end of the stream
+func (d *Documents) Append(doc *YAMLValue) {
+	d.docs = append(d.docs, doc)
+	if d.layouts != nil {
+		d.layouts = append(d.layouts, nil)
+	}
+}
+
+// Filter returns the documents for which pred returns true
+// Usage: docs.Filter(func(doc *easyyaml.YAMLValue) bool { return doc.Get("kind").AsString() == "Service" })
+func (d *Documents) Filter(pred func(doc *YAMLValue) bool) *Documents {
+	filtered := &Documents{prefix: d.prefix, directives: d.directives}
+	for i, doc := range d.docs {
+		if pred(doc) {
+			filtered.docs = append(filtered.docs, doc)
+			if d.layouts != nil {
+				filtered.layouts = append(filtered.layouts, d.layouts[i])
+			}
+		}
+	}
+	return filtered
+}
+
+// MergeByKey deep-merges documents that share an identity, given as one or
+// more dot-separated paths whose values must all match. Each group is merged
+// in stream order into a copy of its first document, which keeps its
+// position; documents missing any identity path are kept unchanged.
+// Usage: docs.MergeByKey("kind", "metadata.name")
+func (d *Documents) MergeByKey(paths ...string) (*Documents, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("at least one identity path is required")
+	}
+
+	merged := &Documents{prefix: d.prefix, directives: d.directives}
+	groups := make(map[string]int)
+	for i, doc := range d.docs {
+		identity, ok := documentIdentity(doc, paths)
+		if !ok {
+			merged.docs = append(merged.docs, doc)
+			if d.layouts != nil {
+				merged.layouts = append(merged.layouts, d.layouts[i])
+			}
+			continue
+		}
+
+		if target, seen := groups[identity]; seen {
+			if err := merged.docs[target].Merge(doc); err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+			continue
+		}
+
+		groups[identity] = len(merged.docs)
+		clone := &YAMLValue{data: deepCopy(doc.data), directives: doc.directives}
+		merged.docs = append(merged.docs, clone)
+		if d.layouts != nil {
+			merged.layouts = append(merged.layouts, d.layouts[i])
+		}
+	}
+	return merged, nil
+}
+
+// documentIdentity joins the values at the identity paths into a single key
+func documentIdentity(doc *YAMLValue, paths []string) (string, bool) {
+	parts := make([]string, len(paths))
+	for i, path := range paths {
+		value := doc.Path(path)
+		if !value.Exists() || value.IsNull() {
+			return "", false
+		}
+		parts[i] = value.AsString()
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+// Each calls fn for every document in order
+func (d *Documents) Each(fn func(i int, doc *YAMLValue)) {
+	for i, doc := range d.docs {
+		fn(i, doc)
+	}
+}
+
+// DumpAll converts all documents to a single YAML stream separated by "---".
+// For loaded streams, unchanged documents are written exactly as read and
+// the comments and blank lines between documents are preserved.
+func (d *Documents) DumpAll() ([]byte, error) {
+	start := time.Now()
+	out, err := d.dumpAll()
+	recordMetrics(OpDump, len(d.docs), len(out), start, err)
+	logError("easyyaml: dump failed", err)
+	return out, err
+}
+
+// dumpAll builds the stream for DumpAll without recording metrics
+func (d *Documents) dumpAll() ([]byte, error) {
+	var buf bytes.Buffer
+	if d.layouts != nil && len(d.docs) > 0 {
+		d.writePrefix(&buf)
+	}
+	for i, doc := range d.docs {
+		if d.layouts != nil && d.layouts[i] != nil {
+			if err := d.layouts[i].write(&buf, doc, i == 0); err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+			continue
+		}
+
+		out, err := doc.dump()
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if i > 0 {
+			if doc.directives.isEmpty() {
+				buf.WriteString("---\n")
+			} else {
+				// Directives may only follow an explicitly ended document
+				buf.WriteString("...\n")
+			}
+		}
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
+
+// writePrefix emits the text preceding the first document, regenerating
+// its directive lines if the first document's directives were changed
+func (d *Documents) writePrefix(buf *bytes.Buffer) {
+	first := d.docs[0]
+	if reflect.DeepEqual(first.Directives(), d.directives) {
+		buf.WriteString(d.prefix)
+		return
+	}
+
+	current := first.Directives()
+	if !current.isEmpty() {
+		out := current.prepend(nil)
+		if d.layouts[0] != nil && strings.Contains(d.layouts[0].separator, "---") {
+			out = bytes.TrimSuffix(out, []byte("---\n"))
+		}
+		buf.Write(out)
+	}
+	for _, line := range strings.SplitAfter(d.prefix, "\n") {
+		if !strings.HasPrefix(line, "%") {
+			buf.WriteString(line)
+		}
+	}
+}
+
+// DumpAlls converts all documents to a YAML string
+func (d *Documents) DumpAlls() (string, error) {
+	out, err := d.DumpAll()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// DumpAllFile writes all documents to a file
+func (d *Documents) DumpAllFile(filename string) error {
+	yamlBytes, err := d.DumpAll()
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	err = writeFile(filename, yamlBytes)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package easyyaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const manifestYAML = `kind: Deployment
+metadata:
+    name: app
+---
+kind: Service
+metadata:
+    name: app
+---
+kind: Service
+metadata:
+    name: db
+`
+
+func TestLoadAll(t *testing.T) {
+	docs, err := LoadAlls(manifestYAML)
+	if err != nil {
+		t.Fatalf("Failed to load documents: %v", err)
+	}
+
+	if docs.Len() != 3 {
+		t.Fatalf("Expected 3 documents, got %d", docs.Len())
+	}
+
+	if docs.Get(0).Get("kind").AsString() != "Deployment" {
+		t.Errorf("Expected first kind 'Deployment', got %s", docs.Get(0).Get("kind").AsString())
+	}
+
+	if docs.Get(5).Exists() {
+		t.Error("Expected out of range document to not exist")
+	}
+
+	services := docs.Filter(func(doc *YAMLValue) bool {
+		return doc.Get("kind").AsString() == "Service"
+	})
+	if services.Len() != 2 {
+		t.Errorf("Expected 2 services, got %d", services.Len())
+	}
+
+	if _, err := LoadAlls("a: 1\n---\nb: [1\n"); err == nil {
+		t.Error("Expected error for invalid document")
+	}
+}
+
+func TestDumpAll(t *testing.T) {
+	docs, err := LoadAlls(manifestYAML)
+	if err != nil {
+		t.Fatalf("Failed to load documents: %v", err)
+	}
+
+	out, err := docs.DumpAlls()
+	if err != nil {
+		t.Fatalf("Failed to dump documents: %v", err)
+	}
+	if out != manifestYAML {
+		t.Errorf("Expected round trip to match input, got:\n%s", out)
+	}
+
+	extra := NewObject()
+	extra.Set("kind", "ConfigMap")
+	docs.Append(extra)
+
+	filename := filepath.Join(t.TempDir(), "bundle.yaml")
+	if err := docs.DumpAllFile(filename); err != nil {
+		t.Fatalf("Failed to dump file: %v", err)
+	}
+
+	loaded, err := LoadAllFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+	if loaded.Len() != 4 || loaded.Get(3).Get("kind").AsString() != "ConfigMap" {
+		t.Errorf("Unexpected documents after file round trip: %d", loaded.Len())
+	}
+
+	if _, err := os.Stat(filename); err != nil {
+		t.Errorf("Expected file to exist: %v", err)
+	}
+}
+
+func TestDumpAllPreservesLayout(t *testing.T) {
+	input := `%YAML 1.2
+# Bundle generated by hand
+---
+# The application
+kind: Deployment
+metadata: {name: app}   # flow style kept
+
+---
+# The service
+
+kind: Service
+spec:
+  ports: [80]
+...
+# trailing notes
+`
+
+	docs, err := LoadAlls(input)
+	if err != nil {
+		t.Fatalf("Failed to load documents: %v", err)
+	}
+	if docs.Len() != 2 {
+		t.Fatalf("Expected 2 documents, got %d", docs.Len())
+	}
+
+	out, err := docs.DumpAlls()
+	if err != nil {
+		t.Fatalf("Failed to dump documents: %v", err)
+	}
+	if out != input {
+		t.Errorf("Expected unchanged stream to round trip exactly, got:\n%s", out)
+	}
+
+	docs.Get(1).SetPath("spec.ports", []interface{}{8080})
+	out, err = docs.DumpAlls()
+	if err != nil {
+		t.Fatalf("Failed to dump documents: %v", err)
+	}
+
+	expected := `%YAML 1.2
+# Bundle generated by hand
+---
+# The application
+kind: Deployment
+metadata: {name: app}   # flow style kept
+
+---
+# The service
+
+kind: Service
+spec:
+    ports:
+        - 8080
+...
+# trailing notes
+`
+	if out != expected {
+		t.Errorf("Expected only the edited document to change, got:\n%s", out)
+	}
+
+	services := docs.Filter(func(doc *YAMLValue) bool {
+		return doc.Get("kind").AsString() == "Service"
+	})
+	out, err = services.DumpAlls()
+	if err != nil {
+		t.Fatalf("Failed to dump documents: %v", err)
+	}
+	if out[:len("# Bundle")] != "# Bundle" {
+		t.Errorf("Expected directives to be dropped with the first document, got:\n%s", out)
+	}
+}
+
+func TestDumpAllChangedStyles(t *testing.T) {
+	docs, _ := LoadAlls("# first\na: x\n---\n# second\nb: !Ref svc\n")
+	docs.Get(0).Get("a").SetStyle(DoubleQuotedStyle)
+	docs.Get(1).Set("c", "z")
+	out, err := docs.DumpAlls()
+	if err != nil {
+		t.Fatalf("Failed to dump documents: %v", err)
+	}
+	if expected := "# first\na: \"x\"\n---\n# second\nb: !Ref svc\nc: z\n"; out != expected {
+		t.Errorf("Expected changed documents to keep their styles and tags, got %q", out)
+	}
+}
+
+func TestDumpAllEmptyDocuments(t *testing.T) {
+	input := "---\n---\nb: 2\n"
+	docs, err := LoadAlls(input)
+	if err != nil {
+		t.Fatalf("Failed to load documents: %v", err)
+	}
+	if docs.Len() != 2 {
+		t.Fatalf("Expected 2 documents, got %d", docs.Len())
+	}
+	if out, _ := docs.DumpAlls(); out != input {
+		t.Errorf("Expected round trip to match input, got %q", out)
+	}
+}
+
+func TestMergeByKey(t *testing.T) {
+	docs, err := LoadAlls(`kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+---
+kind: Service
+metadata:
+  name: app
+---
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template: {}
+---
+note: no identity
+`)
+	if err != nil {
+		t.Fatalf("Failed to load documents: %v", err)
+	}
+
+	merged, err := docs.MergeByKey("kind", "metadata.name")
+	if err != nil {
+		t.Fatalf("Failed to merge documents: %v", err)
+	}
+
+	if merged.Len() != 3 {
+		t.Fatalf("Expected 3 documents after merge, got %d", merged.Len())
+	}
+
+	deployment := merged.Get(0)
+	if deployment.Path("spec.replicas").AsInt() != 1 || !deployment.Path("spec.template").Exists() {
+		t.Errorf("Expected deployment specs to be merged, got %v", deployment.Get("spec").Raw())
+	}
+
+	if docs.Get(0).Path("spec.template").Exists() {
+		t.Error("Expected original documents to be unchanged")
+	}
+
+	if merged.Get(2).Get("note").AsString() != "no identity" {
+		t.Error("Expected document without identity to be kept")
+	}
+
+	if _, err := docs.MergeByKey(); err == nil {
+		t.Error("Expected error without identity paths")
+	}
+}
@@ -0,0 +1,40 @@
+package easyyaml
+
+import (
+	"bytes"
+)
+
+// DumpOptions controls document markers emitted by DumpWith
+type DumpOptions struct {
+	// DocumentStart emits a leading "---" marker
+	DocumentStart bool
+	// DocumentEnd emits a trailing "..." marker
+	DocumentEnd bool
+}
+
+// DumpWith converts the YAMLValue to YAML bytes with explicit control over
+// document markers, for concatenating output into multi-document streams.
+// A start marker is always written when the document has directives.
+func (yv *YAMLValue) DumpWith(opts DumpOptions) ([]byte, error) {
+	out, err := yv.Dump()
+	if err != nil {
+		return nil, err
+	}
+	return applyDocumentMarkers(out, opts, !yv.directives.isEmpty()), nil
+}
+
+// applyDocumentMarkers adds the requested markers to a dumped document
+func applyDocumentMarkers(out []byte, opts DumpOptions, hasStart bool) []byte {
+	var buf bytes.Buffer
+	if opts.DocumentStart && !hasStart {
+		buf.WriteString("---\n")
+	}
+	buf.Write(out)
+	if opts.DocumentEnd {
+		if len(out) > 0 && out[len(out)-1] != '\n' {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("...\n")
+	}
+	return buf.Bytes()
+}
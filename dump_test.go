@@ -0,0 +1,38 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestDumpWithMarkers(t *testing.T) {
+	yv := NewObject()
+	yv.Set("a", 1)
+
+	tests := []struct {
+		opts     DumpOptions
+		expected string
+	}{
+		{DumpOptions{}, "a: 1\n"},
+		{DumpOptions{DocumentStart: true}, "---\na: 1\n"},
+		{DumpOptions{DocumentEnd: true}, "a: 1\n...\n"},
+		{DumpOptions{DocumentStart: true, DocumentEnd: true}, "---\na: 1\n...\n"},
+	}
+	for _, tt := range tests {
+		out, err := yv.DumpWith(tt.opts)
+		if err != nil {
+			t.Fatalf("Failed to dump: %v", err)
+		}
+		if string(out) != tt.expected {
+			t.Errorf("DumpWith(%+v) = %q, expected %q", tt.opts, out, tt.expected)
+		}
+	}
+
+	yv.SetVersion("1.2")
+	out, err := yv.DumpWith(DumpOptions{DocumentStart: true})
+	if err != nil {
+		t.Fatalf("Failed to dump: %v", err)
+	}
+	if string(out) != "%YAML 1.2\n---\na: 1\n" {
+		t.Errorf("Expected a single start marker after directives, got %q", out)
+	}
+}
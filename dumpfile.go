@@ -0,0 +1,90 @@
+package easyyaml
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// FileOptions controls how DumpFileWith writes a file
+type FileOptions struct {
+	// Backup copies the existing file to filename+".bak" before writing
+	Backup bool
+	// DryRun writes nothing; DumpFileWith only returns the diff
+	DryRun bool
+}
+
+// DumpFileWith writes the value to filename like DumpFile, optionally
+// keeping a backup of the original. In dry-run mode nothing is written and
+// the unified diff that would be applied is returned instead ("" if the file
+// would not change).
+// Usage: diff, err := cfg.DumpFileWith("config.yaml", easyyaml.FileOptions{DryRun: true})
+func (yv *YAMLValue) DumpFileWith(filename string, opts FileOptions) (string, error) {
+	yamlBytes, err := yv.Dump()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	raw, err := os.ReadFile(filename)
+	exists := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	original, err := decompress(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if opts.DryRun {
+		from := "a/" + filename
+		if !exists {
+			from = "/dev/null"
+		}
+		return unifiedDiff(string(original), string(yamlBytes), from, "b/"+filename), nil
+	}
+
+	if opts.Backup && exists {
+		// The backup may hold secrets, so it keeps the original's permissions
+		info, err := os.Stat(filename)
+		if err != nil {
+			return "", fmt.Errorf("failed to write backup: %w", err)
+		}
+		if err := os.WriteFile(filename+".bak", raw, info.Mode().Perm()); err != nil {
+			return "", fmt.Errorf("failed to write backup: %w", err)
+		}
+		if err := os.Chmod(filename+".bak", info.Mode().Perm()); err != nil {
+			return "", fmt.Errorf("failed to write backup: %w", err)
+		}
+	}
+	if err := writeFile(filename, yamlBytes); err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return "", nil
+}
+
+// WritableFS is a file system that files can be written to, such as an
+// in-memory file system in tests. Its WriteFile matches os.WriteFile.
+type WritableFS interface {
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// DumpFS writes the value to name in fsys like DumpFile, gzipping it when
+// name ends in ".gz"
+// Usage: err := cfg.DumpFS(memfs, "config.yaml")
+func (yv *YAMLValue) DumpFS(fsys WritableFS, name string) error {
+	yamlBytes, err := yv.Dump()
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	data, err := compressFor(name, yamlBytes)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := fsys.WriteFile(name, data, 0644); err != nil {
+		logError("easyyaml: write failed", err, "file", name)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package easyyaml
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDumpFileWithDryRun(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	original := "a: 1\nb: 2\nc: 3\nd: 4\ne: 5\nf: 6\ng: 7\nh: 8\n"
+	os.WriteFile(filename, []byte(original), 0644)
+
+	yv, _ := LoadFile(filename)
+	yv.Set("b", 20)
+	yv.Set("h", 80)
+
+	diff, err := yv.DumpFileWith(filename, FileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("DumpFileWith failed: %v", err)
+	}
+	expected := "--- a/" + filename + "\n+++ b/" + filename + "\n" +
+		"@@ -1,8 +1,8 @@\n a: 1\n-b: 2\n+b: 20\n c: 3\n d: 4\n e: 5\n f: 6\n g: 7\n-h: 8\n+h: 80\n"
+	if diff != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+	if content, _ := os.ReadFile(filename); string(content) != original {
+		t.Error("Expected dry run not to write the file")
+	}
+
+	unchanged, _ := LoadFile(filename)
+	if diff, _ := unchanged.DumpFileWith(filename, FileOptions{DryRun: true}); diff != "" {
+		t.Errorf("Expected no diff for unchanged file, got:\n%s", diff)
+	}
+}
+
+func TestDumpFileWithBackup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(filename, []byte("a: 1\n"), 0644)
+
+	if _, err := Object(KV("a", 2)).DumpFileWith(filename, FileOptions{Backup: true}); err != nil {
+		t.Fatalf("DumpFileWith failed: %v", err)
+	}
+	if backup, _ := os.ReadFile(filename + ".bak"); string(backup) != "a: 1\n" {
+		t.Errorf("Expected backup of the original, got %q", backup)
+	}
+	if content, _ := os.ReadFile(filename); string(content) != "a: 2\n" {
+		t.Errorf("Expected new content, got %q", content)
+	}
+
+	secret := filepath.Join(t.TempDir(), "secret.yaml")
+	os.WriteFile(secret, []byte("token: x\n"), 0600)
+	if _, err := Object(KV("token", "y")).DumpFileWith(secret, FileOptions{Backup: true}); err != nil {
+		t.Fatalf("DumpFileWith failed: %v", err)
+	}
+	info, err := os.Stat(secret + ".bak")
+	if err != nil {
+		t.Fatalf("Expected a backup: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the backup to keep mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestUnifiedDiffHunks(t *testing.T) {
+	var a, b string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a'+i)) + "\n"
+		a += line
+		if i == 2 || i == 15 {
+			line = "X\n"
+		}
+		b += line
+	}
+	diff := unifiedDiff(a, b, "a", "b")
+	expected := "--- a\n+++ b\n" +
+		"@@ -1,6 +1,6 @@\n a\n b\n-c\n+X\n d\n e\n f\n" +
+		"@@ -13,7 +13,7 @@\n m\n n\n o\n-p\n+X\n q\n r\n s\n"
+	if diff != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, diff)
+	}
+}
+
+func TestDiffLinesLargeRegion(t *testing.T) {
+	x := []string{"head"}
+	y := []string{"head"}
+	for i := 0; i < 2100; i++ {
+		x = append(x, "old")
+		y = append(y, "new")
+	}
+	x = append(x, "tail")
+	y = append(y, "tail")
+
+	ops := diffLines(x, y)
+	if len(ops) != 4202 || ops[0] != (diffOp{' ', "head"}) || ops[1].kind != '-' || ops[2101].kind != '+' || ops[4201] != (diffOp{' ', "tail"}) {
+		t.Errorf("Expected the large changed region replaced as a whole, got %d ops", len(ops))
+	}
+}
+
+type memFS map[string][]byte
+
+func (m memFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if name == "readonly.yaml" {
+		return fs.ErrPermission
+	}
+	m[name] = data
+	return nil
+}
+
+func TestDumpFS(t *testing.T) {
+	yv, _ := Loads("name: app\nport: 80\n")
+	fsys := memFS{}
+	if err := yv.DumpFS(fsys, "config.yaml"); err != nil {
+		t.Fatalf("DumpFS failed: %v", err)
+	}
+	if string(fsys["config.yaml"]) != "name: app\nport: 80\n" {
+		t.Errorf("Unexpected content: %q", fsys["config.yaml"])
+	}
+
+	if err := yv.DumpFS(fsys, "config.yaml.gz"); err != nil {
+		t.Fatalf("DumpFS failed: %v", err)
+	}
+	if data, err := decompress(fsys["config.yaml.gz"]); err != nil || string(data) != "name: app\nport: 80\n" {
+		t.Errorf("Expected gzip content, got %q, %v", data, err)
+	}
+
+	if err := yv.DumpFS(fsys, "readonly.yaml"); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("Expected the write error, got %v", err)
+	}
+}
@@ -1,10 +1,14 @@
 package easyyaml
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/javanhut/easyjson"
 	"gopkg.in/yaml.v3"
@@ -12,7 +16,27 @@ import (
 
 // YAMLValue represents a flexible YAML value that can be any type
 type YAMLValue struct {
-	data interface{}
+	data       interface{}
+	missing    bool
+	directives *Directives
+	opts       *Options
+	cache      *pathCache
+	source     *fidelitySource
+	blanks     map[string]bool
+	origins    map[string]Origin
+	warnings   []Warning
+	access     *accessLog
+	at         string
+	foldKeys   bool
+	coerceKeys bool
+	meta       *docMeta
+	parent     *YAMLValue
+	key        interface{}
+}
+
+// missingValue returns the YAMLValue produced by lookups that found nothing
+func missingValue() *YAMLValue {
+	return &YAMLValue{data: nil, missing: true}
 }
 
 // Q provides a fluent query interface for chaining access
@@ -21,7 +45,7 @@ func (yv *YAMLValue) Q(keys ...interface{}) *YAMLValue {
 	current := yv
 	for _, key := range keys {
 		current = current.Get(key)
-		if current.IsNull() {
+		if !current.Exists() {
 			break
 		}
 	}
@@ -30,41 +54,93 @@ func (yv *YAMLValue) Q(keys ...interface{}) *YAMLValue {
 
 // New creates a new YAMLValue from any Go value
 func New(data interface{}) *YAMLValue {
-	return &YAMLValue{data: data}
+	return &YAMLValue{data: data, meta: &docMeta{}}
 }
 
 // Loads parses a YAML string and returns a YAMLValue
-func Loads(yamlStr string) (*YAMLValue, error) {
-	var data interface{}
-	err := yaml.Unmarshal([]byte(yamlStr), &data)
+func Loads(yamlStr string, opts ...LoadOption) (*YAMLValue, error) {
+	return Load([]byte(yamlStr), opts...)
+}
+
+// Load parses YAML from a byte slice and returns a YAMLValue
+func Load(yamlBytes []byte, opts ...LoadOption) (*YAMLValue, error) {
+	return LoadContext(context.Background(), yamlBytes, opts...)
+}
+
+// load implements Load without tracing
+func load(yamlBytes []byte, opts Options) (yv *YAMLValue, err error) {
+	if opts.Recover {
+		defer recoverError(&err)
+	}
+	start := time.Now()
+	size := len(yamlBytes)
+	original := yamlBytes
+	yamlBytes, err = normalizeEncoding(yamlBytes, opts.StrictEncoding)
 	if err != nil {
+		recordMetrics(OpParse, 1, size, start, err)
+		logError("easyyaml: parse failed", err, parseLogArgs(opts.sourceName, size)...)
 		return nil, err
 	}
-	return &YAMLValue{data: data}, nil
-}
+	yamlBytes, warnings := repairTabs(yamlBytes, opts.TabWidth)
+	if warnings != nil {
+		original = yamlBytes
+	}
+	yamlBytes, directives := extractDirectives(yamlBytes)
 
-// Load parses YAML from a byte slice and returns a YAMLValue
-func Load(yamlBytes []byte) (*YAMLValue, error) {
 	var data interface{}
-	err := yaml.Unmarshal(yamlBytes, &data)
+	var blanks map[string]bool
+	var node yaml.Node
+	err = yaml.Unmarshal(yamlBytes, &node)
+	if err == nil {
+		warnings = append(warnings, inspectNode(&node, opts)...)
+		data, blanks, err = decodeNode(&node, yamlBytes)
+	}
+	if err == nil && opts.Strict {
+		err = checkStrict(yamlBytes, data)
+	}
+	if err == nil && opts.Intern {
+		data = interner{}.internAll(data)
+	}
+	recordMetrics(OpParse, 1, size, start, err)
 	if err != nil {
+		logError("easyyaml: parse failed", err, parseLogArgs(opts.sourceName, size)...)
 		return nil, err
 	}
-	return &YAMLValue{data: data}, nil
+	yv = &YAMLValue{data: data, directives: directives, blanks: blanks, warnings: warnings, foldKeys: opts.FoldKeys, coerceKeys: opts.CoerceKeys, meta: recordMeta(&node)}
+	if opts.Provenance {
+		yv.origins = recordOrigins(&node, opts.sourceName)
+	}
+	if opts.KeepNode {
+		yv.meta.node = &node
+	}
+	if opts.Fidelity {
+		yv.source = newFidelitySource(original, yv)
+	}
+	if opts.Recover {
+		exercise(yv)
+	}
+	return yv, nil
 }
 
 // LoadFile parses YAML from a file and returns a YAMLValue
-func LoadFile(filename string) (*YAMLValue, error) {
-	yamlBytes, err := os.ReadFile(filename)
+func LoadFile(filename string, opts ...LoadOption) (*YAMLValue, error) {
+	return LoadFileContext(context.Background(), filename, opts...)
+}
+
+// loadFile implements LoadFile, tracing the parse as a child span
+func loadFile(ctx context.Context, filename string, opts []LoadOption) (*YAMLValue, error) {
+	yamlBytes, err := readFile(filename)
 	if err != nil {
+		logError("easyyaml: read failed", err, "file", filename)
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
-	return Load(yamlBytes)
+	// Parse failures are logged by load, with the file name
+	return LoadContext(ctx, yamlBytes, append(opts, WithSourceName(filename))...)
 }
 
 // Dumps converts the YAMLValue to a YAML string
 func (yv *YAMLValue) Dumps() (string, error) {
-	bytes, err := yaml.Marshal(yv.data)
+	bytes, err := yv.Dump()
 	if err != nil {
 		return "", err
 	}
@@ -73,7 +149,41 @@ func (yv *YAMLValue) Dumps() (string, error) {
 
 // Dump converts the YAMLValue to YAML bytes
 func (yv *YAMLValue) Dump() ([]byte, error) {
-	return yaml.Marshal(yv.data)
+	start := time.Now()
+	bytes, err := yv.dump()
+	recordMetrics(OpDump, 1, len(bytes), start, err)
+	logError("easyyaml: dump failed", err)
+	return bytes, err
+}
+
+// dump marshals the value with its directives without recording metrics
+func (yv *YAMLValue) dump() ([]byte, error) {
+	return yv.dumpWithOptions(yv.options())
+}
+
+// dumpWithOptions marshals the value with its directives using opts
+func (yv *YAMLValue) dumpWithOptions(opts Options) ([]byte, error) {
+	if yv.source != nil && !opts.reformats() && yv.source.unchanged(yv) {
+		return append([]byte(nil), yv.source.raw...), nil
+	}
+	bytes, err := yv.dumpBody(opts)
+	if err != nil {
+		return nil, err
+	}
+	return yv.directives.prepend(bytes), nil
+}
+
+// dumpBody marshals the value using opts, with its styles, tags and blank
+// lines but without directives
+func (yv *YAMLValue) dumpBody(opts Options) ([]byte, error) {
+	bytes, err := yv.marshalStyled(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.AlignValues {
+		bytes = alignValues(bytes)
+	}
+	return insertBlankLines(bytes, yv.blanks), nil
 }
 
 // DumpFile writes the YAMLValue to a file
@@ -83,8 +193,9 @@ func (yv *YAMLValue) DumpFile(filename string) error {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
 	}
 	
-	err = os.WriteFile(filename, yamlBytes, 0644)
+	err = writeFile(filename, yamlBytes)
 	if err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 	
@@ -93,36 +204,70 @@ func (yv *YAMLValue) DumpFile(filename string) error {
 
 // Get retrieves a value by key (for objects) or index (for arrays)
 func (yv *YAMLValue) Get(key interface{}) *YAMLValue {
+	value, _ := yv.Lookup(key)
+	return value
+}
+
+// Lookup retrieves a value by key or index and reports whether it was present.
+// Unlike Get, it lets callers tell an explicit null apart from a missing key.
+func (yv *YAMLValue) Lookup(key interface{}) (*YAMLValue, bool) {
+	if yv.coerceKeys {
+		key = yv.coerceKey(key)
+	}
 	switch v := yv.data.(type) {
 	case map[string]interface{}:
 		if keyStr, ok := key.(string); ok {
 			if val, exists := v[keyStr]; exists {
-				return &YAMLValue{data: val}
+				return yv.child(val, key), true
 			}
 		}
 	case map[interface{}]interface{}:
 		if val, exists := v[key]; exists {
-			return &YAMLValue{data: val}
+			return yv.child(val, key), true
 		}
 	case []interface{}:
 		if keyInt, ok := key.(int); ok {
 			if keyInt >= 0 && keyInt < len(v) {
-				return &YAMLValue{data: v[keyInt]}
+				return yv.child(v[keyInt], key), true
 			}
 		}
 	}
-	return &YAMLValue{data: nil}
+	if keyStr, ok := key.(string); ok && yv.foldKeys {
+		return yv.lookupFold(keyStr)
+	}
+	return missingValue(), false
 }
 
 // Set sets a value by key (for objects) or index (for arrays)
 func (yv *YAMLValue) Set(key interface{}, value interface{}) error {
+	logMutation("set", "key", key)
+	return yv.set(key, value)
+}
+
+// set implements Set without logging, for use by other mutators
+func (yv *YAMLValue) set(key interface{}, value interface{}) error {
+	if err := yv.checkFrozen("set", key); err != nil {
+		return err
+	}
+	yv.invalidate()
+	if yv.meta != nil {
+		path := childPath(yv.at, key)
+		yv.meta.forget(path)
+		if src, ok := value.(*YAMLValue); ok && src.meta != nil && src.meta != yv.meta {
+			yv.meta.graft(src.meta, src.at, path)
+		}
+	}
+	value, err := normalize(value)
+	if err != nil {
+		return err
+	}
 	switch v := yv.data.(type) {
 	case map[string]interface{}:
 		if keyStr, ok := key.(string); ok {
 			v[keyStr] = value
 			return nil
 		}
-		return fmt.Errorf("key must be string for string-keyed map")
+		return opError("set", key, ErrTypeMismatch, "key must be string for string-keyed map")
 	case map[interface{}]interface{}:
 		v[key] = value
 		return nil
@@ -132,16 +277,17 @@ func (yv *YAMLValue) Set(key interface{}, value interface{}) error {
 				v[keyInt] = value
 				return nil
 			}
-			return fmt.Errorf("index out of range")
+			return opError("set", key, ErrIndexOutOfRange, "")
 		}
-		return fmt.Errorf("key must be int for array")
+		return opError("set", key, ErrTypeMismatch, "key must be int for array")
 	default:
-		return fmt.Errorf("cannot set on non-object/array type")
+		return opError("set", key, ErrTypeMismatch, "cannot set on "+typeName(yv.data))
 	}
 }
 
 // Has checks if a key exists (for objects) or index is valid (for arrays)
 func (yv *YAMLValue) Has(key interface{}) bool {
+	key = yv.coerceKey(key)
 	switch v := yv.data.(type) {
 	case map[string]interface{}:
 		if keyStr, ok := key.(string); ok {
@@ -161,29 +307,38 @@ func (yv *YAMLValue) Has(key interface{}) bool {
 
 // Delete removes a key from an object or index from array
 func (yv *YAMLValue) Delete(key interface{}) error {
+	logMutation("delete", "key", key)
+	if err := yv.checkFrozen("delete", key); err != nil {
+		return err
+	}
+	yv.invalidate()
+	key = yv.coerceKey(key)
 	switch v := yv.data.(type) {
 	case map[string]interface{}:
 		if keyStr, ok := key.(string); ok {
 			delete(v, keyStr)
+			yv.meta.forget(childPath(yv.at, key))
 			return nil
 		}
-		return fmt.Errorf("key must be string for string-keyed map")
+		return opError("delete", key, ErrTypeMismatch, "key must be string for string-keyed map")
 	case map[interface{}]interface{}:
 		delete(v, key)
+		yv.meta.forget(childPath(yv.at, key))
 		return nil
 	case []interface{}:
 		if keyInt, ok := key.(int); ok {
 			if keyInt >= 0 && keyInt < len(v) {
-				copy(v[keyInt:], v[keyInt+1:])
-				v = v[:len(v)-1]
-				yv.data = v
+				yv.meta.reindex(yv.at, without(len(v), keyInt))
+				kept := make([]interface{}, 0, len(v)-1)
+				kept = append(append(kept, v[:keyInt]...), v[keyInt+1:]...)
+				yv.replaceData(kept)
 				return nil
 			}
-			return fmt.Errorf("index out of range")
+			return opError("delete", key, ErrIndexOutOfRange, "")
 		}
-		return fmt.Errorf("key must be int for array")
+		return opError("delete", key, ErrTypeMismatch, "key must be int for array")
 	default:
-		return fmt.Errorf("cannot delete from non-object/array type")
+		return opError("delete", key, ErrTypeMismatch, "cannot delete from "+typeName(yv.data))
 	}
 }
 
@@ -195,19 +350,28 @@ func (yv *YAMLValue) Keys() []interface{} {
 		for k := range v {
 			keys = append(keys, k)
 		}
-		return keys
+		return yv.sortKeys(keys)
 	case map[interface{}]interface{}:
 		keys := make([]interface{}, 0, len(v))
 		for k := range v {
 			keys = append(keys, k)
 		}
-		return keys
+		return yv.sortKeys(keys)
 	}
 	return []interface{}{}
 }
 
 // Values returns all values for an object or array
 func (yv *YAMLValue) Values() []*YAMLValue {
+	if yv.IsObject() && yv.options().SortKeys {
+		keys := yv.Keys()
+		values := make([]*YAMLValue, len(keys))
+		for i, k := range keys {
+			values[i] = yv.Get(k)
+		}
+		return values
+	}
+
 	switch v := yv.data.(type) {
 	case map[string]interface{}:
 		values := make([]*YAMLValue, 0, len(v))
@@ -247,6 +411,19 @@ func (yv *YAMLValue) Items() map[interface{}]*YAMLValue {
 	return items
 }
 
+// ItemsOrdered returns the key-value pairs of an object as a slice in a
+// stable order. Decoded maps do not record document order, so keys are
+// sorted the way Dump writes them, comparing numbers by value: 9 before 10.
+func (yv *YAMLValue) ItemsOrdered() []KeyValue {
+	keys := encoderOrder(yv.Keys())
+	items := make([]KeyValue, len(keys))
+	for i, k := range keys {
+		value, _ := yv.Lookup(k)
+		items[i] = KeyValue{Key: k, Value: value}
+	}
+	return items
+}
+
 // Len returns the length of an array or object
 func (yv *YAMLValue) Len() int {
 	switch v := yv.data.(type) {
@@ -267,6 +444,12 @@ func (yv *YAMLValue) IsNull() bool {
 	return yv.data == nil
 }
 
+// Exists reports whether the value was found by the lookup that produced it.
+// A key explicitly set to null exists; an absent key does not.
+func (yv *YAMLValue) Exists() bool {
+	return !yv.missing
+}
+
 // IsObject checks if the value is an object
 func (yv *YAMLValue) IsObject() bool {
 	switch yv.data.(type) {
@@ -303,12 +486,37 @@ func (yv *YAMLValue) IsBool() bool {
 	return ok
 }
 
-// AsString returns the value as a string
+// AsString returns the value as a string. Null becomes the empty string and
+// objects and arrays are rendered according to the StringFormat option.
 func (yv *YAMLValue) AsString() string {
-	if str, ok := yv.data.(string); ok {
-		return str
+	str, _ := yv.AsStringE()
+	return str
+}
+
+// AsStringE is like AsString but reports an error when an object or array
+// cannot be rendered, or when the StringFormat option is StringError
+func (yv *YAMLValue) AsStringE() (string, error) {
+	switch v := yv.data.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
 	}
-	return fmt.Sprintf("%v", yv.data)
+	if !yv.IsObject() && !yv.IsArray() {
+		return fmt.Sprintf("%v", yv.data), nil
+	}
+
+	switch yv.options().StringFormat {
+	case StringJSON:
+		out, err := json.Marshal(jsonCompatible(yv.data))
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	case StringError:
+		return "", fmt.Errorf("%w: cannot convert %s to string", ErrTypeMismatch, typeName(yv.data))
+	}
+	return yamlFlow(yv.data)
 }
 
 // AsInt returns the value as an integer
@@ -384,6 +592,148 @@ func (yv *YAMLValue) AsObject() map[interface{}]*YAMLValue {
 	return result
 }
 
+// AsStringSlice returns an array of scalars as a slice of strings
+func (yv *YAMLValue) AsStringSlice() ([]string, error) {
+	arr, ok := yv.data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot convert %s to string slice", ErrTypeMismatch, typeName(yv.data))
+	}
+	result := make([]string, len(arr))
+	for i, v := range arr {
+		item := &YAMLValue{data: v}
+		if item.IsObject() || item.IsArray() {
+			return nil, fmt.Errorf("element %d: %w: cannot convert %s to string", i, ErrTypeMismatch, typeName(v))
+		}
+		result[i] = item.AsString()
+	}
+	return result, nil
+}
+
+// AsIntSlice returns an array of integers as a slice of ints. Whole floats
+// and numeric strings are converted; anything else is an error.
+func (yv *YAMLValue) AsIntSlice() ([]int, error) {
+	arr, ok := yv.data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot convert %s to int slice", ErrTypeMismatch, typeName(yv.data))
+	}
+	result := make([]int, len(arr))
+	for i, v := range arr {
+		n, err := toInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// AsFloatSlice returns an array of numbers as a slice of float64s
+func (yv *YAMLValue) AsFloatSlice() ([]float64, error) {
+	arr, ok := yv.data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot convert %s to float slice", ErrTypeMismatch, typeName(yv.data))
+	}
+	result := make([]float64, len(arr))
+	for i, v := range arr {
+		if f, ok := toFloat(v); ok {
+			result[i] = f
+			continue
+		}
+		if str, ok := v.(string); ok {
+			if f, err := strconv.ParseFloat(str, 64); err == nil {
+				result[i] = f
+				continue
+			}
+		}
+		return nil, fmt.Errorf("element %d: %w: cannot convert %s to float", i, ErrTypeMismatch, typeName(v))
+	}
+	return result, nil
+}
+
+// AsStringMap returns an object of scalars as a map of strings
+func (yv *YAMLValue) AsStringMap() (map[string]string, error) {
+	if !yv.IsObject() {
+		return nil, fmt.Errorf("%w: cannot convert %s to string map", ErrTypeMismatch, typeName(yv.data))
+	}
+	result := make(map[string]string, yv.Len())
+	for k, v := range yv.Items() {
+		key := fmt.Sprintf("%v", k)
+		if v.IsObject() || v.IsArray() {
+			return nil, fmt.Errorf("key %q: %w: cannot convert %s to string", key, ErrTypeMismatch, typeName(v.data))
+		}
+		result[key] = v.AsString()
+	}
+	return result, nil
+}
+
+// toInt converts integers, whole floats and numeric strings to int
+func toInt(v interface{}) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case uint64:
+		return int(n), nil
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), nil
+		}
+		return 0, fmt.Errorf("%w: cannot convert %v to int without losing precision", ErrTypeMismatch, n)
+	case string:
+		if i, err := strconv.Atoi(n); err == nil {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: cannot convert %s to int", ErrTypeMismatch, typeName(v))
+}
+
+// unwrap returns the raw data of a *YAMLValue or *easyjson.JSONValue so
+// wrappers are never stored inside a document
+func unwrap(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *YAMLValue:
+		return v.data
+	case *easyjson.JSONValue:
+		return v.Raw()
+	}
+	return value
+}
+
+// normalize unwraps value and converts structs, pointers and typed maps or
+// slices into generic YAML data by round-tripping them through yaml.v3, so
+// yaml tags and custom marshalers are honored. A byte slice is copied and
+// kept, as NewNormalized keeps it, so it stays !!binary.
+func normalize(value interface{}) (interface{}, error) {
+	value = unwrap(value)
+	switch v := value.(type) {
+	case nil, []interface{}, map[string]interface{}, map[interface{}]interface{}:
+		return value, nil
+	case []byte:
+		return append([]byte(nil), v...), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return value, nil
+	}
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T: %w", value, err)
+	}
+	var data interface{}
+	if err := yaml.Unmarshal(out, &data); err != nil {
+		return nil, fmt.Errorf("failed to convert %T: %w", value, err)
+	}
+	return data, nil
+}
+
 // Raw returns the underlying Go value
 func (yv *YAMLValue) Raw() interface{} {
 	return yv.data
@@ -399,24 +749,68 @@ func (yv *YAMLValue) String() string {
 
 // Append adds a value to an array
 func (yv *YAMLValue) Append(value interface{}) error {
+	logMutation("append")
+	if err := yv.checkFrozen("append", nil); err != nil {
+		return err
+	}
+	yv.invalidate()
 	if arr, ok := yv.data.([]interface{}); ok {
-		yv.data = append(arr, value)
+		value, err := normalize(value)
+		if err != nil {
+			return err
+		}
+		yv.replaceData(append(arr, value))
 		return nil
 	}
-	return fmt.Errorf("cannot append to non-array type")
+	return opError("append", nil, ErrNotAnArray, typeName(yv.data))
 }
 
 // Extend adds multiple values to an array
 func (yv *YAMLValue) Extend(values []interface{}) error {
+	logMutation("extend", "count", len(values))
+	if err := yv.checkFrozen("extend", nil); err != nil {
+		return err
+	}
+	yv.invalidate()
 	if arr, ok := yv.data.([]interface{}); ok {
-		yv.data = append(arr, values...)
+		for _, value := range values {
+			value, err := normalize(value)
+			if err != nil {
+				return err
+			}
+			arr = append(arr, value)
+		}
+		yv.replaceData(arr)
 		return nil
 	}
-	return fmt.Errorf("cannot extend non-array type")
+	return opError("extend", nil, ErrNotAnArray, typeName(yv.data))
+}
+
+// graftKeys replaces the node details recorded for the keys of other with
+// those other has, for its values being copied in
+func (yv *YAMLValue) graftKeys(other *YAMLValue) {
+	if yv.meta == nil || yv.meta == other.meta {
+		return
+	}
+	for _, key := range other.Keys() {
+		path := childPath(yv.at, key)
+		yv.meta.forget(path)
+		if other.meta != nil {
+			yv.meta.graft(other.meta, childPath(other.at, key), path)
+		}
+	}
 }
 
 // Update merges another object into this one
 func (yv *YAMLValue) Update(other *YAMLValue) error {
+	logMutation("update", "keys", other.Len())
+	if err := yv.checkFrozen("update", nil); err != nil {
+		return err
+	}
+	yv.invalidate()
+	if yv.IsObject() && other.IsObject() {
+		yv.graftKeys(other)
+	}
 	switch obj := yv.data.(type) {
 	case map[string]interface{}:
 		switch otherObj := other.data.(type) {
@@ -433,7 +827,7 @@ func (yv *YAMLValue) Update(other *YAMLValue) error {
 			}
 			return nil
 		}
-		return fmt.Errorf("can only update with another object")
+		return opError("update", nil, ErrTypeMismatch, "can only update with another object")
 	case map[interface{}]interface{}:
 		switch otherObj := other.data.(type) {
 		case map[string]interface{}:
@@ -447,9 +841,70 @@ func (yv *YAMLValue) Update(other *YAMLValue) error {
 			}
 			return nil
 		}
-		return fmt.Errorf("can only update with another object")
+		return opError("update", nil, ErrTypeMismatch, "can only update with another object")
+	}
+	return opError("update", nil, ErrNotAnObject, typeName(yv.data))
+}
+
+// Merge deep-merges another object into this one. Nested objects are merged
+// recursively; any other value in other replaces the existing one.
+func (yv *YAMLValue) Merge(other *YAMLValue) error {
+	return yv.MergeContext(context.Background(), other)
+}
+
+// merge implements Merge without logging each nested level
+func (yv *YAMLValue) merge(other *YAMLValue) error {
+	err := yv.mergeAt(other, "", nil)
+	yv.mergeOrigins(other)
+	return err
+}
+
+// MergeCollect deep-merges like Merge but records every key that cannot be
+// merged in c, with its path, and carries on with the rest. It returns c.Err().
+func (yv *YAMLValue) MergeCollect(other *YAMLValue, c *ErrorCollector) error {
+	logMutation("merge", "keys", other.Len())
+	if c == nil {
+		c = &ErrorCollector{}
+	}
+	if err := yv.mergeAt(other, "", c); err != nil {
+		c.Add("merge", "", err)
+	}
+	yv.mergeOrigins(other)
+	return c.Err()
+}
+
+// mergeAt merges other into yv, which is at path. Key errors go to c when it
+// is non-nil; otherwise the first one is returned.
+func (yv *YAMLValue) mergeAt(other *YAMLValue, path string, c *ErrorCollector) error {
+	if err := yv.checkFrozen("merge", nil); err != nil {
+		return err
+	}
+	if !yv.IsObject() {
+		return opError("merge", nil, ErrNotAnObject, typeName(yv.data))
+	}
+	if !other.IsObject() {
+		return opError("merge", nil, ErrTypeMismatch, "can only merge with another object")
+	}
+
+	for _, item := range other.ItemsOrdered() {
+		k, otherVal := item.Key, item.Value
+		current := yv.Get(k)
+		var err error
+		if current.IsObject() && otherVal.IsObject() {
+			err = current.mergeAt(otherVal, joinPath(path, fmt.Sprintf("%v", k)), c)
+		} else {
+			// Copy so later changes to either document stay apart
+			err = yv.set(k, &YAMLValue{data: deepCopy(otherVal.data), meta: otherVal.meta, at: otherVal.at})
+		}
+		if err == nil {
+			continue
+		}
+		if c == nil {
+			return err
+		}
+		c.Add("merge", joinPath(path, fmt.Sprintf("%v", k)), err)
 	}
-	return fmt.Errorf("cannot update non-object type")
+	return nil
 }
 
 // Clone creates a deep copy of the YAMLValue
@@ -464,11 +919,30 @@ func (yv *YAMLValue) Clone() *YAMLValue {
 		return &YAMLValue{data: nil}
 	}
 
-	return &YAMLValue{data: cloned}
+	clone := &YAMLValue{data: cloned, opts: yv.opts, source: yv.source, blanks: yv.blanks, origins: copyOrigins(yv.origins), warnings: yv.warnings, foldKeys: yv.foldKeys, coerceKeys: yv.coerceKeys, meta: yv.meta.clone()}
+	if yv.cache != nil {
+		clone.EnablePathCache()
+	}
+	if !yv.directives.isEmpty() {
+		directives := yv.Directives()
+		clone.directives = &directives
+	}
+	return clone
 }
 
-// Path retrieves a nested value using a dot-separated path
-func (yv *YAMLValue) Path(path string) *YAMLValue {
+// Path retrieves a nested value using a dot-separated path. Paths longer than
+// the segment limit resolve to a missing value.
+func (yv *YAMLValue) Path(path string, opts ...PathOption) *YAMLValue {
+	if resolvePathLimits(opts).checkSegments(path) != nil {
+		return missingValue()
+	}
+	if yv.cachesPaths() {
+		if cached, ok := yv.cache.get(path); ok {
+			cached.access.record(cached.at)
+			return cached
+		}
+	}
+
 	parts := strings.Split(path, ".")
 	current := yv
 
@@ -483,21 +957,82 @@ func (yv *YAMLValue) Path(path string) *YAMLValue {
 			current = current.Get(part)
 		}
 
-		if current.IsNull() {
+		if !current.Exists() {
 			break
 		}
 	}
 
+	if yv.cachesPaths() {
+		yv.cache.put(path, current)
+	}
 	return current
 }
 
-// SetPath sets a nested value using a dot-separated path
-func (yv *YAMLValue) SetPath(path string, value interface{}) error {
+// PathE is like Path but reports why a path did not resolve. The error is
+// an *OpError for the first failing prefix wrapping ErrKeyNotFound,
+// ErrIndexOutOfRange or ErrTypeMismatch, or ErrPathTooLong. A missing key
+// error names the nearest existing key, as found by SuggestKey.
+func (yv *YAMLValue) PathE(path string, opts ...PathOption) (*YAMLValue, error) {
+	if err := resolvePathLimits(opts).checkSegments(path); err != nil {
+		return missingValue(), err
+	}
+
+	current := yv
+	prefix := ""
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+		prefix = joinPath(prefix, part)
+
+		index, err := strconv.Atoi(part)
+		isIndex := err == nil
+		var next *YAMLValue
+		var ok bool
+		if isIndex {
+			next, ok = current.Lookup(index)
+		} else {
+			next, ok = current.Lookup(part)
+		}
+		if ok {
+			current = next
+			continue
+		}
+
+		switch {
+		case current.IsArray() && isIndex:
+			return missingValue(), opError("path", prefix, ErrIndexOutOfRange, "")
+		case current.IsArray() || !current.IsObject():
+			return missingValue(), opError("path", prefix, ErrTypeMismatch, "cannot index "+typeName(current.data))
+		default:
+			detail := ""
+			if suggestions := current.SuggestKey(part); len(suggestions) > 0 {
+				detail = fmt.Sprintf("did you mean %q?", suggestions[0])
+			}
+			return missingValue(), opError("path", prefix, ErrKeyNotFound, detail)
+		}
+	}
+	return current, nil
+}
+
+// SetPath sets a nested value using a dot-separated path, creating missing
+// objects and arrays along the way. The number of segments and of created
+// levels are limited; see MaxPathSegments and MaxPathDepth.
+func (yv *YAMLValue) SetPath(path string, value interface{}, opts ...PathOption) error {
+	logMutation("set path", "path", path)
+	if err := yv.checkFrozen("set path", path); err != nil {
+		return err
+	}
+	limits := resolvePathLimits(opts)
+	if err := limits.checkSegments(path); err != nil {
+		return err
+	}
 	parts := strings.Split(path, ".")
 	if len(parts) == 0 {
 		return fmt.Errorf("empty path")
 	}
 
+	creating := false
 	current := yv
 	for i, part := range parts[:len(parts)-1] {
 		if part == "" {
@@ -512,17 +1047,25 @@ func (yv *YAMLValue) SetPath(path string, value interface{}) error {
 		}
 
 		if next.IsNull() {
+			// Every later segment is created too, so check the limit once
+			// before changing anything
+			if !creating {
+				creating = true
+				if err := limits.checkDepth(countSegments(parts[i : len(parts)-1])); err != nil {
+					return err
+				}
+			}
 			if i+1 < len(parts)-1 {
 				if _, err := strconv.Atoi(parts[i+1]); err == nil {
 					newArray := make([]interface{}, 0)
-					current.Set(part, newArray)
+					current.set(part, newArray)
 				} else {
 					newObj := make(map[interface{}]interface{})
-					current.Set(part, newObj)
+					current.set(part, newObj)
 				}
 			} else {
 				newObj := make(map[interface{}]interface{})
-				current.Set(part, newObj)
+				current.set(part, newObj)
 			}
 
 			if index, err := strconv.Atoi(part); err == nil {
@@ -537,30 +1080,76 @@ func (yv *YAMLValue) SetPath(path string, value interface{}) error {
 
 	lastPart := parts[len(parts)-1]
 	if index, err := strconv.Atoi(lastPart); err == nil {
-		return current.Set(index, value)
+		return current.set(index, value)
 	} else {
-		return current.Set(lastPart, value)
+		return current.set(lastPart, value)
 	}
 }
 
 // NewObject creates a new YAMLValue representing an empty object
 func NewObject() *YAMLValue {
-	return &YAMLValue{data: make(map[interface{}]interface{})}
+	return &YAMLValue{data: make(map[interface{}]interface{}), meta: &docMeta{}}
 }
 
 // NewArray creates a new YAMLValue representing an empty array
 func NewArray() *YAMLValue {
-	return &YAMLValue{data: make([]interface{}, 0)}
+	return &YAMLValue{data: make([]interface{}, 0), meta: &docMeta{}}
 }
 
 // NewArrayFrom creates a new YAMLValue array from a slice
 func NewArrayFrom(items []interface{}) *YAMLValue {
-	return &YAMLValue{data: items}
+	return &YAMLValue{data: items, meta: &docMeta{}}
 }
 
 // NewObjectFrom creates a new YAMLValue object from a map
 func NewObjectFrom(obj map[interface{}]interface{}) *YAMLValue {
-	return &YAMLValue{data: obj}
+	return &YAMLValue{data: obj, meta: &docMeta{}}
+}
+
+// KeyValue is a single key and value of an object
+type KeyValue struct {
+	Key   interface{}
+	Value *YAMLValue
+}
+
+// KV creates a KeyValue for use with Object
+func KV(key interface{}, value interface{}) KeyValue {
+	if yv, ok := value.(*YAMLValue); ok {
+		return KeyValue{Key: key, Value: yv}
+	}
+	if data, err := normalize(value); err == nil {
+		value = data
+	}
+	return KeyValue{Key: key, Value: New(value)}
+}
+
+// Object creates a new YAMLValue object from key/value pairs
+// Usage: easyyaml.Object(easyyaml.KV("name", "Alice"), easyyaml.KV("age", 30))
+func Object(pairs ...KeyValue) *YAMLValue {
+	obj := make(map[interface{}]interface{}, len(pairs))
+	for _, pair := range pairs {
+		obj[pair.Key] = pair.Value.Raw()
+	}
+	return &YAMLValue{data: obj, meta: &docMeta{}}
+}
+
+// SetMany sets several keys of an object at once, stopping at the first error
+func (yv *YAMLValue) SetMany(values map[string]interface{}) error {
+	logMutation("set_many", "count", len(values))
+	if err := yv.checkFrozen("set_many", nil); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := yv.set(k, values[k]); err != nil {
+			return fmt.Errorf("key %q: %w", k, err)
+		}
+	}
+	return nil
 }
 
 // FromJSON converts an easyjson.JSONValue to a YAMLValue
@@ -580,6 +1169,7 @@ func FromJSON(jsonValue *easyjson.JSONValue) (*YAMLValue, error) {
 
 // ToJSON converts a YAMLValue to an easyjson.JSONValue
 func (yv *YAMLValue) ToJSON() (*easyjson.JSONValue, error) {
-	// Convert the raw data directly to JSON using easyjson
-	return easyjson.New(yv.data), nil
+	// Convert the raw data directly to JSON using easyjson, with any
+	// non-string map keys turned into strings as JSON requires
+	return easyjson.New(jsonCompatible(yv.data)), nil
 }
\ No newline at end of file
@@ -107,6 +107,17 @@ func TestAppend(t *testing.T) {
 	if yv.Get(0).AsString() != "first" {
 		t.Errorf("Expected first item to be 'first', got %s", yv.Get(0).AsString())
 	}
+
+	doc, _ := Loads("server:\n  tags: [a]\n")
+	if err := doc.Path("server.tags").Append("b"); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+	if err := doc.Path("server.tags").Extend([]interface{}{"c", "d"}); err != nil {
+		t.Fatalf("Failed to extend: %v", err)
+	}
+	if doc.Path("server.tags").Len() != 4 || doc.Path("server.tags.3").AsString() != "d" {
+		t.Errorf("Expected appended items on the document, got %v", doc.Raw())
+	}
 }
 
 func TestTypeChecking(t *testing.T) {
@@ -361,4 +372,291 @@ func TestFileOperations(t *testing.T) {
 	
 	// Clean up
 	os.Remove(filename)
-}
\ No newline at end of file
+}
+
+func TestExistsAndLookup(t *testing.T) {
+	yv, err := Loads("feature: null\nname: app\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	feature, ok := yv.Lookup("feature")
+	if !ok || !feature.Exists() {
+		t.Error("Expected explicit null key to exist")
+	}
+	if !feature.IsNull() {
+		t.Error("Expected feature to be null")
+	}
+
+	if _, ok := yv.Lookup("missing"); ok {
+		t.Error("Expected missing key to not be found")
+	}
+
+	if yv.Get("missing").Exists() {
+		t.Error("Expected Get on missing key to not exist")
+	}
+
+	if yv.Path("feature.child").Exists() {
+		t.Error("Expected path below null value to not exist")
+	}
+
+	if !yv.Path("name").Exists() {
+		t.Error("Expected name path to exist")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base, err := Loads("server:\n  host: localhost\n  port: 8080\nname: app\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	override, err := Loads("server:\n  port: 9090\ndebug: true\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	if err := base.Merge(override); err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+
+	if base.Path("server.host").AsString() != "localhost" {
+		t.Errorf("Expected host to remain 'localhost', got %s", base.Path("server.host").AsString())
+	}
+
+	if base.Path("server.port").AsInt() != 9090 {
+		t.Errorf("Expected port to be 9090, got %d", base.Path("server.port").AsInt())
+	}
+
+	if !base.Get("debug").AsBool() {
+		t.Error("Expected debug to be true")
+	}
+
+	if err := NewArray().Merge(override); err == nil {
+		t.Error("Expected error when merging into an array")
+	}
+}
+
+func TestMergeCopies(t *testing.T) {
+	base, _ := Loads("name: app\n")
+	over, _ := Loads("tags: [a]\nserver: {port: 80}\n")
+	if err := base.Merge(over); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	over.Path("server").Set("port", 81)
+	over.Get("tags").Append("b")
+	base.Get("tags").Set(0, "z")
+	if base.Path("server.port").AsInt() != 80 || base.Get("tags").Len() != 1 {
+		t.Errorf("Expected merged values to be copies, got %s", base)
+	}
+	if over.Get("tags").Get(0).AsString() != "a" {
+		t.Errorf("Expected the overlay to be unchanged, got %s", over)
+	}
+}
+
+func TestAsStringComposites(t *testing.T) {
+	yv, err := Loads("obj:\n  b: [x, z]\n  a: 1\nnothing: null\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	if got := yv.Get("obj").AsString(); got != "{a: 1, b: [x, z]}" {
+		t.Errorf("Expected flow style by default, got %s", got)
+	}
+
+	if got := yv.Get("nothing").AsString(); got != "" {
+		t.Errorf("Expected null to be empty string, got %q", got)
+	}
+
+	if got := yv.Get("missing").AsString(); got != "" {
+		t.Errorf("Expected missing value to be empty string, got %q", got)
+	}
+
+	if got := yv.WithStringFormat(StringJSON).Get("obj").AsString(); got != `{"a":1,"b":["x","z"]}` {
+		t.Errorf("Expected JSON rendering, got %s", got)
+	}
+
+	strict := yv.WithStringFormat(StringError)
+	if _, err := strict.Get("obj").AsStringE(); err == nil {
+		t.Error("Expected error for composite with StringError")
+	}
+	if str, err := strict.Get("obj").Get("a").AsStringE(); err != nil || str != "1" {
+		t.Errorf("Expected scalar to convert, got %q, %v", str, err)
+	}
+}
+
+func TestTypedSliceAccessors(t *testing.T) {
+	yv, err := Loads(`
+hosts: [a.example.com, b.example.com, 8080]
+ports: [80, 443, "8080", 9090.0]
+ratios: [0.5, 1, "2.5"]
+labels:
+  app: web
+  tier: 2
+bad: [1, [2]]
+fractional: [1.5]
+`)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	hosts, err := yv.Get("hosts").AsStringSlice()
+	if err != nil || len(hosts) != 3 || hosts[2] != "8080" {
+		t.Errorf("AsStringSlice returned %v, %v", hosts, err)
+	}
+
+	ports, err := yv.Get("ports").AsIntSlice()
+	if err != nil || len(ports) != 4 || ports[2] != 8080 || ports[3] != 9090 {
+		t.Errorf("AsIntSlice returned %v, %v", ports, err)
+	}
+
+	ratios, err := yv.Get("ratios").AsFloatSlice()
+	if err != nil || ratios[0] != 0.5 || ratios[1] != 1 || ratios[2] != 2.5 {
+		t.Errorf("AsFloatSlice returned %v, %v", ratios, err)
+	}
+
+	labels, err := yv.Get("labels").AsStringMap()
+	if err != nil || labels["app"] != "web" || labels["tier"] != "2" {
+		t.Errorf("AsStringMap returned %v, %v", labels, err)
+	}
+
+	if _, err := yv.Get("bad").AsStringSlice(); err == nil {
+		t.Error("Expected error for nested array in string slice")
+	}
+	if _, err := yv.Get("fractional").AsIntSlice(); err == nil {
+		t.Error("Expected error for fractional value in int slice")
+	}
+	if _, err := yv.Get("labels").AsIntSlice(); err == nil {
+		t.Error("Expected error for object as int slice")
+	}
+	if _, err := yv.Get("hosts").AsStringMap(); err == nil {
+		t.Error("Expected error for array as string map")
+	}
+}
+
+func TestObjectAndSetMany(t *testing.T) {
+	obj := Object(
+		KV("name", "Alice"),
+		KV("age", 30),
+		KV("tags", []interface{}{"admin"}),
+		KV("address", Object(KV("city", "Paris"))),
+	)
+	if obj.Get("name").AsString() != "Alice" || obj.Get("age").AsInt() != 30 {
+		t.Errorf("Unexpected object contents: %v", obj)
+	}
+	if obj.Path("address.city").AsString() != "Paris" {
+		t.Errorf("Expected nested object, got %v", obj.Get("address").Raw())
+	}
+
+	if err := obj.SetMany(map[string]interface{}{"age": 31, "role": "ops"}); err != nil {
+		t.Fatalf("SetMany failed: %v", err)
+	}
+	if obj.Get("age").AsInt() != 31 || obj.Get("role").AsString() != "ops" {
+		t.Errorf("SetMany did not update values: %v", obj)
+	}
+
+	if err := NewArray().SetMany(map[string]interface{}{"a": 1}); err == nil {
+		t.Error("Expected error from SetMany on array")
+	}
+}
+
+func TestSetUnwrapsValues(t *testing.T) {
+	obj := NewObject()
+	if err := obj.Set("server", Object(KV("port", 80))); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := obj.SetPath("meta.json", easyjson.New(map[string]interface{}{"ok": true})); err != nil {
+		t.Fatalf("SetPath failed: %v", err)
+	}
+	list := NewArray()
+	list.Append(New("a"))
+	list.Extend([]interface{}{New("b"), "c"})
+	obj.Set("list", list)
+
+	out, err := obj.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps failed: %v", err)
+	}
+	expected := "list:\n    - a\n    - b\n    - c\nmeta:\n    json:\n        ok: true\nserver:\n    port: 80\n"
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+func TestSetReflectsStructs(t *testing.T) {
+	type TLS struct {
+		Cert string `yaml:"cert"`
+	}
+	type Server struct {
+		Host  string `yaml:"host"`
+		Port  int    `yaml:"port"`
+		Debug bool   `yaml:"debug,omitempty"`
+		TLS   *TLS   `yaml:"tls,omitempty"`
+	}
+
+	obj := NewObject()
+	if err := obj.Set("server", Server{Host: "localhost", Port: 8080, TLS: &TLS{Cert: "a.pem"}}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if obj.Path("server.host").AsString() != "localhost" || obj.Path("server.port").AsInt() != 8080 {
+		t.Errorf("Expected struct fields by yaml tag, got %v", obj.Get("server").Raw())
+	}
+	if obj.Path("server.tls.cert").AsString() != "a.pem" {
+		t.Errorf("Expected nested struct pointer, got %v", obj.Get("server").Raw())
+	}
+	if obj.Get("server").Has("debug") {
+		t.Error("Expected omitempty field to be omitted")
+	}
+
+	list := NewArray()
+	list.Append(&Server{Host: "a"})
+	list.Extend([]interface{}{[]string{"x", "y"}})
+	if list.Path("0.host").AsString() != "a" || list.Get(1).Len() != 2 {
+		t.Errorf("Unexpected array contents: %v", list.Raw())
+	}
+}
+
+func TestSetKeepsBinary(t *testing.T) {
+	yv := New(map[string]interface{}{})
+	data := []byte("hi")
+	yv.Set("blob", data)
+	data[0] = 'x'
+	if tag := yv.Get("blob").Tag(); tag != "!!binary" {
+		t.Errorf("Expected a byte slice to stay !!binary, got %q", tag)
+	}
+	if out, _ := yv.Dumps(); out != "blob: !!binary aGk=\n" {
+		t.Errorf("Expected the copied bytes dumped base64-encoded, got %q", out)
+	}
+}
+
+func TestItemsOrdered(t *testing.T) {
+	yv, err := Loads("zeta: 1\nalpha: 2\nmid: 3\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	for run := 0; run < 5; run++ {
+		items := yv.ItemsOrdered()
+		if len(items) != 3 {
+			t.Fatalf("Expected 3 items, got %d", len(items))
+		}
+		if items[0].Key != "alpha" || items[1].Key != "mid" || items[2].Key != "zeta" {
+			t.Fatalf("Expected sorted keys, got %v %v %v", items[0].Key, items[1].Key, items[2].Key)
+		}
+		if items[0].Value.AsInt() != 2 {
+			t.Errorf("Expected alpha=2, got %d", items[0].Value.AsInt())
+		}
+	}
+
+	numbered, _ := Loads("10: a\n9: b\nitem10: c\nitem9: d\n")
+	expected := []interface{}{9, 10, "item9", "item10"}
+	for i, item := range numbered.ItemsOrdered() {
+		if item.Key != expected[i] {
+			t.Errorf("Expected key %v at %d in the order Dump writes, got %v", expected[i], i, item.Key)
+		}
+	}
+
+	if len(NewArray().ItemsOrdered()) != 0 {
+		t.Error("Expected no items for an array")
+	}
+}
@@ -0,0 +1,83 @@
+package easyyaml
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// WithStrictEncoding makes a load fail with ErrEncoding on a byte order
+// mark, UTF-16 input or CRLF line endings instead of normalizing them
+// Usage: cfg, err := easyyaml.LoadFile("app.yaml", easyyaml.WithStrictEncoding())
+func WithStrictEncoding() LoadOption {
+	return func(opts *Options) {
+		opts.StrictEncoding = true
+	}
+}
+
+// normalizeEncoding converts input to BOM-free UTF-8 with LF line endings.
+// With strict set, it returns an error describing the first problem instead.
+func normalizeEncoding(input []byte, strict bool) ([]byte, error) {
+	if order, ok := utf16Order(input); ok {
+		if strict {
+			return nil, fmt.Errorf("%w: input is UTF-16, not UTF-8", ErrEncoding)
+		}
+		if len(input)%2 != 0 {
+			return nil, fmt.Errorf("%w: UTF-16 input has an odd number of bytes", ErrEncoding)
+		}
+		input = decodeUTF16(input, order)
+	}
+	if bytes.HasPrefix(input, bomUTF8) {
+		if strict {
+			return nil, fmt.Errorf("%w: input starts with a UTF-8 byte order mark", ErrEncoding)
+		}
+		input = input[len(bomUTF8):]
+	}
+	if i := bytes.IndexByte(input, '\r'); i >= 0 {
+		if strict {
+			line := bytes.Count(input[:i], []byte("\n")) + 1
+			return nil, fmt.Errorf("%w: carriage return at line %d", ErrEncoding, line)
+		}
+		input = bytes.ReplaceAll(input, []byte("\r\n"), []byte("\n"))
+		input = bytes.ReplaceAll(input, []byte("\r"), []byte("\n"))
+	}
+	return input, nil
+}
+
+// utf16Order detects UTF-16 input by its byte order mark or, without one,
+// by a zero byte next to the first character, which YAML text never has
+func utf16Order(input []byte) (binary.ByteOrder, bool) {
+	switch {
+	case bytes.HasPrefix(input, bomUTF16LE):
+		return binary.LittleEndian, true
+	case bytes.HasPrefix(input, bomUTF16BE):
+		return binary.BigEndian, true
+	case len(input) >= 2 && input[0] == 0 && input[1] != 0:
+		return binary.BigEndian, true
+	case len(input) >= 2 && input[0] != 0 && input[1] == 0:
+		return binary.LittleEndian, true
+	}
+	return nil, false
+}
+
+// decodeUTF16 converts UTF-16 input to UTF-8, keeping any byte order mark
+// as a UTF-8 one for normalizeEncoding to strip
+func decodeUTF16(input []byte, order binary.ByteOrder) []byte {
+	units := make([]uint16, len(input)/2)
+	for i := range units {
+		units[i] = order.Uint16(input[2*i:])
+	}
+	out := make([]byte, 0, len(input))
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package easyyaml
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, optionally with a BOM
+func encodeUTF16(s string, order binary.AppendByteOrder, bom bool) []byte {
+	var out []byte
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	for _, u := range units {
+		out = order.AppendUint16(out, u)
+	}
+	return out
+}
+
+func TestLoadNormalizesEncoding(t *testing.T) {
+	want := map[string]interface{}{"name": "café", "text": "a\nb\n"}
+	plain := "name: café\ntext: |\n  a\n  b\n"
+	inputs := map[string][]byte{
+		"utf-8 bom":         append([]byte("\xef\xbb\xbf"), plain...),
+		"crlf":              []byte("name: café\r\ntext: |\r\n  a\r\n  b\r\n"),
+		"utf-16le bom":      encodeUTF16(plain, binary.LittleEndian, true),
+		"utf-16be bom":      encodeUTF16(plain, binary.BigEndian, true),
+		"utf-16le no bom":   encodeUTF16(plain, binary.LittleEndian, false),
+		"utf-16be crlf bom": encodeUTF16("name: café\r\ntext: |\r\n  a\r\n  b\r\n", binary.BigEndian, true),
+	}
+	for name, input := range inputs {
+		yv, err := Load(input)
+		if err != nil {
+			t.Errorf("%s: Load failed: %v", name, err)
+			continue
+		}
+		if !valuesEqual(yv.Raw(), want) {
+			t.Errorf("%s: Expected %v, got %v", name, want, yv.Raw())
+		}
+	}
+
+	docs, err := LoadAll([]byte("\xef\xbb\xbfa: 1\r\n---\r\nb: 2\r\n"))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if docs.Len() != 2 || docs.Get(1).Get("b").AsInt() != 2 {
+		t.Errorf("Expected two documents, got %d", docs.Len())
+	}
+}
+
+func TestLoadFileNormalizesEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "windows.yaml")
+	os.WriteFile(path, encodeUTF16("port: 8080\r\n", binary.LittleEndian, true), 0644)
+
+	yv, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if yv.Get("port").AsInt() != 8080 {
+		t.Errorf("Expected port 8080, got %v", yv.Get("port").Raw())
+	}
+}
+
+func TestWithStrictEncoding(t *testing.T) {
+	inputs := map[string][]byte{
+		"utf-8 bom": []byte("\xef\xbb\xbfa: 1\n"),
+		"crlf":      []byte("a: 1\nb: 2\r\n"),
+		"utf-16":    encodeUTF16("a: 1\n", binary.LittleEndian, true),
+	}
+	for name, input := range inputs {
+		if _, err := Load(input, WithStrictEncoding()); !errors.Is(err, ErrEncoding) {
+			t.Errorf("%s: Expected ErrEncoding, got %v", name, err)
+		}
+		if _, err := LoadAll(input, WithStrictEncoding()); !errors.Is(err, ErrEncoding) {
+			t.Errorf("%s: Expected ErrEncoding from LoadAll, got %v", name, err)
+		}
+	}
+
+	_, err := Load([]byte("a: 1\nb: 2\r\n"), WithStrictEncoding())
+	if err == nil || err.Error() != "unsupported encoding: carriage return at line 2" {
+		t.Errorf("Expected line number in error, got %v", err)
+	}
+	if _, err := Load([]byte("a: 1\n"), WithStrictEncoding()); err != nil {
+		t.Errorf("Expected clean input to load, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package easyyaml
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTypeMismatch is returned when a value or key has the wrong type for
+	// an operation
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrNotAnArray is returned by array operations on other types. It
+	// matches ErrTypeMismatch.
+	ErrNotAnArray = fmt.Errorf("%w: not an array", ErrTypeMismatch)
+	// ErrNotAnObject is returned by object operations on other types. It
+	// matches ErrTypeMismatch.
+	ErrNotAnObject = fmt.Errorf("%w: not an object", ErrTypeMismatch)
+	// ErrIndexOutOfRange is returned when an array index is out of range
+	ErrIndexOutOfRange = errors.New("index out of range")
+	// ErrKeyNotFound is returned when a required key is missing
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists is returned when a key would overwrite an existing one
+	ErrKeyExists = errors.New("key already exists")
+	// ErrPathTooLong is returned when a path has more segments than allowed
+	ErrPathTooLong = errors.New("path has too many segments")
+	// ErrPathTooDeep is returned when SetPath would create more nested
+	// levels than allowed
+	ErrPathTooDeep = errors.New("path creates too many nested levels")
+	// ErrChecksumMismatch is returned by VerifyChecksum when the document
+	// does not match its embedded checksum
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrBadSignature is returned by Verify when the signature does not match
+	ErrBadSignature = errors.New("signature verification failed")
+	// ErrSchemaViolation is returned by Validate for a value that does not
+	// match its schema
+	ErrSchemaViolation = errors.New("schema violation")
+	// ErrNoSchema is returned by SchemaRegistry.Validate when no schema is
+	// registered for a document's kind
+	ErrNoSchema = errors.New("no schema registered")
+	// ErrRefCycle is returned by ResolveRefs for a $ref that refers back to
+	// itself
+	ErrRefCycle = errors.New("$ref cycle")
+	// ErrUndefinedVariable is returned by CheckPlaceholders for a variable
+	// with no value and no default
+	ErrUndefinedVariable = errors.New("undefined variable")
+	// ErrEncoding is returned when loading input that is not UTF-8 text,
+	// or that has a byte order mark or CRLF line endings with
+	// WithStrictEncoding
+	ErrEncoding = errors.New("unsupported encoding")
+	// ErrUnsupportedVersion is returned by Migrator.Migrate for a document
+	// newer than the latest registered migration
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrNoFetcher is returned by LoadFrom for a URL scheme with no
+	// registered Fetcher
+	ErrNoFetcher = errors.New("no fetcher registered")
+	// ErrFrozen is returned by mutators of a document frozen with Freeze
+	ErrFrozen = errors.New("document is frozen")
+	// ErrArchiveTooLarge is returned by LoadArchive when the archive
+	// decompresses to more than the allowed size
+	ErrArchiveTooLarge = errors.New("archive too large")
+	// ErrMergePatchNull is returned by GeneratePatch when a merge patch
+	// would have to set a key to null, which it cannot express
+	ErrMergePatchNull = errors.New("merge patch cannot set null")
+)
+
+// OpError records the operation and key or path that failed. Err wraps one
+// of the sentinel errors, so callers can use errors.Is on an OpError too.
+type OpError struct {
+	Op  string
+	Key interface{}
+	Err error
+}
+
+// Error implements error
+func (e *OpError) Error() string {
+	if e.Key == nil {
+		return e.Op + ": " + e.Err.Error()
+	}
+	return fmt.Sprintf("%s %v: %s", e.Op, e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// opError creates an OpError whose Err wraps sentinel with detail appended
+func opError(op string, key interface{}, sentinel error, detail string) error {
+	err := sentinel
+	if detail != "" {
+		err = fmt.Errorf("%w: %s", sentinel, detail)
+	}
+	return &OpError{Op: op, Key: key, Err: err}
+}
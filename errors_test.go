@@ -0,0 +1,61 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	yv, err := Loads("name: app\nitems: [1, 2]\nserver:\n  port: 80\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	if err := yv.Append(3); !errors.Is(err, ErrNotAnArray) || !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrNotAnArray, got %v", err)
+	}
+	if err := yv.Get("items").Set(5, "x"); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if err := yv.Get("items").Delete("x"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+	if err := yv.Get("items").Merge(NewObject()); !errors.Is(err, ErrNotAnObject) {
+		t.Errorf("Expected ErrNotAnObject, got %v", err)
+	}
+	if _, err := yv.Get("server").AsIntSlice(); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	var opErr *OpError
+	err = yv.Get("items").Set(9, "x")
+	if !errors.As(err, &opErr) || opErr.Op != "set" || opErr.Key != 9 {
+		t.Errorf("Expected *OpError for set 9, got %#v", err)
+	}
+	if err.Error() != "set 9: index out of range" {
+		t.Errorf("Unexpected message: %s", err)
+	}
+}
+
+func TestPathE(t *testing.T) {
+	yv, err := Loads("items: [1, 2]\nserver:\n  port: 80\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	port, err := yv.PathE("server.port")
+	if err != nil || port.AsInt() != 80 {
+		t.Errorf("Expected 80, got %v, %v", port.Raw(), err)
+	}
+
+	var opErr *OpError
+	if _, err := yv.PathE("server.host"); !errors.Is(err, ErrKeyNotFound) || !errors.As(err, &opErr) || opErr.Key != "server.host" {
+		t.Errorf("Expected ErrKeyNotFound for server.host, got %v", err)
+	}
+	if _, err := yv.PathE("items.5"); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := yv.PathE("server.port.x"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+}
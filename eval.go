@@ -0,0 +1,828 @@
+package easyyaml
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Eval evaluates a yq/jq-style expression against the value and returns
+// every output it produces. Supported syntax covers the commonly used
+// subset: paths (.a.b, .a[0], .a[], ."key"), pipes, commas, parentheses,
+// literals, comparison and boolean operators, arithmetic, and the
+// select, map, has, keys, length and not functions.
+// Usage: data.Eval(`.spec.containers[] | select(.name == "app") | .image`)
+func (yv *YAMLValue) Eval(expr string) (*Results, error) {
+	tokens, err := tokenizeExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	node, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("unexpected token %q at position %d", p.peek().text, p.peek().pos)
+	}
+
+	outputs, err := node.eval(evalItem{value: yv})
+	if err != nil {
+		return nil, err
+	}
+
+	results := &Results{}
+	for _, out := range outputs {
+		results.add(out.path, out.value)
+	}
+	return results, nil
+}
+
+// evalItem is a value flowing through an expression along with its path
+// in the input document, or an empty path for computed values
+type evalItem struct {
+	path  string
+	value *YAMLValue
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokDot
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokPipe
+	tokComma
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenizeExpr splits an expression into tokens
+func tokenizeExpr(expr string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(expr)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '.':
+			tokens = append(tokens, exprToken{kind: tokDot, text: ".", pos: i})
+			i++
+		case r == '(':
+			tokens = append(tokens, exprToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, exprToken{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == '[':
+			tokens = append(tokens, exprToken{kind: tokLBracket, text: "[", pos: i})
+			i++
+		case r == ']':
+			tokens = append(tokens, exprToken{kind: tokRBracket, text: "]", pos: i})
+			i++
+		case r == '|':
+			tokens = append(tokens, exprToken{kind: tokPipe, text: "|", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, exprToken{kind: tokComma, text: ",", pos: i})
+			i++
+		case r == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						sb.WriteRune('\n')
+					case 't':
+						sb.WriteRune('\t')
+					default:
+						sb.WriteRune(runes[i])
+					}
+				} else {
+					sb.WriteRune(runes[i])
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, exprToken{kind: tokString, text: sb.String(), pos: start})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("unexpected operator %q at position %d", op, start)
+			}
+			tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: start})
+		case strings.ContainsRune("+-*/%", r):
+			tokens = append(tokens, exprToken{kind: tokOp, text: string(r), pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+	tokens = append(tokens, exprToken{kind: tokEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+// exprNode is a parsed expression that maps one input to zero or more outputs
+type exprNode interface {
+	eval(input evalItem) ([]evalItem, error)
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) expect(kind tokenKind, text string) error {
+	tok := p.next()
+	if tok.kind != kind {
+		return fmt.Errorf("expected %q at position %d, got %q", text, tok.pos, tok.text)
+	}
+	return nil
+}
+
+func (p *exprParser) isOp(ops ...string) bool {
+	tok := p.peek()
+	if tok.kind != tokOp && tok.kind != tokIdent {
+		return false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePipe parses: comma ('|' comma)*
+func (p *exprParser) parsePipe() (exprNode, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokPipe {
+		p.next()
+		right, err := p.parseComma()
+		if err != nil {
+			return nil, err
+		}
+		left = &pipeNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseComma parses: or (',' or)*
+func (p *exprParser) parseComma() (exprNode, error) {
+	left, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokComma {
+		p.next()
+		right, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		left = &commaNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// binaryLevels lists binary operators from lowest to highest precedence
+var binaryLevels = [][]string{
+	{"or"},
+	{"and"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary parses left-associative binary operators at the given level
+func (p *exprParser) parseBinary(level int) (exprNode, error) {
+	if level >= len(binaryLevels) {
+		return p.parsePostfix()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp(binaryLevels[level]...) {
+		op := p.next().text
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parsePostfix parses a primary expression followed by path suffixes
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokDot:
+			p.next()
+			key, err := p.parseKey()
+			if err != nil {
+				return nil, err
+			}
+			node = &pipeNode{left: node, right: key}
+		case tokLBracket:
+			index, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			node = &pipeNode{left: node, right: index}
+		default:
+			return node, nil
+		}
+	}
+}
+
+// parseKey parses the field name following a dot
+func (p *exprParser) parseKey() (exprNode, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokIdent, tokString:
+		p.next()
+		return &keyNode{key: tok.text}, nil
+	case tokLBracket:
+		return p.parseBracket()
+	}
+	return nil, fmt.Errorf("expected field name at position %d, got %q", tok.pos, tok.text)
+}
+
+// parseBracket parses [], [n] or ["key"]
+func (p *exprParser) parseBracket() (exprNode, error) {
+	if err := p.expect(tokLBracket, "["); err != nil {
+		return nil, err
+	}
+	tok := p.next()
+	switch tok.kind {
+	case tokRBracket:
+		return &iterateNode{}, nil
+	case tokNumber:
+		index, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q at position %d", tok.text, tok.pos)
+		}
+		if err := p.expect(tokRBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &indexNode{index: index}, nil
+	case tokString:
+		if err := p.expect(tokRBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &keyNode{key: tok.text}, nil
+	case tokOp:
+		if tok.text == "-" && p.peek().kind == tokNumber {
+			num := p.next()
+			index, err := strconv.Atoi(num.text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q at position %d", num.text, num.pos)
+			}
+			if err := p.expect(tokRBracket, "]"); err != nil {
+				return nil, err
+			}
+			return &indexNode{index: -index}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected token %q in brackets at position %d", tok.text, tok.pos)
+}
+
+// parsePrimary parses identity, paths, literals, parentheses and function calls
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokDot:
+		p.next()
+		switch p.peek().kind {
+		case tokIdent, tokString, tokLBracket:
+			return p.parseKey()
+		}
+		return &identityNode{}, nil
+	case tokString:
+		p.next()
+		return &literalNode{value: tok.text}, nil
+	case tokNumber:
+		p.next()
+		if i, err := strconv.Atoi(tok.text); err == nil {
+			return &literalNode{value: i}, nil
+		}
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return &literalNode{value: f}, nil
+	case tokOp:
+		if tok.text == "-" {
+			p.next()
+			operand, err := p.parsePostfix()
+			if err != nil {
+				return nil, err
+			}
+			return &binaryNode{op: "-", left: &literalNode{value: 0}, right: operand}, nil
+		}
+	case tokLParen:
+		p.next()
+		node, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case tokIdent:
+		p.next()
+		return p.parseFunction(tok)
+	}
+	return nil, fmt.Errorf("unexpected token %q at position %d", tok.text, tok.pos)
+}
+
+// parseFunction parses a builtin name and its optional argument
+func (p *exprParser) parseFunction(name exprToken) (exprNode, error) {
+	switch name.text {
+	case "true":
+		return &literalNode{value: true}, nil
+	case "false":
+		return &literalNode{value: false}, nil
+	case "null":
+		return &literalNode{value: nil}, nil
+	case "keys", "length", "not":
+		return &funcNode{name: name.text}, nil
+	case "select", "map", "has":
+		if err := p.expect(tokLParen, "("); err != nil {
+			return nil, err
+		}
+		arg, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return &funcNode{name: name.text, arg: arg}, nil
+	}
+	return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+}
+
+type identityNode struct{}
+
+func (n *identityNode) eval(input evalItem) ([]evalItem, error) {
+	return []evalItem{input}, nil
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(input evalItem) ([]evalItem, error) {
+	return []evalItem{{value: &YAMLValue{data: n.value}}}, nil
+}
+
+type keyNode struct {
+	key string
+}
+
+func (n *keyNode) eval(input evalItem) ([]evalItem, error) {
+	if input.value.IsNull() {
+		return []evalItem{{path: joinPath(input.path, n.key), value: &YAMLValue{data: nil}}}, nil
+	}
+	if !input.value.IsObject() {
+		return nil, fmt.Errorf("cannot index %s with %q", typeName(input.value.data), n.key)
+	}
+	return []evalItem{{path: joinPath(input.path, n.key), value: input.value.Get(n.key)}}, nil
+}
+
+type indexNode struct {
+	index int
+}
+
+func (n *indexNode) eval(input evalItem) ([]evalItem, error) {
+	if input.value.IsNull() {
+		return []evalItem{{value: &YAMLValue{data: nil}}}, nil
+	}
+	if !input.value.IsArray() {
+		return nil, fmt.Errorf("cannot index %s with number", typeName(input.value.data))
+	}
+	index := n.index
+	if index < 0 {
+		index += input.value.Len()
+	}
+	return []evalItem{{path: joinPath(input.path, strconv.Itoa(index)), value: input.value.Get(index)}}, nil
+}
+
+type iterateNode struct{}
+
+func (n *iterateNode) eval(input evalItem) ([]evalItem, error) {
+	if !input.value.IsArray() && !input.value.IsObject() {
+		return nil, fmt.Errorf("cannot iterate over %s", typeName(input.value.data))
+	}
+	var outputs []evalItem
+	input.value.eachChild(func(key string, child *YAMLValue) {
+		outputs = append(outputs, evalItem{path: joinPath(input.path, key), value: child})
+	})
+	return outputs, nil
+}
+
+type pipeNode struct {
+	left, right exprNode
+}
+
+func (n *pipeNode) eval(input evalItem) ([]evalItem, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var outputs []evalItem
+	for _, l := range lefts {
+		rights, err := n.right.eval(l)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, rights...)
+	}
+	return outputs, nil
+}
+
+type commaNode struct {
+	left, right exprNode
+}
+
+func (n *commaNode) eval(input evalItem) ([]evalItem, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	return append(lefts, rights...), nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *binaryNode) eval(input evalItem) ([]evalItem, error) {
+	lefts, err := n.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var outputs []evalItem
+	for _, r := range rights {
+		for _, l := range lefts {
+			result, err := applyBinary(n.op, l.value.data, r.value.data)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, evalItem{value: &YAMLValue{data: result}})
+		}
+	}
+	return outputs, nil
+}
+
+type funcNode struct {
+	name string
+	arg  exprNode
+}
+
+func (n *funcNode) eval(input evalItem) ([]evalItem, error) {
+	switch n.name {
+	case "not":
+		return []evalItem{{value: &YAMLValue{data: !isTruthy(input.value.data)}}}, nil
+	case "length":
+		switch v := input.value.data.(type) {
+		case nil:
+			return []evalItem{{value: &YAMLValue{data: 0}}}, nil
+		case string:
+			return []evalItem{{value: &YAMLValue{data: len([]rune(v))}}}, nil
+		}
+		if input.value.IsArray() || input.value.IsObject() {
+			return []evalItem{{value: &YAMLValue{data: input.value.Len()}}}, nil
+		}
+		if f, ok := toFloat(input.value.data); ok {
+			return []evalItem{{value: &YAMLValue{data: math.Abs(f)}}}, nil
+		}
+		return nil, fmt.Errorf("%s has no length", typeName(input.value.data))
+	case "keys":
+		if input.value.IsArray() {
+			keys := make([]interface{}, input.value.Len())
+			for i := range keys {
+				keys[i] = i
+			}
+			return []evalItem{{value: &YAMLValue{data: keys}}}, nil
+		}
+		if !input.value.IsObject() {
+			return nil, fmt.Errorf("%s has no keys", typeName(input.value.data))
+		}
+		var keys []string
+		input.value.eachChild(func(key string, child *YAMLValue) {
+			keys = append(keys, key)
+		})
+		sort.Strings(keys)
+		result := make([]interface{}, len(keys))
+		for i, k := range keys {
+			result[i] = k
+		}
+		return []evalItem{{value: &YAMLValue{data: result}}}, nil
+	case "select":
+		conds, err := n.arg.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		var outputs []evalItem
+		for _, c := range conds {
+			if isTruthy(c.value.data) {
+				outputs = append(outputs, input)
+			}
+		}
+		return outputs, nil
+	case "map":
+		if !input.value.IsArray() && !input.value.IsObject() {
+			return nil, fmt.Errorf("cannot iterate over %s", typeName(input.value.data))
+		}
+		var mapped []interface{}
+		var mapErr error
+		input.value.eachChild(func(key string, child *YAMLValue) {
+			if mapErr != nil {
+				return
+			}
+			outs, err := n.arg.eval(evalItem{path: joinPath(input.path, key), value: child})
+			if err != nil {
+				mapErr = err
+				return
+			}
+			for _, out := range outs {
+				mapped = append(mapped, out.value.data)
+			}
+		})
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		if mapped == nil {
+			mapped = []interface{}{}
+		}
+		return []evalItem{{value: &YAMLValue{data: mapped}}}, nil
+	case "has":
+		keys, err := n.arg.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		var outputs []evalItem
+		for _, k := range keys {
+			outputs = append(outputs, evalItem{value: &YAMLValue{data: input.value.Has(k.value.data)}})
+		}
+		return outputs, nil
+	}
+	return nil, fmt.Errorf("unknown function %q", n.name)
+}
+
+// applyBinary evaluates a binary operator on two raw values
+func applyBinary(op string, left, right interface{}) (interface{}, error) {
+	switch op {
+	case "and":
+		return isTruthy(left) && isTruthy(right), nil
+	case "or":
+		return isTruthy(left) || isTruthy(right), nil
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		cmp, err := compareValues(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	}
+	return applyArithmetic(op, left, right)
+}
+
+// applyArithmetic evaluates +, -, *, / and % on raw values
+func applyArithmetic(op string, left, right interface{}) (interface{}, error) {
+	if op == "+" {
+		if left == nil {
+			return right, nil
+		}
+		if right == nil {
+			return left, nil
+		}
+		if ls, ok := left.(string); ok {
+			if rs, ok := right.(string); ok {
+				return ls + rs, nil
+			}
+		}
+		if la, ok := left.([]interface{}); ok {
+			if ra, ok := right.([]interface{}); ok {
+				result := make([]interface{}, 0, len(la)+len(ra))
+				return append(append(result, la...), ra...), nil
+			}
+		}
+	}
+
+	li, lInt := left.(int)
+	ri, rInt := right.(int)
+	if lInt && rInt {
+		switch op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		case "%":
+			if ri == 0 {
+				return nil, fmt.Errorf("modulo by zero")
+			}
+			return li % ri, nil
+		case "/":
+			if ri == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			if li%ri == 0 {
+				return li / ri, nil
+			}
+			return float64(li) / float64(ri), nil
+		}
+	}
+
+	lf, lok := toFloat(left)
+	rf, rok := toFloat(right)
+	if !lok || !rok {
+		return nil, fmt.Errorf("cannot apply %q to %s and %s", op, typeName(left), typeName(right))
+	}
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return lf / rf, nil
+	case "%":
+		if int(rf) == 0 {
+			return nil, fmt.Errorf("modulo by zero")
+		}
+		return int(lf) % int(rf), nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// compareValues orders two numbers or two strings
+func compareValues(left, right interface{}) (int, error) {
+	if lf, ok := toFloat(left); ok {
+		if rf, ok := toFloat(right); ok {
+			switch {
+			case lf < rf:
+				return -1, nil
+			case lf > rf:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	if ls, ok := left.(string); ok {
+		if rs, ok := right.(string); ok {
+			return strings.Compare(ls, rs), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %s with %s", typeName(left), typeName(right))
+}
+
+// valuesEqual compares raw values, treating equal ints and floats as equal
+func valuesEqual(left, right interface{}) bool {
+	if lf, ok := toFloat(left); ok {
+		if rf, ok := toFloat(right); ok {
+			return lf == rf
+		}
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// isTruthy follows jq semantics: only false and null are falsy
+func isTruthy(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	return true
+}
+
+// toFloat converts any numeric raw value to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// typeName describes the YAML type of a raw value for error messages
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	if _, ok := toFloat(v); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,85 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestEvalSelect(t *testing.T) {
+	yv, err := Loads(podYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	results, err := yv.Eval(`.spec.containers[] | select(.name == "app") | .image`)
+	if err != nil {
+		t.Fatalf("Failed to evaluate expression: %v", err)
+	}
+
+	if results.Len() != 1 {
+		t.Fatalf("Expected 1 result, got %d", results.Len())
+	}
+
+	if results.First().AsString() != "app:1.0" {
+		t.Errorf("Expected image 'app:1.0', got %s", results.First().AsString())
+	}
+
+	if results.Paths()[0] != "spec.containers.0.image" {
+		t.Errorf("Expected path 'spec.containers.0.image', got %s", results.Paths()[0])
+	}
+}
+
+func TestEvalBuiltins(t *testing.T) {
+	yv, err := Loads(podYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{".spec.containers | length", "2"},
+		{".spec.containers[0] | keys | length", "3"},
+		{".spec.containers | map(.port) | length", "2"},
+		{".spec.containers[-1].name", "sidecar"},
+		{".spec.containers[0].port + 1", "8081"},
+		{".spec.containers[1].port / 10 * 2", "1818"},
+		{`.spec.containers[0].name + "-" + .spec.containers[1].name`, "app-sidecar"},
+		{".spec.containers[0].port > 9000 or .spec.containers[1].port > 9000", "true"},
+		{`.spec.containers[0] | has("image")`, "true"},
+		{".spec.missing | not", "true"},
+		{`.spec."containers"[0]["name"]`, "app"},
+	}
+
+	for _, tt := range tests {
+		results, err := yv.Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q) returned error: %v", tt.expr, err)
+			continue
+		}
+		if got := results.First().AsString(); got != tt.expected {
+			t.Errorf("Eval(%q) = %s, expected %s", tt.expr, got, tt.expected)
+		}
+	}
+
+	names, err := yv.Eval(".spec.containers[].name")
+	if err != nil {
+		t.Fatalf("Failed to evaluate expression: %v", err)
+	}
+	if names.Len() != 2 || names.Strings()[1] != "sidecar" {
+		t.Errorf("Unexpected names: %v", names.Strings())
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	yv, err := Loads(podYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	for _, expr := range []string{".spec |", "select(", ".spec.containers[0].name - 1", "unknown", `"open`} {
+		if _, err := yv.Eval(expr); err == nil {
+			t.Errorf("Expected error for expression %q", expr)
+		}
+	}
+}
@@ -32,7 +32,7 @@ preferences:
   max_items: 100
 `
 
-	fmt.Println("=== easyYAML Demo ===\n")
+	fmt.Print("=== easyYAML Demo ===\n\n")
 
 	// Parse YAML
 	yv, err := easyyaml.Loads(yamlData)
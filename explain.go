@@ -0,0 +1,164 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeTrace records which layer supplied the final value at a path and
+// which earlier layers it overrode. Layers are numbered from 0 for the base.
+type MergeTrace struct {
+	Path       string
+	Value      *YAMLValue
+	Layer      int
+	Overridden []int
+}
+
+// MergeReport explains a MergeTraced result, one trace per leaf value
+type MergeReport struct {
+	// Layers names each layer by the file it was loaded from, when known
+	Layers []string
+	Traces []MergeTrace
+}
+
+// MergeTraced deep-merges overlays onto a copy of base, in order, and
+// reports which layer each final value came from. base is not modified.
+// Usage: merged, report, err := easyyaml.MergeTraced(base, env, local)
+func MergeTraced(base *YAMLValue, overlays ...*YAMLValue) (*YAMLValue, *MergeReport, error) {
+	merged := &YAMLValue{data: deepCopy(base.data), opts: base.opts, directives: base.directives, origins: copyOrigins(base.origins)}
+
+	report := &MergeReport{Layers: []string{layerName(0, base)}}
+	suppliers := make(map[string]int)
+	overridden := make(map[string][]int)
+	for path := range leafPaths(base) {
+		suppliers[path] = 0
+	}
+
+	for i, overlay := range overlays {
+		layer := i + 1
+		report.Layers = append(report.Layers, layerName(layer, overlay))
+		for path, value := range leafPaths(overlay) {
+			current := merged.Path(path)
+			if value.IsObject() && current.IsObject() {
+				// Merging an empty object changes nothing
+				continue
+			}
+			var previous []int
+			for prefix := path; ; {
+				if l, ok := suppliers[prefix]; ok {
+					previous = append(previous, l)
+					delete(suppliers, prefix)
+				}
+				dot := strings.LastIndex(prefix, ".")
+				if dot < 0 {
+					break
+				}
+				prefix = prefix[:dot]
+			}
+			if current.IsObject() || current.IsArray() {
+				for other, l := range suppliers {
+					if strings.HasPrefix(other, path+".") {
+						previous = append(previous, l)
+						delete(suppliers, other)
+						delete(overridden, other)
+					}
+				}
+			}
+			suppliers[path] = layer
+			overridden[path] = uniqueLayers(append(overridden[path], previous...))
+		}
+		if err := merged.merge(overlay); err != nil {
+			return nil, nil, fmt.Errorf("overlay %d: %w", layer, err)
+		}
+	}
+
+	for path, layer := range suppliers {
+		report.Traces = append(report.Traces, MergeTrace{
+			Path:       path,
+			Value:      merged.Path(path),
+			Layer:      layer,
+			Overridden: overridden[path],
+		})
+	}
+	sort.Slice(report.Traces, func(i, j int) bool {
+		return report.Traces[i].Path < report.Traces[j].Path
+	})
+	return merged, report, nil
+}
+
+// Trace returns the trace for a path
+func (r *MergeReport) Trace(path string) (MergeTrace, bool) {
+	for _, trace := range r.Traces {
+		if trace.Path == path {
+			return trace, true
+		}
+	}
+	return MergeTrace{}, false
+}
+
+// Table renders the report as an aligned text table with the path, final
+// value, the layer that supplied it and the layers it overrode
+func (r *MergeReport) Table() string {
+	rows := NewArray()
+	for _, trace := range r.Traces {
+		overrides := make([]string, len(trace.Overridden))
+		for i, layer := range trace.Overridden {
+			overrides[i] = r.Layers[layer]
+		}
+		rows.Append(map[string]interface{}{
+			"path":      trace.Path,
+			"value":     trace.Value.AsString(),
+			"from":      r.Layers[trace.Layer],
+			"overrides": strings.Join(overrides, ", "),
+		})
+	}
+	return rows.ToTable("path", "value", "from", "overrides")
+}
+
+// leafPaths returns the non-object values of a document by path, with
+// empty objects counted as leaves
+func leafPaths(yv *YAMLValue) map[string]*YAMLValue {
+	leaves := make(map[string]*YAMLValue)
+	yv.walk("", func(path string, value *YAMLValue) {
+		if path != "" && (!value.IsObject() || value.Len() == 0) {
+			leaves[path] = value
+		}
+	})
+	for path := range leaves {
+		// Array elements belong to the array, which merge replaces whole
+		for dot := strings.LastIndex(path, "."); dot >= 0; dot = strings.LastIndex(path[:dot], ".") {
+			if yv.Path(path[:dot]).IsArray() {
+				delete(leaves, path)
+				break
+			}
+		}
+	}
+	return leaves
+}
+
+// layerName names a layer by its source file, or by its position
+func layerName(layer int, yv *YAMLValue) string {
+	if origin, ok := yv.origins[""]; ok && origin.File != "" {
+		return origin.File
+	}
+	if layer == 0 {
+		return "base"
+	}
+	return fmt.Sprintf("overlay %d", layer)
+}
+
+// uniqueLayers sorts layers and removes duplicates
+func uniqueLayers(layers []int) []int {
+	sort.Ints(layers)
+	unique := layers[:0]
+	for i, layer := range layers {
+		if i == 0 || layer != layers[i-1] {
+			unique = append(unique, layer)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	return unique
+}
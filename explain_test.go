@@ -0,0 +1,74 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeTraced(t *testing.T) {
+	base, _ := Loads(`
+server:
+  host: localhost
+  port: 80
+  tls: {}
+features: [a, b]
+log: {level: info}
+`)
+	env, _ := Loads(`
+server:
+  port: 8080
+features: [c]
+`)
+	local, _ := Loads(`
+server:
+  port: 9090
+log: debug
+`)
+
+	merged, report, err := MergeTraced(base, env, local)
+	if err != nil {
+		t.Fatalf("MergeTraced failed: %v", err)
+	}
+	if merged.Path("server.port").AsInt() != 9090 || merged.Get("log").AsString() != "debug" {
+		t.Errorf("Expected merged values, got %v", merged.Raw())
+	}
+	if base.Path("server.port").AsInt() != 80 {
+		t.Errorf("Expected base unchanged, got %v", base.Raw())
+	}
+
+	tests := []struct {
+		path       string
+		layer      int
+		overridden []int
+	}{
+		{"server.host", 0, nil},
+		{"server.port", 2, []int{0, 1}},
+		{"server.tls", 0, nil},
+		{"features", 1, []int{0}},
+		{"log", 2, []int{0}},
+	}
+	for _, tt := range tests {
+		trace, ok := report.Trace(tt.path)
+		if !ok {
+			t.Errorf("Expected a trace for %s", tt.path)
+			continue
+		}
+		if trace.Layer != tt.layer || !reflect.DeepEqual(trace.Overridden, tt.overridden) {
+			t.Errorf("Expected %s from %d overriding %v, got %d overriding %v", tt.path, tt.layer, tt.overridden, trace.Layer, trace.Overridden)
+		}
+	}
+	if len(report.Traces) != len(tests) {
+		t.Errorf("Expected %d traces, got %+v", len(tests), report.Traces)
+	}
+
+	expected := `PATH         VALUE      FROM       OVERRIDES
+features     [c]        overlay 1  base
+log          debug      overlay 2  base
+server.host  localhost  base
+server.port  9090       overlay 2  base, overlay 1
+server.tls   {}         base
+`
+	if table := report.Table(); table != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, table)
+	}
+}
@@ -0,0 +1,148 @@
+package easyyaml
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Fetcher retrieves the raw bytes of a document from a URL, for LoadFrom
+type Fetcher interface {
+	Fetch(ctx context.Context, location *url.URL) (io.ReadCloser, error)
+}
+
+// FetcherFunc adapts a function to the Fetcher interface
+type FetcherFunc func(ctx context.Context, location *url.URL) (io.ReadCloser, error)
+
+// Fetch calls f
+func (f FetcherFunc) Fetch(ctx context.Context, location *url.URL) (io.ReadCloser, error) {
+	return f(ctx, location)
+}
+
+var (
+	fetchersMu sync.RWMutex
+	fetchers   = map[string]Fetcher{
+		"file":  FetcherFunc(fetchFile),
+		"http":  &HTTPFetcher{},
+		"https": &HTTPFetcher{},
+	}
+)
+
+// RegisterFetcher makes LoadFrom use f for URLs with the given scheme,
+// replacing any fetcher registered for it. "file", "http" and "https" are
+// registered by default.
+// Usage: easyyaml.RegisterFetcher("s3", easyyaml.ObjectFetcher(getS3Object))
+func RegisterFetcher(scheme string, f Fetcher) {
+	fetchersMu.Lock()
+	defer fetchersMu.Unlock()
+	fetchers[strings.ToLower(scheme)] = f
+}
+
+// LoadFrom loads a document from a URL using the Fetcher registered for its
+// scheme, e.g. "s3://bucket/config.yaml". A location without a scheme is read
+// as a local file. Gzipped content is decompressed. It returns an error
+// wrapping ErrNoFetcher for an unknown scheme.
+// Usage: cfg, err := easyyaml.LoadFrom(ctx, "https://example.com/config.yaml")
+func LoadFrom(ctx context.Context, location string, opts ...LoadOption) (*YAMLValue, error) {
+	ctx, span := startSpan(ctx, "easyyaml.LoadFrom")
+	span.SetAttribute("yaml.location", location)
+	yv, err := loadFrom(ctx, location, opts)
+	endSpan(span, err)
+	return yv, err
+}
+
+// loadFrom implements LoadFrom
+func loadFrom(ctx context.Context, location string, opts []LoadOption) (*YAMLValue, error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme == "" || len(u.Scheme) == 1 {
+		// no scheme, or a Windows drive letter
+		return loadFile(ctx, location, opts)
+	}
+	fetchersMu.RLock()
+	f, ok := fetchers[strings.ToLower(u.Scheme)]
+	fetchersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: for scheme %q", ErrNoFetcher, u.Scheme)
+	}
+
+	body, err := f.Fetch(ctx, u)
+	if err != nil {
+		logError("easyyaml: fetch failed", err, "location", location)
+		return nil, fmt.Errorf("failed to fetch %s: %w", location, err)
+	}
+	defer body.Close()
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", location, err)
+	}
+	yamlBytes, err := decompress(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", location, err)
+	}
+	yv, err := LoadContext(ctx, yamlBytes, append(opts, WithSourceName(location))...)
+	if err != nil {
+		logError("easyyaml: parse failed", err, "location", location)
+	}
+	return yv, err
+}
+
+// fetchFile opens the path of a file:// URL
+func fetchFile(_ context.Context, location *url.URL) (io.ReadCloser, error) {
+	return os.Open(location.Path)
+}
+
+// HTTPFetcher fetches documents with GET requests. Responses other than 2xx
+// are errors.
+type HTTPFetcher struct {
+	// Client sends the requests; http.DefaultClient if nil
+	Client *http.Client
+	// Header is added to every request, e.g. for an Authorization token
+	Header http.Header
+}
+
+// Fetch sends a GET request for location
+func (h *HTTPFetcher) Fetch(ctx context.Context, location *url.URL) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range h.Header {
+		req.Header[name] = values
+	}
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// ObjectGetter reads an object from a bucket, typically by calling an
+// object storage SDK such as the S3 or GCS client
+type ObjectGetter func(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+
+// ObjectFetcher adapts an ObjectGetter to a Fetcher for URLs of the form
+// scheme://bucket/key, so object storage can be used without this package
+// depending on any SDK
+// Usage: easyyaml.RegisterFetcher("gs", easyyaml.ObjectFetcher(func(ctx context.Context, bucket, key string) (io.ReadCloser, error) { return gcs.Bucket(bucket).Object(key).NewReader(ctx) }))
+func ObjectFetcher(get ObjectGetter) Fetcher {
+	return FetcherFunc(func(ctx context.Context, location *url.URL) (io.ReadCloser, error) {
+		key := strings.TrimPrefix(location.Path, "/")
+		if location.Host == "" || key == "" {
+			return nil, fmt.Errorf("%s is not a bucket/key URL", location)
+		}
+		return get(ctx, location.Host, key)
+	})
+}
@@ -0,0 +1,74 @@
+package easyyaml
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("name: remote\n"))
+	}))
+	defer server.Close()
+
+	yv, err := LoadFrom(context.Background(), server.URL+"/config.yaml")
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if yv.Get("name").AsString() != "remote" {
+		t.Errorf("Unexpected document: %v", yv.Raw())
+	}
+	if _, err := LoadFrom(context.Background(), server.URL+"/missing.yaml"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+}
+
+func TestLoadFromObjectStore(t *testing.T) {
+	objects := map[string]string{"configs/deploy/app.yaml": "replicas: 3\n"}
+	RegisterFetcher("mem", ObjectFetcher(func(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+		body, ok := objects[bucket+"/"+key]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader(body)), nil
+	}))
+
+	yv, err := LoadFrom(context.Background(), "mem://configs/deploy/app.yaml", WithProvenance())
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	if yv.Get("replicas").AsInt() != 3 {
+		t.Errorf("Unexpected document: %v", yv.Raw())
+	}
+	if origin, _ := yv.Provenance("replicas"); origin.File != "mem://configs/deploy/app.yaml" {
+		t.Errorf("Expected the URL as source name, got %q", origin.File)
+	}
+	if _, err := LoadFrom(context.Background(), "mem://configs/other.yaml"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the getter's error, got %v", err)
+	}
+	if _, err := LoadFrom(context.Background(), "ftp://host/config.yaml"); !errors.Is(err, ErrNoFetcher) {
+		t.Errorf("Expected ErrNoFetcher, got %v", err)
+	}
+}
+
+func TestLoadFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("a: 1\n"), 0644)
+	for _, location := range []string{path, "file://" + path} {
+		yv, err := LoadFrom(context.Background(), location)
+		if err != nil || yv.Get("a").AsInt() != 1 {
+			t.Errorf("LoadFrom(%q) = %v, %v", location, yv, err)
+		}
+	}
+}
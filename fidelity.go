@@ -0,0 +1,75 @@
+package easyyaml
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// fidelitySource is the original text of a document loaded in fidelity mode
+// together with a snapshot of what it decoded to and its node details
+type fidelitySource struct {
+	raw        []byte
+	snapshot   interface{}
+	meta       *docMeta
+	directives *Directives
+}
+
+// WithFidelity loads in fidelity mode: as long as the document is not
+// modified, Dump returns the original input byte for byte, keeping comments,
+// scalar styles, anchors and blank lines. Once modified, or when dumped with
+// layout options other than the package defaults, such as a WithIndent
+// view, it is re-marshaled.
+// Usage: doc, err := easyyaml.LoadFile("config.yaml", easyyaml.WithFidelity())
+func WithFidelity() LoadOption {
+	return func(opts *Options) {
+		opts.Fidelity = true
+	}
+}
+
+// newFidelitySource records raw as the text of yv
+func newFidelitySource(raw []byte, yv *YAMLValue) *fidelitySource {
+	source := &fidelitySource{raw: append([]byte(nil), raw...), snapshot: deepCopy(yv.data), meta: yv.meta.clone()}
+	if !yv.directives.isEmpty() {
+		directives := yv.Directives()
+		source.directives = &directives
+	}
+	return source
+}
+
+// reformats reports whether opts lay out a dump differently from the package
+// defaults, so the original text cannot stand in for it
+func (opts Options) reformats() bool {
+	defaults := Defaults()
+	return len(opts.KeyPriority) > 0 || opts.Indent != defaults.Indent || opts.AlignValues != defaults.AlignValues || opts.NullStyle != defaults.NullStyle
+}
+
+// unchanged reports whether yv still matches the text it was loaded from,
+// including the styles and tags set on it
+func (s *fidelitySource) unchanged(yv *YAMLValue) bool {
+	if !reflect.DeepEqual(s.snapshot, yv.data) || !s.meta.sameDetails(yv.meta) {
+		return false
+	}
+	if yv.directives.isEmpty() || s.directives == nil {
+		return yv.directives.isEmpty() && s.directives == nil
+	}
+	return reflect.DeepEqual(*s.directives, *yv.directives)
+}
+
+// RoundTripEqual loads input and dumps it again with the current defaults,
+// reporting whether the output is byte-identical. When it is not, diff lists
+// the lost or changed lines prefixed with "-" and their replacements with "+".
+// It is meant for tests of tools that rewrite files.
+func RoundTripEqual(input []byte) (bool, string) {
+	yv, err := Load(input)
+	if err != nil {
+		return false, fmt.Sprintf("load failed: %v", err)
+	}
+	out, err := yv.Dump()
+	if err != nil {
+		return false, fmt.Sprintf("dump failed: %v", err)
+	}
+	if string(out) == string(input) {
+		return true, ""
+	}
+	return false, lineDiff(string(input), string(out))
+}
@@ -0,0 +1,73 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+const fidelityYAML = `# Service configuration
+name: 'web'   # quoted on purpose
+
+defaults: &defaults
+  retries: 3
+server:
+  <<: *defaults
+  port: 0x1F90
+`
+
+func TestFidelityMode(t *testing.T) {
+	yv, err := Loads(fidelityYAML, WithFidelity())
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	out, err := yv.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps failed: %v", err)
+	}
+	if out != fidelityYAML {
+		t.Errorf("Expected byte-identical output, got:\n%s", out)
+	}
+
+	yv.Set("name", "api")
+	out, _ = yv.Dumps()
+	if out == fidelityYAML || !strings.Contains(out, "name: api") {
+		t.Errorf("Expected modified document to be re-marshaled, got:\n%s", out)
+	}
+
+	plain, _ := Loads(fidelityYAML)
+	if out, _ := plain.Dumps(); out == fidelityYAML {
+		t.Error("Expected default mode to re-marshal")
+	}
+}
+
+func TestFidelityDumpOptions(t *testing.T) {
+	yv, _ := Loads("# config\nserver:\n    host: x\n    port: ~\n", WithFidelity())
+	views := map[string]*YAMLValue{
+		"WithIndent":        yv.WithIndent(2),
+		"WithAlignedValues": yv.WithAlignedValues(),
+		"WithNullStyle":     yv.WithNullStyle(NullTilde),
+	}
+	for name, view := range views {
+		if out, _ := view.Dumps(); strings.Contains(out, "# config") {
+			t.Errorf("%s: expected the view to be re-marshaled, got %q", name, out)
+		}
+	}
+	if out, _ := yv.Dumps(); !strings.HasPrefix(out, "# config") {
+		t.Errorf("Expected the document itself to keep its text, got %q", out)
+	}
+}
+
+func TestRoundTripEqual(t *testing.T) {
+	if ok, diff := RoundTripEqual([]byte("a: 1\nb:\n    - x\n")); !ok {
+		t.Errorf("Expected canonical input to round-trip, got diff:\n%s", diff)
+	}
+
+	ok, diff := RoundTripEqual([]byte("# comment\na: 1\nb: [x]\n"))
+	if ok {
+		t.Fatal("Expected flow style and comments not to round-trip")
+	}
+	expected := "-# comment\n-b: [x]\n+b:\n+    - x\n"
+	if diff != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+}
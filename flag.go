@@ -0,0 +1,133 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// flagMode selects how a FlagValue applies each occurrence of its flag
+type flagMode int
+
+const (
+	flagSet flagMode = iota
+	flagSetString
+	flagValues
+)
+
+// FlagValue is a flag.Value that populates a YAMLValue from command-line
+// flags, Helm style. It also provides Type so it satisfies pflag.Value.
+//
+//	config := easyyaml.NewObject()
+//	flag.Var(easyyaml.ValuesFlag(config), "values", "YAML file to merge")
+//	flag.Var(easyyaml.SetFlag(config), "set", "override key=value")
+type FlagValue struct {
+	target *YAMLValue
+	mode   flagMode
+	values []string
+}
+
+// SetFlag returns a flag whose arguments are comma-separated path=value
+// pairs, e.g. --set server.port=9090,debug=true. Values are parsed as YAML
+// scalars, so numbers and booleans keep their types.
+func SetFlag(target *YAMLValue) *FlagValue {
+	return &FlagValue{target: target, mode: flagSet}
+}
+
+// SetStringFlag is like SetFlag but always stores values as strings
+func SetStringFlag(target *YAMLValue) *FlagValue {
+	return &FlagValue{target: target, mode: flagSetString}
+}
+
+// ValuesFlag returns a flag whose argument is a YAML file deep-merged into
+// the target, e.g. --values prod.yaml
+func ValuesFlag(target *YAMLValue) *FlagValue {
+	return &FlagValue{target: target, mode: flagValues}
+}
+
+// String returns the arguments given so far, comma-separated
+func (f *FlagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(f.values, ",")
+}
+
+// Type returns the flag type name shown in pflag usage output
+func (f *FlagValue) Type() string {
+	if f.mode == flagValues {
+		return "file"
+	}
+	return "key=value"
+}
+
+// Set applies one occurrence of the flag to the target
+func (f *FlagValue) Set(arg string) error {
+	if err := f.target.checkFrozen("flag", arg); err != nil {
+		return err
+	}
+	if f.target.data == nil {
+		f.target.data = make(map[interface{}]interface{})
+		f.target.missing = false
+	}
+
+	if f.mode == flagValues {
+		loaded, err := LoadFile(arg)
+		if err != nil {
+			return err
+		}
+		if loaded.IsNull() {
+			f.values = append(f.values, arg)
+			return nil
+		}
+		if err := f.target.Merge(loaded); err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+		f.values = append(f.values, arg)
+		return nil
+	}
+
+	for _, pair := range splitUnescaped(arg, ',') {
+		eq := strings.Index(pair, "=")
+		if eq <= 0 {
+			return fmt.Errorf("invalid assignment %q, expected path=value", pair)
+		}
+		path, raw := pair[:eq], pair[eq+1:]
+
+		var value interface{} = raw
+		if f.mode == flagSet {
+			parsed, err := Loads(raw)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %w", path, err)
+			}
+			value = parsed.data
+			if raw == "" {
+				value = ""
+			}
+		}
+		if err := f.target.SetPath(path, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", path, err)
+		}
+	}
+	f.values = append(f.values, arg)
+	return nil
+}
+
+// splitUnescaped splits s on sep, treating a backslash-escaped sep as literal
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == sep {
+			current.WriteByte(sep)
+			i++
+			continue
+		}
+		if s[i] == sep {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(s[i])
+	}
+	return append(parts, current.String())
+}
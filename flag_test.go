@@ -0,0 +1,54 @@
+package easyyaml
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFlagValues(t *testing.T) {
+	valuesFile := filepath.Join(t.TempDir(), "values.yaml")
+	if err := os.WriteFile(valuesFile, []byte("server:\n  host: localhost\n  port: 8080\n"), 0644); err != nil {
+		t.Fatalf("Failed to write values file: %v", err)
+	}
+
+	config := NewObject()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(ValuesFlag(config), "values", "values file")
+	fs.Var(SetFlag(config), "set", "override")
+	fs.Var(SetStringFlag(config), "set-string", "string override")
+
+	err := fs.Parse([]string{
+		"--values", valuesFile,
+		"--set", "server.port=9090,debug=true",
+		"--set", `tags.0=a\,b`,
+		"--set-string", "version=1.10",
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if config.Path("server.host").AsString() != "localhost" {
+		t.Errorf("Expected host from values file, got %s", config.Path("server.host").AsString())
+	}
+	if config.Path("server.port").AsInt() != 9090 {
+		t.Errorf("Expected port override 9090, got %d", config.Path("server.port").AsInt())
+	}
+	if !config.Get("debug").IsBool() {
+		t.Errorf("Expected debug to be a boolean, got %T", config.Get("debug").Raw())
+	}
+	if config.Path("tags.0").AsString() != "a,b" {
+		t.Errorf("Expected escaped comma to be kept, got %s", config.Path("tags.0").AsString())
+	}
+	if config.Get("version").Raw() != "1.10" {
+		t.Errorf("Expected version to stay a string, got %v", config.Get("version").Raw())
+	}
+
+	if err := SetFlag(config).Set("novalue"); err == nil {
+		t.Error("Expected error for assignment without '='")
+	}
+	if SetFlag(config).Type() != "key=value" || ValuesFlag(config).Type() != "file" {
+		t.Error("Unexpected flag type names")
+	}
+}
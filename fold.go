@@ -0,0 +1,98 @@
+package easyyaml
+
+import (
+	"sort"
+	"strings"
+)
+
+// WithCaseInsensitiveKeys makes Get, Path and Q on the loaded value fall
+// back to a case-insensitive match when an object has no exact key
+// Usage: cfg, err := easyyaml.LoadFile("app.yaml", easyyaml.WithCaseInsensitiveKeys())
+func WithCaseInsensitiveKeys() LoadOption {
+	return func(opts *Options) {
+		opts.FoldKeys = true
+	}
+}
+
+// GetFold retrieves an object value by key, ignoring case when there is no
+// exact match. If several keys match, the first in sorted order wins.
+// Usage: port := cfg.GetFold("Port").AsInt()
+func (yv *YAMLValue) GetFold(key string) *YAMLValue {
+	if value, ok := yv.Lookup(key); ok {
+		return value
+	}
+	value, _ := yv.lookupFold(key)
+	return value
+}
+
+// lookupFold finds the first key, in sorted order, equal to key under
+// Unicode case folding
+func (yv *YAMLValue) lookupFold(key string) (*YAMLValue, bool) {
+	if !yv.IsObject() {
+		return missingValue(), false
+	}
+	var match string
+	found := false
+	yv.eachChild(func(k string, child *YAMLValue) {
+		if !found && strings.EqualFold(k, key) {
+			match, found = k, true
+		}
+	})
+	if !found {
+		return missingValue(), false
+	}
+	return yv.lookupKeyString(match)
+}
+
+// SuggestKey returns the object's keys that are close to key, nearest
+// first, for "did you mean" messages. Keys are compared ignoring case and
+// must be within an edit distance of 2, or a third of key's length for
+// longer keys.
+// Usage: if !cfg.Has(name) { log.Printf("unknown key %s, did you mean %v?", name, cfg.SuggestKey(name)) }
+func (yv *YAMLValue) SuggestKey(key string) []string {
+	if !yv.IsObject() {
+		return nil
+	}
+	limit := len([]rune(key)) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	lower := strings.ToLower(key)
+	distances := make(map[string]int)
+	var suggestions []string
+	yv.eachChild(func(k string, child *YAMLValue) {
+		if k == key {
+			return
+		}
+		if d := levenshtein(lower, strings.ToLower(k)); d <= limit {
+			distances[k] = d
+			suggestions = append(suggestions, k)
+		}
+	})
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return distances[suggestions[i]] < distances[suggestions[j]]
+	})
+	return suggestions
+}
+
+// levenshtein returns the edit distance between two strings in runes
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
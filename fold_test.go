@@ -0,0 +1,58 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetFold(t *testing.T) {
+	yv, _ := Loads("Server:\n  Port: 80\nname: app\nNAME: other\n")
+	if yv.GetFold("server").GetFold("PORT").AsInt() != 80 {
+		t.Errorf("Expected case-insensitive match, got %v", yv.GetFold("server").Raw())
+	}
+	if yv.GetFold("name").AsString() != "app" {
+		t.Errorf("Expected exact match to win, got %v", yv.GetFold("name").Raw())
+	}
+	if yv.GetFold("Name").AsString() != "other" {
+		t.Errorf("Expected first sorted match, got %v", yv.GetFold("Name").Raw())
+	}
+	if yv.Get("server").Exists() {
+		t.Error("Expected Get to stay case-sensitive by default")
+	}
+}
+
+func TestWithCaseInsensitiveKeys(t *testing.T) {
+	yv, err := Loads("Server:\n  Hosts: [a, b]\n", WithCaseInsensitiveKeys())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if yv.Path("server.hosts.1").AsString() != "b" || yv.Q("SERVER", "hosts", 0).AsString() != "a" {
+		t.Errorf("Expected folded lookups to resolve, got %v", yv.Raw())
+	}
+	if yv.Path("server.hosts").Get("0").Exists() {
+		t.Error("Expected string keys not to index arrays")
+	}
+
+	docs, _ := LoadAll([]byte("A: 1\n---\nB: 2\n"), WithCaseInsensitiveKeys())
+	if docs.Get(1).Get("b").AsInt() != 2 {
+		t.Error("Expected LoadAll documents to fold keys")
+	}
+}
+
+func TestSuggestKey(t *testing.T) {
+	yv, _ := Loads("timeout: 1\ntime_out: 2\nport: 3\nhost: 4\nretries: 5\n")
+	if got := yv.SuggestKey("timout"); !reflect.DeepEqual(got, []string{"timeout", "time_out"}) {
+		t.Errorf("Expected [timeout time_out], got %v", got)
+	}
+	if got := yv.SuggestKey("PROT"); !reflect.DeepEqual(got, []string{"port"}) {
+		t.Errorf("Expected [port], got %v", got)
+	}
+	if got := yv.SuggestKey("database"); len(got) != 0 {
+		t.Errorf("Expected no suggestions, got %v", got)
+	}
+
+	_, err := yv.PathE("retires")
+	if err == nil || err.Error() != `path retires: key not found: did you mean "retries"?` {
+		t.Errorf("Expected suggestion in error, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package easyyaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatOptions controls how Format normalizes YAML
+type FormatOptions struct {
+	// Indent is the number of spaces per nesting level (default 2)
+	Indent int
+	// DocumentStart emits a leading "---" marker before every document
+	DocumentStart bool
+	// KeepQuotes leaves quoted strings quoted even when they could be plain
+	KeepQuotes bool
+}
+
+// Format re-indents YAML, unquotes strings that do not need quoting, trims
+// trailing whitespace and optionally enforces document-start markers. Key
+// order and comments are preserved, so it is suitable for pre-commit hooks.
+func Format(input []byte, opts FormatOptions) ([]byte, error) {
+	indent := opts.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(input))
+	var docs []*yaml.Node
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, &doc)
+	}
+
+	var out bytes.Buffer
+	for i, doc := range docs {
+		if !opts.KeepQuotes {
+			unquoteScalars(doc)
+		}
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(indent)
+		if err := enc.Encode(doc); err != nil {
+			return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+
+		if opts.DocumentStart || i > 0 {
+			out.WriteString("---\n")
+		}
+		out.WriteString(trimTrailingSpace(buf.String()))
+	}
+	return out.Bytes(), nil
+}
+
+// FormatFile formats a YAML file in place. Like DumpFile it reads and
+// writes gzipped files, and the file keeps its permissions.
+func FormatFile(filename string, opts FormatOptions) error {
+	input, err := os.ReadFile(filename)
+	if err == nil {
+		input, err = decompress(input)
+	}
+	if err != nil {
+		logError("easyyaml: read failed", err, "file", filename)
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	formatted, err := Format(input, opts)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(input, formatted) {
+		return nil
+	}
+	if err := writeFile(filename, formatted); err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// unquoteScalars drops quoting from string scalars that the encoder would
+// itself emit plain, so values like "8080" or 'yes' stay quoted
+func unquoteScalars(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" &&
+		(node.Style == yaml.SingleQuotedStyle || node.Style == yaml.DoubleQuotedStyle) {
+		if out, err := yaml.Marshal(node.Value); err == nil && strings.TrimSuffix(string(out), "\n") == node.Value {
+			node.Style = 0
+		}
+	}
+	for _, child := range node.Content {
+		unquoteScalars(child)
+	}
+}
+
+// trimTrailingSpace strips trailing spaces and tabs from every line
+func trimTrailingSpace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
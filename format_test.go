@@ -0,0 +1,88 @@
+package easyyaml
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	input := "# app config\nname:    'app'   \nserver:\n    port: \"8080\"\n    host: \"localhost\" # bind address\nlist:\n- \"a\"\n- 'yes'\n"
+
+	out, err := Format([]byte(input), FormatOptions{DocumentStart: true})
+	if err != nil {
+		t.Fatalf("Failed to format: %v", err)
+	}
+
+	expected := "---\n# app config\nname: app\nserver:\n  port: \"8080\"\n  host: localhost # bind address\nlist:\n  - a\n  - 'yes'\n"
+	if string(out) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	again, err := Format(out, FormatOptions{DocumentStart: true})
+	if err != nil {
+		t.Fatalf("Failed to format: %v", err)
+	}
+	if string(again) != string(out) {
+		t.Errorf("Expected formatting to be idempotent, got:\n%s", again)
+	}
+}
+
+func TestFormatOptions(t *testing.T) {
+	input := "a:\n  b: 'text'\n---\nc: 1\n"
+
+	out, err := Format([]byte(input), FormatOptions{Indent: 4, KeepQuotes: true})
+	if err != nil {
+		t.Fatalf("Failed to format: %v", err)
+	}
+
+	expected := "a:\n    b: 'text'\n---\nc: 1\n"
+	if string(out) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	if _, err := Format([]byte("a: [1"), FormatOptions{}); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
+
+func TestFormatFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(filename, []byte("a:\n      b: 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := FormatFile(filename, FormatOptions{}); err != nil {
+		t.Fatalf("Failed to format file: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "a:\n  b: 1\n" {
+		t.Errorf("Unexpected formatted file:\n%s", data)
+	}
+}
+
+func TestFormatFileKeepsModeAndGzip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "secrets.yaml.gz")
+	yv, _ := Loads("a:\n      b: 1\n")
+	if err := yv.WithIndent(6).DumpFile(filename); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	os.Chmod(filename, 0600)
+
+	if err := FormatFile(filename, FormatOptions{}); err != nil {
+		t.Fatalf("Failed to format file: %v", err)
+	}
+	if info, _ := os.Stat(filename); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the file to keep mode 0600, got %v", info.Mode().Perm())
+	}
+	raw, _ := os.ReadFile(filename)
+	data, err := decompress(raw)
+	if err != nil || string(data) != "a:\n  b: 1\n" || bytes.Equal(raw, data) {
+		t.Errorf("Expected the file to be formatted and stay gzipped, got %q, %v", data, err)
+	}
+}
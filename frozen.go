@@ -0,0 +1,28 @@
+package easyyaml
+
+// Freeze makes the whole document the value belongs to read-only: Set,
+// Delete, Append, SetPath, Update, Merge and the other mutators return an
+// error wrapping ErrFrozen, for the value and for every value reached
+// through it with Get or Path. Clone returns a copy that can be changed.
+// Data obtained with Raw is not protected.
+// Usage: defaults.Freeze(); cfg := defaults.Clone()
+func (yv *YAMLValue) Freeze() {
+	if yv.meta == nil {
+		yv.meta = &docMeta{}
+	}
+	yv.meta.frozen = true
+}
+
+// IsFrozen reports whether the value's document has been frozen
+func (yv *YAMLValue) IsFrozen() bool {
+	return yv.meta != nil && yv.meta.frozen
+}
+
+// checkFrozen returns an error wrapping ErrFrozen for op on a frozen
+// document
+func (yv *YAMLValue) checkFrozen(op string, key interface{}) error {
+	if yv.IsFrozen() {
+		return opError(op, key, ErrFrozen, "")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	yv, _ := Loads("server:\n  host: localhost\n  ports: [80]\nname: app\n")
+	yv.Freeze()
+	if !yv.IsFrozen() || !yv.Get("server").IsFrozen() {
+		t.Fatal("Expected document and children to be frozen")
+	}
+
+	server := yv.Get("server")
+	mutations := map[string]error{
+		"Set":       yv.Set("name", "other"),
+		"Delete":    yv.Delete("name"),
+		"SetPath":   yv.SetPath("server.host", "example.com"),
+		"Update":    yv.Update(NewObject()),
+		"Merge":     yv.Merge(NewObject()),
+		"ChildSet":  server.Set("host", "example.com"),
+		"Append":    server.Get("ports").Append(443),
+		"SetStyle":  yv.Get("name").SetStyle(DoubleQuotedStyle),
+		"RenameKey": yv.RenameKey("", "name", "title"),
+		"Unmarshal": yv.UnmarshalText([]byte("name: other\n")),
+		"Scan":      yv.Scan("name: other\n"),
+		"ScanNull":  yv.Scan(nil),
+		"Flag":      SetFlag(yv).Set("name=other"),
+	}
+	if _, err := yv.Prune(PruneOptions{Nulls: true, EmptyMaps: true}); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Prune: expected ErrFrozen, got %v", err)
+	}
+	for name, err := range mutations {
+		if !errors.Is(err, ErrFrozen) {
+			t.Errorf("%s: expected ErrFrozen, got %v", name, err)
+		}
+	}
+	if yv.Path("server.host").AsString() != "localhost" || yv.Path("server.ports").Len() != 1 || yv.Get("name").AsString() != "app" {
+		t.Errorf("Frozen document was changed: %s", yv)
+	}
+
+	clone := yv.Clone()
+	if clone.IsFrozen() {
+		t.Error("Expected clone to be mutable")
+	}
+	if err := clone.Set("name", "other"); err != nil {
+		t.Errorf("Unexpected error on clone: %v", err)
+	}
+}
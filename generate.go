@@ -0,0 +1,163 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateFromSchema builds a skeleton document from a JSON Schema. Each
+// value is taken from the schema's default, const, first enum or first
+// example, falling back to a zero value of the declared type.
+func GenerateFromSchema(schema *YAMLValue) *YAMLValue {
+	return &YAMLValue{data: generateValue(schema)}
+}
+
+// GenerateFromSchemaBytes renders the skeleton from GenerateFromSchema as
+// YAML, adding each property's description as a comment above its key
+// Usage: out, err := easyyaml.GenerateFromSchemaBytes(schema) // myapp config init
+func GenerateFromSchemaBytes(schema *YAMLValue) ([]byte, error) {
+	node, err := generateNode(schema)
+	if err != nil {
+		return nil, err
+	}
+	if desc := schema.Get("description"); desc.IsString() {
+		node.HeadComment = desc.AsString()
+	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}
+	return yaml.Marshal(doc)
+}
+
+// schemaExample returns the schema's default, const, first enum or first
+// example value, reporting whether one was found
+func schemaExample(schema *YAMLValue) (interface{}, bool) {
+	for _, key := range []string{"default", "const"} {
+		if v, ok := schema.Lookup(key); ok {
+			return v.data, true
+		}
+	}
+	for _, key := range []string{"enum", "examples"} {
+		if v := schema.Get(key); v.IsArray() && v.Len() > 0 {
+			return v.Get(0).data, true
+		}
+	}
+	return nil, false
+}
+
+// schemaVariant resolves anyOf/oneOf/allOf by picking the first alternative
+func schemaVariant(schema *YAMLValue) *YAMLValue {
+	for _, key := range []string{"anyOf", "oneOf", "allOf"} {
+		if v := schema.Get(key); v.IsArray() && v.Len() > 0 && !schema.Has("type") {
+			return v.Get(0)
+		}
+	}
+	return schema
+}
+
+// schemaType returns the declared type, taking the first non-null entry of a type list
+func schemaType(schema *YAMLValue) string {
+	t := schema.Get("type")
+	if t.IsArray() {
+		for _, entry := range t.AsArray() {
+			if entry.AsString() != "null" {
+				return entry.AsString()
+			}
+		}
+		return "null"
+	}
+	if t.IsString() {
+		return t.AsString()
+	}
+	if schema.Has("properties") {
+		return "object"
+	}
+	if schema.Has("items") {
+		return "array"
+	}
+	return ""
+}
+
+// sortedProperties returns the property names of an object schema in sorted order
+func sortedProperties(schema *YAMLValue) []string {
+	var names []string
+	schema.Get("properties").eachChild(func(key string, child *YAMLValue) {
+		names = append(names, key)
+	})
+	sort.Strings(names)
+	return names
+}
+
+// generateValue produces the raw skeleton value for a schema
+func generateValue(schema *YAMLValue) interface{} {
+	schema = schemaVariant(schema)
+	if v, ok := schemaExample(schema); ok {
+		return v
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		obj := make(map[string]interface{})
+		props := schema.Get("properties")
+		for _, name := range sortedProperties(schema) {
+			obj[name] = generateValue(props.Get(name))
+		}
+		return obj
+	case "array":
+		if items := schema.Get("items"); items.IsObject() {
+			return []interface{}{generateValue(items)}
+		}
+		return []interface{}{}
+	case "string":
+		return ""
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return false
+	}
+	return nil
+}
+
+// generateNode produces the skeleton as a yaml.Node so descriptions can be
+// attached as comments
+func generateNode(schema *YAMLValue) (*yaml.Node, error) {
+	schema = schemaVariant(schema)
+	if _, ok := schemaExample(schema); !ok {
+		switch schemaType(schema) {
+		case "object":
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			props := schema.Get("properties")
+			for _, name := range sortedProperties(schema) {
+				prop := props.Get(name)
+				key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+				if desc := prop.Get("description"); desc.IsString() {
+					key.HeadComment = desc.AsString()
+				}
+				value, err := generateNode(prop)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, key, value)
+			}
+			return node, nil
+		case "array":
+			node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			if items := schema.Get("items"); items.IsObject() {
+				item, err := generateNode(items)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, item)
+			}
+			return node, nil
+		}
+	}
+
+	var node yaml.Node
+	if err := node.Encode(generateValue(schema)); err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	return &node, nil
+}
@@ -0,0 +1,88 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+const serverSchema = `
+description: Server configuration
+type: object
+properties:
+  host:
+    type: string
+    description: Address to bind
+    default: 0.0.0.0
+  port:
+    type: integer
+    default: 8080
+  mode:
+    enum: [production, development]
+  tls:
+    type: object
+    properties:
+      enabled:
+        type: boolean
+      cert:
+        type: [string, "null"]
+  tags:
+    type: array
+    items:
+      type: string
+`
+
+func TestGenerateFromSchema(t *testing.T) {
+	schema, err := Loads(serverSchema)
+	if err != nil {
+		t.Fatalf("Failed to load schema: %v", err)
+	}
+
+	doc := GenerateFromSchema(schema)
+
+	if doc.Get("host").AsString() != "0.0.0.0" {
+		t.Errorf("Expected default host, got %s", doc.Get("host").AsString())
+	}
+	if doc.Get("port").AsInt() != 8080 {
+		t.Errorf("Expected default port, got %d", doc.Get("port").AsInt())
+	}
+	if doc.Get("mode").AsString() != "production" {
+		t.Errorf("Expected first enum value, got %s", doc.Get("mode").AsString())
+	}
+	if !doc.Path("tls.enabled").IsBool() || doc.Path("tls.enabled").AsBool() {
+		t.Error("Expected tls.enabled placeholder to be false")
+	}
+	if !doc.Path("tls.cert").IsString() {
+		t.Error("Expected tls.cert placeholder to be a string")
+	}
+	if doc.Get("tags").Len() != 1 {
+		t.Errorf("Expected one placeholder tag, got %d", doc.Get("tags").Len())
+	}
+}
+
+func TestGenerateFromSchemaBytes(t *testing.T) {
+	schema, err := Loads(serverSchema)
+	if err != nil {
+		t.Fatalf("Failed to load schema: %v", err)
+	}
+
+	out, err := GenerateFromSchemaBytes(schema)
+	if err != nil {
+		t.Fatalf("Failed to generate document: %v", err)
+	}
+
+	text := string(out)
+	if !strings.HasPrefix(text, "# Server configuration\n") {
+		t.Errorf("Expected document comment, got:\n%s", text)
+	}
+	if !strings.Contains(text, "# Address to bind\nhost: 0.0.0.0\n") {
+		t.Errorf("Expected description comment above host, got:\n%s", text)
+	}
+
+	doc, err := Load(out)
+	if err != nil {
+		t.Fatalf("Generated document does not parse: %v", err)
+	}
+	if doc.Get("port").AsInt() != 8080 {
+		t.Errorf("Expected port 8080, got %d", doc.Get("port").AsInt())
+	}
+}
@@ -0,0 +1,74 @@
+package easyyaml
+
+import (
+	"strconv"
+	"strings"
+)
+
+// pathTrie groups dot-separated paths by their shared prefixes
+type pathTrie struct {
+	children map[string]*pathTrie
+	order    []string
+	// paths lists the requested paths that end at this node
+	paths []string
+}
+
+// insert adds path under the trie, segment by segment
+func (t *pathTrie) insert(path string) {
+	node := t
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+		child, ok := node.children[part]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[string]*pathTrie)
+			}
+			child = &pathTrie{}
+			node.children[part] = child
+			node.order = append(node.order, part)
+		}
+		node = child
+	}
+	node.paths = append(node.paths, path)
+}
+
+// GetPaths retrieves several dot-separated paths at once, as Path would,
+// walking each shared prefix only once. The result has an entry for every
+// path; those that do not resolve, or are longer than the segment limit,
+// map to a missing value.
+// Usage: fields := doc.GetPaths("metadata.name", "metadata.namespace", "spec.template.spec.containers.0.image")
+func (yv *YAMLValue) GetPaths(paths ...string) map[string]*YAMLValue {
+	values := make(map[string]*YAMLValue, len(paths))
+	limits := resolvePathLimits(nil)
+	root := &pathTrie{}
+	for _, path := range paths {
+		if limits.checkSegments(path) != nil {
+			values[path] = missingValue()
+			continue
+		}
+		root.insert(path)
+	}
+	root.resolve(yv, values)
+	return values
+}
+
+// resolve records value for the paths ending at t and descends into its
+// children
+func (t *pathTrie) resolve(value *YAMLValue, values map[string]*YAMLValue) {
+	for _, path := range t.paths {
+		values[path] = value
+	}
+	for _, part := range t.order {
+		next := value
+		if value.Exists() {
+			if index, err := strconv.Atoi(part); err == nil {
+				next = value.Get(index)
+			} else {
+				next = value.Get(part)
+			}
+		}
+		t.children[part].resolve(next, values)
+	}
+}
@@ -0,0 +1,32 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetPaths(t *testing.T) {
+	yv, _ := Loads(`metadata:
+  name: web
+  labels: {app: web}
+spec:
+  containers:
+    - image: web:1
+    - image: proxy:2
+`)
+	long := strings.Repeat("a.", DefaultMaxPathSegments) + "a"
+	paths := []string{"metadata.name", "metadata.labels.app", "spec.containers.0.image", "spec.containers.1.image", "spec.containers.2.image", "missing.key", "metadata.name.x", "", long}
+	values := yv.GetPaths(paths...)
+	if len(values) != len(paths) {
+		t.Fatalf("Expected %d entries, got %d", len(paths), len(values))
+	}
+	for _, path := range paths {
+		want, got := yv.Path(path), values[path]
+		if want.Exists() != got.Exists() || want.Exists() && want.String() != got.String() {
+			t.Errorf("%q: expected %v, got %v", path, want.Raw(), got.Raw())
+		}
+	}
+	if values["spec.containers.1.image"].AsString() != "proxy:2" || values[""].Len() != 2 {
+		t.Errorf("Unexpected values: %v", values)
+	}
+}
@@ -6,5 +6,6 @@ toolchain go1.24.2
 
 require (
 	github.com/javanhut/easyjson v0.1.0
+	google.golang.org/protobuf v1.36.12
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,41 @@
+package easyyaml
+
+// interner deduplicates strings so that equal scalars and keys decoded from
+// one load share a single backing allocation
+type interner map[string]string
+
+// intern returns the canonical copy of s
+func (in interner) intern(s string) string {
+	if canonical, ok := in[s]; ok {
+		return canonical
+	}
+	in[s] = s
+	return s
+}
+
+// internAll replaces every string key and value in data with its canonical
+// copy and returns the updated data
+func (in interner) internAll(data interface{}) interface{} {
+	switch v := data.(type) {
+	case string:
+		return in.intern(v)
+	case map[string]interface{}:
+		for k, val := range v {
+			// Assigning with an equal key replaces the stored key
+			v[in.intern(k)] = in.internAll(val)
+		}
+	case map[interface{}]interface{}:
+		for k, val := range v {
+			if s, ok := k.(string); ok {
+				v[in.intern(s)] = in.internAll(val)
+				continue
+			}
+			v[k] = in.internAll(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = in.internAll(val)
+		}
+	}
+	return data
+}
@@ -0,0 +1,85 @@
+package easyyaml
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestLoadWithInterning(t *testing.T) {
+	input := "a:\n  phase: Running\nb:\n  phase: Running\n"
+	yv, err := Loads(input, WithInterning())
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	first := yv.Path("a.phase").AsString()
+	second := yv.Path("b.phase").AsString()
+	if first != "Running" || second != "Running" {
+		t.Fatalf("Expected Running twice, got %q and %q", first, second)
+	}
+	if unsafe.StringData(first) != unsafe.StringData(second) {
+		t.Error("Expected equal strings to share storage")
+	}
+
+	docs, err := LoadAlls("kind: Pod\n---\nkind: Pod\n", WithInterning())
+	if err != nil {
+		t.Fatalf("Failed to load stream: %v", err)
+	}
+	k1 := docs.Get(0).Get("kind").AsString()
+	k2 := docs.Get(1).Get("kind").AsString()
+	if unsafe.StringData(k1) != unsafe.StringData(k2) {
+		t.Error("Expected strings to be shared across documents")
+	}
+
+	plain, err := Loads(input)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	if unsafe.StringData(plain.Path("a.phase").AsString()) == unsafe.StringData(plain.Path("b.phase").AsString()) {
+		t.Error("Expected strings not to be interned by default")
+	}
+}
+
+// internCorpus builds a stream of manifests with many repeated values
+func internCorpus() []byte {
+	var sb strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&sb, "---\nkind: Pod\nmetadata:\n  name: pod-%d\n  namespace: production\nstatus:\n  phase: Running\n  qosClass: Burstable\n", i)
+	}
+	return []byte(sb.String())
+}
+
+// benchmarkLoadAll loads the corpus b.N times and reports the heap retained
+// by the last result, which is what interning reduces
+func benchmarkLoadAll(b *testing.B, opts ...LoadOption) {
+	corpus := internCorpus()
+	b.ReportAllocs()
+	var docs *Documents
+	for i := 0; i < b.N; i++ {
+		var err error
+		if docs, err = LoadAll(corpus, opts...); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	var before, after runtime.MemStats
+	docs = nil
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	docs, _ = LoadAll(corpus, opts...)
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.HeapAlloc)-float64(before.HeapAlloc), "retained-B")
+	runtime.KeepAlive(docs)
+}
+
+func BenchmarkLoadAll(b *testing.B) {
+	benchmarkLoadAll(b)
+}
+
+func BenchmarkLoadAllInterning(b *testing.B) {
+	benchmarkLoadAll(b, WithInterning())
+}
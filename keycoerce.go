@@ -0,0 +1,52 @@
+package easyyaml
+
+import "strconv"
+
+// WithKeyCoercion makes Get, Has, Delete, Path and Q on the loaded value
+// treat integer keys and their decimal strings as the same key, so a
+// port-keyed map written as "8080:" can be read with Get("8080") and a
+// quoted "8080": with Get(8080)
+// Usage: svc, err := easyyaml.LoadFile("ports.yaml", easyyaml.WithKeyCoercion())
+func WithKeyCoercion() LoadOption {
+	return func(opts *Options) {
+		opts.CoerceKeys = true
+	}
+}
+
+// coerceKey returns the key under which an object stores key: key itself
+// if present or key coercion is off, otherwise its int or string
+// counterpart when that is present
+func (yv *YAMLValue) coerceKey(key interface{}) interface{} {
+	if !yv.coerceKeys {
+		return key
+	}
+	var alternate interface{}
+	switch k := key.(type) {
+	case int:
+		alternate = strconv.Itoa(k)
+	case string:
+		n, err := strconv.Atoi(k)
+		if err != nil || strconv.Itoa(n) != k {
+			return key
+		}
+		alternate = n
+	default:
+		return key
+	}
+
+	switch m := yv.data.(type) {
+	case map[string]interface{}:
+		if s, ok := alternate.(string); ok {
+			if _, exists := m[s]; exists {
+				return s
+			}
+		}
+	case map[interface{}]interface{}:
+		if _, exists := m[key]; !exists {
+			if _, exists := m[alternate]; exists {
+				return alternate
+			}
+		}
+	}
+	return key
+}
@@ -0,0 +1,49 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestWithKeyCoercion(t *testing.T) {
+	input := "ports:\n  8080: http\n  \"8443\": https\n  \"08\": padded\n"
+	plain, _ := Loads(input)
+	if plain.Get("ports").Get("8080").Exists() || plain.Get("ports").Get(8443).Exists() {
+		t.Error("Expected no key coercion by default")
+	}
+
+	yv, err := Loads(input, WithKeyCoercion())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	ports := yv.Get("ports")
+	if ports.Get("8080").AsString() != "http" || ports.Get(8443).AsString() != "https" {
+		t.Errorf("Expected coerced lookups, got %v", ports.Raw())
+	}
+	if yv.Path("ports.8443").AsString() != "https" || yv.Q("ports", "8080").AsString() != "http" {
+		t.Error("Expected Path and Q to coerce keys")
+	}
+	if !ports.Has("8080") || !ports.Has(8443) {
+		t.Error("Expected Has to coerce keys")
+	}
+	if ports.Has(8) {
+		t.Error("Expected 8 not to match the non-canonical key \"08\"")
+	}
+
+	if err := ports.Delete("8080"); err != nil || ports.Has(8080) {
+		t.Errorf("Expected Delete to remove the int key, got %v", err)
+	}
+	if err := ports.Delete(8443); err != nil || ports.Has("8443") {
+		t.Errorf("Expected Delete to remove the string key, got %v", err)
+	}
+}
+
+func TestKeyCoercionStringKeyedMap(t *testing.T) {
+	yv := New(map[string]interface{}{"80": "http"})
+	yv.coerceKeys = true
+	if yv.Get(80).AsString() != "http" || !yv.Has(80) {
+		t.Errorf("Expected int key to find string key, got %v", yv.Get(80).Raw())
+	}
+	if err := yv.Delete(80); err != nil || yv.Len() != 0 {
+		t.Errorf("Expected Delete to coerce, got %v", err)
+	}
+}
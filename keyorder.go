@@ -0,0 +1,115 @@
+package easyyaml
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SortKeys returns a view of the value that dumps the top-level keys in
+// priority first, in that order, followed by the rest alphabetically
+// Usage: manifest.SortKeys([]string{"apiVersion", "kind", "metadata", "spec"}).DumpFile("out.yaml")
+func (yv *YAMLValue) SortKeys(priority []string) *YAMLValue {
+	opts := yv.options()
+	opts.KeyPriority = append([]string(nil), priority...)
+	opts.KeyPriorityNested = false
+	return yv.WithOptions(opts)
+}
+
+// SortKeysNested is like SortKeys but orders the keys of nested mappings too
+func (yv *YAMLValue) SortKeysNested(priority []string) *YAMLValue {
+	view := yv.SortKeys(priority)
+	view.opts.KeyPriorityNested = true
+	return view
+}
+
+// marshalOrdered marshals data like marshalIndent, moving the keys listed
+// in opts.KeyPriority to the front of their mappings and writing nulls in
+// opts.NullStyle
+func marshalOrdered(data interface{}, opts Options) ([]byte, error) {
+	if len(opts.KeyPriority) == 0 && opts.NullStyle == NullKeyword {
+		return marshalIndent(data, opts.Indent)
+	}
+	node, err := encodeOrdered(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	return marshalIndent(node, opts.Indent)
+}
+
+// encodeOrdered encodes data to a node tree with opts.KeyPriority and
+// opts.NullStyle applied
+func encodeOrdered(data interface{}, opts Options) (*yaml.Node, error) {
+	if wrapped, ok := withBinary(data); ok {
+		data = wrapped
+	}
+	var node yaml.Node
+	if err := node.Encode(data); err != nil {
+		return nil, err
+	}
+	if len(opts.KeyPriority) > 0 {
+		rank := make(map[string]int, len(opts.KeyPriority))
+		for _, key := range opts.KeyPriority {
+			if _, seen := rank[key]; !seen {
+				rank[key] = len(rank)
+			}
+		}
+		orderMapping(&node, rank, opts.KeyPriorityNested)
+	}
+	if opts.NullStyle != NullKeyword {
+		setNullStyle(&node, opts.NullStyle)
+	}
+	return &node, nil
+}
+
+// orderMapping moves ranked keys of a mapping node to the front, keeping
+// the encoder's sorted order for the rest
+func orderMapping(n *yaml.Node, rank map[string]int, nested bool) {
+	if n.Kind == yaml.MappingNode {
+		var first, rest []*yaml.Node
+		ranked := make([][]*yaml.Node, len(rank))
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if r, ok := rank[key.Value]; ok && key.Kind == yaml.ScalarNode {
+				ranked[r] = []*yaml.Node{key, value}
+			} else {
+				rest = append(rest, key, value)
+			}
+			if nested {
+				orderMapping(value, rank, nested)
+			}
+		}
+		for _, pair := range ranked {
+			first = append(first, pair...)
+		}
+		n.Content = append(first, rest...)
+		return
+	}
+	if n.Kind == yaml.SequenceNode && nested {
+		for _, child := range n.Content {
+			orderMapping(child, rank, nested)
+		}
+	}
+}
+
+// encoderOrder returns mapping keys in the order yaml.v3 writes them, which
+// compares numbers and the digit runs of strings by value
+func encoderOrder(keys []interface{}) []interface{} {
+	index := make(map[interface{}]int, len(keys))
+	for i, k := range keys {
+		index[k] = i
+	}
+	var node yaml.Node
+	if err := node.Encode(index); err != nil {
+		return keys
+	}
+	ordered := make([]interface{}, 0, len(keys))
+	for i := 1; i < len(node.Content); i += 2 {
+		n, err := strconv.Atoi(node.Content[i].Value)
+		if err != nil {
+			return keys
+		}
+		ordered = append(ordered, keys[n])
+	}
+	return ordered
+}
@@ -0,0 +1,52 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSortKeys(t *testing.T) {
+	yv, _ := Loads(`
+spec:
+  replicas: 2
+  selector: {}
+metadata:
+  name: web
+  labels: {app: web}
+kind: Deployment
+apiVersion: apps/v1
+status: {}
+`)
+	priority := []string{"apiVersion", "kind", "metadata", "name", "spec"}
+
+	out, err := yv.SortKeys(priority).WithIndent(2).Dumps()
+	if err != nil {
+		t.Fatalf("Dumps failed: %v", err)
+	}
+	expected := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: web
+  name: web
+spec:
+  replicas: 2
+  selector: {}
+status: {}
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	out, _ = yv.SortKeysNested(priority).WithIndent(2).Dumps()
+	if expected := "metadata:\n  name: web\n  labels:\n"; !strings.Contains(out, expected) {
+		t.Errorf("Expected nested keys ordered, got:\n%s", out)
+	}
+
+	if out, _ := yv.SortKeys([]string{"status"}).Dumps(); !strings.HasPrefix(out, "status: {}\n") {
+		t.Errorf("Expected status first, got:\n%s", out)
+	}
+	if out, _ := yv.Dumps(); strings.HasPrefix(out, "status") {
+		t.Errorf("Expected SortKeys to leave the original unchanged, got:\n%s", out)
+	}
+}
@@ -0,0 +1,152 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegisterCRD registers the OpenAPI v3 schema of every version of a
+// Kubernetes CustomResourceDefinition under "group/version/Kind", so
+// Validate picks it for custom resources with that apiVersion and kind.
+// Usage: registry.RegisterCRD(crd)
+func (r *SchemaRegistry) RegisterCRD(crd *YAMLValue) error {
+	if crd.Get("kind").AsString() != "CustomResourceDefinition" {
+		return fmt.Errorf("%w: expected a CustomResourceDefinition, got %q", ErrTypeMismatch, crd.Get("kind").AsString())
+	}
+	group := crd.Path("spec.group").AsString()
+	kind := crd.Path("spec.names.kind").AsString()
+	if kind == "" {
+		return opError("register_crd", "spec.names.kind", ErrKeyNotFound, "")
+	}
+
+	registered := 0
+	for _, version := range crd.Path("spec.versions").AsArray() {
+		schema := version.Path("schema.openAPIV3Schema")
+		if !schema.IsObject() {
+			continue
+		}
+		r.Register(groupVersion(group, version.Get("name").AsString())+"/"+kind, ConvertOpenAPISchema(schema, schema))
+		registered++
+	}
+	if registered == 0 {
+		return opError("register_crd", "spec.versions", ErrKeyNotFound, "no version has an openAPIV3Schema")
+	}
+	return nil
+}
+
+// RegisterOpenAPI registers every schema of an OpenAPI v3 document (as
+// served by the Kubernetes API server at /openapi/v3) or a Swagger 2.0
+// document that declares x-kubernetes-group-version-kind, under
+// "group/version/Kind". It returns the number of kinds registered.
+func (r *SchemaRegistry) RegisterOpenAPI(spec *YAMLValue) int {
+	definitions := spec.Path("components.schemas")
+	if !definitions.Exists() {
+		definitions = spec.Get("definitions")
+	}
+
+	registered := 0
+	definitions.eachChild(func(name string, schema *YAMLValue) {
+		var converted *YAMLValue
+		for _, gvk := range schema.Get("x-kubernetes-group-version-kind").AsArray() {
+			if converted == nil {
+				converted = ConvertOpenAPISchema(schema, spec)
+			}
+			key := groupVersion(gvk.Get("group").AsString(), gvk.Get("version").AsString()) + "/" + gvk.Get("kind").AsString()
+			r.Register(key, converted)
+			registered++
+		}
+	})
+	return registered
+}
+
+// ConvertOpenAPISchema converts a Kubernetes OpenAPI v3 schema into the
+// JSON Schema understood by Validate. Local "$ref"s are inlined from root,
+// with recursive references accepting any value. The Kubernetes extensions
+// are mapped as follows:
+//
+//   - nullable allows null
+//   - x-kubernetes-int-or-string allows an integer or a string
+//   - x-kubernetes-preserve-unknown-fields allows unknown fields, which are
+//     rejected otherwise on objects that declare properties
+//   - x-kubernetes-embedded-resource requires apiVersion and kind
+//   - x-kubernetes-list-type "set" requires unique items and "map" unique
+//     x-kubernetes-list-map-keys
+//
+// x-kubernetes-validations (CEL rules) are not evaluated.
+func ConvertOpenAPISchema(schema *YAMLValue, root *YAMLValue) *YAMLValue {
+	return &YAMLValue{data: convertOpenAPI(schema, root, map[string]bool{})}
+}
+
+// convertOpenAPI converts one schema node; refs holds the refs being inlined
+func convertOpenAPI(schema *YAMLValue, root *YAMLValue, refs map[string]bool) interface{} {
+	if !schema.IsObject() {
+		return schema.data
+	}
+	if ref := schema.Get("$ref"); ref.IsString() && strings.HasPrefix(ref.AsString(), "#") {
+		target := ref.AsString()
+		resolved, ok := resolveFragment(root, target)
+		if !ok || refs[target] {
+			return map[string]interface{}{}
+		}
+		refs[target] = true
+		defer delete(refs, target)
+		return convertOpenAPI(resolved, root, refs)
+	}
+
+	converted := make(map[string]interface{})
+	for _, item := range schema.ItemsOrdered() {
+		key := fmt.Sprintf("%v", item.Key)
+		value := item.Value
+		switch key {
+		case "properties", "patternProperties", "definitions":
+			props := make(map[string]interface{})
+			value.eachChild(func(name string, child *YAMLValue) {
+				props[name] = convertOpenAPI(child, root, refs)
+			})
+			converted[key] = props
+		case "items", "additionalProperties", "not":
+			converted[key] = convertOpenAPI(value, root, refs)
+		case "allOf", "anyOf", "oneOf":
+			var alternatives []interface{}
+			for _, child := range value.AsArray() {
+				alternatives = append(alternatives, convertOpenAPI(child, root, refs))
+			}
+			converted[key] = alternatives
+		default:
+			converted[key] = deepCopy(value.data)
+		}
+	}
+
+	if schema.Get("x-kubernetes-int-or-string").AsBool() {
+		delete(converted, "type")
+		converted["anyOf"] = []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "string"},
+		}
+	}
+	if schema.Get("nullable").AsBool() {
+		if typ, ok := converted["type"].(string); ok {
+			converted["type"] = []interface{}{typ, "null"}
+		}
+	}
+	if schema.Has("properties") && !schema.Has("additionalProperties") &&
+		!schema.Get("x-kubernetes-preserve-unknown-fields").AsBool() {
+		converted["additionalProperties"] = false
+	}
+	if schema.Get("x-kubernetes-embedded-resource").AsBool() {
+		required, _ := converted["required"].([]interface{})
+		converted["required"] = append(append([]interface{}{}, required...), "apiVersion", "kind")
+	}
+	if schema.Get("x-kubernetes-list-type").AsString() == "set" {
+		converted["uniqueItems"] = true
+	}
+	return converted
+}
+
+// groupVersion formats an apiVersion, which has no group for the core API
+func groupVersion(group, version string) string {
+	if group == "" {
+		return version
+	}
+	return group + "/" + version
+}
@@ -0,0 +1,127 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterCRD(t *testing.T) {
+	crd, _ := Loads(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+spec:
+  group: example.com
+  names: {kind: Widget}
+  versions:
+    - name: v1
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            apiVersion: {type: string}
+            kind: {type: string}
+            metadata: {type: object}
+            spec:
+              type: object
+              required: [size]
+              properties:
+                size: {x-kubernetes-int-or-string: true}
+                owner: {type: string, nullable: true}
+                labels:
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+                  properties:
+                    team: {type: string}
+                ports:
+                  type: array
+                  x-kubernetes-list-type: map
+                  x-kubernetes-list-map-keys: [port, protocol]
+                  items:
+                    type: object
+                    properties:
+                      port: {type: integer}
+                      protocol: {type: string}
+`)
+
+	registry := NewSchemaRegistry()
+	if err := registry.RegisterCRD(crd); err != nil {
+		t.Fatalf("RegisterCRD failed: %v", err)
+	}
+
+	valid, _ := Loads(`
+apiVersion: example.com/v1
+kind: Widget
+metadata: {name: w}
+spec:
+  size: 50%
+  owner: null
+  labels: {team: a, extra: b}
+  ports:
+    - {port: 80, protocol: TCP}
+    - {port: 80, protocol: UDP}
+`)
+	if err := registry.Validate(valid); err != nil {
+		t.Errorf("Expected valid widget, got %v", err)
+	}
+
+	invalid, _ := Loads(`
+apiVersion: example.com/v1
+kind: Widget
+spec:
+  size: true
+  colour: red
+  ports:
+    - {port: 80, protocol: TCP}
+    - {port: 80, protocol: TCP}
+`)
+	expected := []string{
+		"validate spec.colour: schema violation: additional property is not allowed",
+		"validate spec.ports.1: schema violation: duplicate port, protocol of item 0",
+		"validate spec.size: schema violation: does not match any schema in anyOf",
+	}
+	if err := registry.Validate(invalid); err == nil || err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%v", strings.Join(expected, "\n"), err)
+	}
+
+	if err := registry.RegisterCRD(valid); err == nil {
+		t.Errorf("Expected an error registering a non-CRD")
+	}
+}
+
+func TestRegisterOpenAPI(t *testing.T) {
+	spec, _ := Loads(`
+openapi: 3.0.0
+components:
+  schemas:
+    io.k8s.api.core.v1.ConfigMap:
+      type: object
+      x-kubernetes-group-version-kind:
+        - {group: "", version: v1, kind: ConfigMap}
+      properties:
+        apiVersion: {type: string}
+        kind: {type: string}
+        metadata: {$ref: "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}
+        data:
+          type: object
+          additionalProperties: {type: string}
+    io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta:
+      type: object
+      properties:
+        name: {type: string}
+        ownerReferences:
+          type: array
+          items: {$ref: "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}
+`)
+
+	registry := NewSchemaRegistry()
+	if n := registry.RegisterOpenAPI(spec); n != 1 {
+		t.Fatalf("Expected 1 kind registered, got %d", n)
+	}
+
+	cm, _ := Loads("apiVersion: v1\nkind: ConfigMap\nmetadata: {name: x, nmae: y}\ndata: {a: 1}\n")
+	expected := "validate data.a: schema violation: expected string, got integer\n" +
+		"validate metadata.nmae: schema violation: additional property is not allowed"
+	if err := registry.Validate(cm); err == nil || err.Error() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%v", expected, err)
+	}
+}
@@ -0,0 +1,179 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KVPair is one entry of a flat key/value store such as Consul or etcd
+type KVPair struct {
+	Key   string
+	Value string
+}
+
+// ToKVPairs flattens the document into key/value pairs whose keys are the
+// "/"-separated paths of its scalars under prefix, e.g. "app/server/port".
+// Array elements use their index as the segment. Values are YAML scalars,
+// so strings that would read as another type are quoted; empty objects and
+// arrays become "{}" and "[]". "/" and "%" in keys are escaped as "%2F" and
+// "%25". Pairs are sorted by path.
+// Usage: pairs, err := cfg.ToKVPairs("apps/web"); for _, p := range pairs { kv.Put(p.Key, p.Value) }
+func (yv *YAMLValue) ToKVPairs(prefix string) ([]KVPair, error) {
+	var pairs []KVPair
+	var walk func(data interface{}, key string) error
+	walk = func(data interface{}, key string) error {
+		switch v := data.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 {
+				break
+			}
+			for _, k := range sortedKeys(v) {
+				if err := walk(v[k], kvJoin(key, k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		case map[interface{}]interface{}:
+			if len(v) == 0 {
+				break
+			}
+			keys := make([]string, 0, len(v))
+			values := make(map[string]interface{}, len(v))
+			for k, val := range v {
+				s := fmt.Sprintf("%v", k)
+				if _, dup := values[s]; dup {
+					return opError("to_kv", kvJoin(key, s), ErrKeyExists, "keys of different types format the same")
+				}
+				keys = append(keys, s)
+				values[s] = val
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if err := walk(values[k], kvJoin(key, k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		case []interface{}:
+			if len(v) == 0 {
+				break
+			}
+			for i, item := range v {
+				if err := walk(item, kvJoin(key, strconv.Itoa(i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			return opError("to_kv", key, ErrTypeMismatch, err.Error())
+		}
+		value := string(out)
+		if strings.Count(value, "\n") == 1 {
+			// block scalars keep the final line break, which is part of
+			// their content
+			value = strings.TrimSuffix(value, "\n")
+		}
+		pairs = append(pairs, KVPair{Key: key, Value: value})
+		return nil
+	}
+	if err := walk(yv.data, strings.Trim(prefix, "/")); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// FromKVPairs builds a document from key/value pairs in the format written
+// by ToKVPairs; strip any prefix from the keys first. A level whose keys are
+// exactly 0..n-1 becomes an array. It returns an error wrapping
+// ErrKeyExists if a key is both a value and the parent of others.
+// Usage: cfg, err := easyyaml.FromKVPairs(pairs)
+func FromKVPairs(pairs []KVPair) (*YAMLValue, error) {
+	sorted := append([]KVPair(nil), pairs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	var root interface{}
+	for _, pair := range sorted {
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(pair.Value), &value); err != nil {
+			return nil, opError("from_kv", pair.Key, ErrTypeMismatch, err.Error())
+		}
+		key := strings.Trim(pair.Key, "/")
+		if key == "" {
+			if root != nil {
+				return nil, opError("from_kv", pair.Key, ErrKeyExists, "the root is both a value and a parent")
+			}
+			root = value
+			continue
+		}
+		if root == nil {
+			root = make(map[string]interface{})
+		}
+		node, ok := root.(map[string]interface{})
+		if !ok {
+			return nil, opError("from_kv", pair.Key, ErrKeyExists, "the root is both a value and a parent")
+		}
+		segments := strings.Split(key, "/")
+		for i, segment := range segments {
+			segments[i] = kvUnescape(segment)
+		}
+		for i, segment := range segments[:len(segments)-1] {
+			next, exists := node[segment]
+			if !exists {
+				next = make(map[string]interface{})
+				node[segment] = next
+			}
+			child, ok := next.(map[string]interface{})
+			if !ok {
+				return nil, opError("from_kv", strings.Join(segments[:i+1], "/"), ErrKeyExists, "key is both a value and a parent")
+			}
+			node = child
+		}
+		last := segments[len(segments)-1]
+		if _, exists := node[last]; exists {
+			return nil, opError("from_kv", pair.Key, ErrKeyExists, "key is both a value and a parent")
+		}
+		node[last] = value
+	}
+	return &YAMLValue{data: kvArrays(root), meta: &docMeta{}}, nil
+}
+
+// kvJoin appends an escaped segment to a "/"-separated key
+func kvJoin(key, segment string) string {
+	segment = strings.NewReplacer("%", "%25", "/", "%2F").Replace(segment)
+	if key == "" {
+		return segment
+	}
+	return key + "/" + segment
+}
+
+// kvUnescape reverses the escaping of kvJoin
+func kvUnescape(segment string) string {
+	return strings.NewReplacer("%2F", "/", "%2f", "/", "%25", "%").Replace(segment)
+}
+
+// kvArrays turns the objects built by FromKVPairs whose keys are 0..n-1
+// into arrays
+func kvArrays(data interface{}) interface{} {
+	obj, ok := data.(map[string]interface{})
+	if !ok || len(obj) == 0 {
+		return data
+	}
+	for k, v := range obj {
+		obj[k] = kvArrays(v)
+	}
+	items := make([]interface{}, len(obj))
+	for i := range items {
+		item, ok := obj[strconv.Itoa(i)]
+		if !ok {
+			return obj
+		}
+		items[i] = item
+	}
+	return items
+}
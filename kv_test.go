@@ -0,0 +1,73 @@
+package easyyaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestToKVPairs(t *testing.T) {
+	yv, _ := Loads(`server:
+  host: example.com
+  port: 8080
+  tls: "true"
+hosts: [a, b]
+paths:
+  /api: backend
+empty: {}
+note: |
+  two
+  lines
+`)
+	pairs, err := yv.ToKVPairs("apps/web/")
+	if err != nil {
+		t.Fatalf("ToKVPairs failed: %v", err)
+	}
+	expected := []KVPair{
+		{"apps/web/empty", "{}"},
+		{"apps/web/hosts/0", "a"},
+		{"apps/web/hosts/1", "b"},
+		{"apps/web/note", "|\n    two\n    lines\n"},
+		{"apps/web/paths/%2Fapi", "backend"},
+		{"apps/web/server/host", "example.com"},
+		{"apps/web/server/port", "8080"},
+		{"apps/web/server/tls", `"true"`},
+	}
+	if !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("Expected %v, got %v", expected, pairs)
+	}
+
+	trimmed := make([]KVPair, len(pairs))
+	for i, p := range pairs {
+		trimmed[i] = KVPair{Key: p.Key[len("apps/web/"):], Value: p.Value}
+	}
+	back, err := FromKVPairs(trimmed)
+	if err != nil {
+		t.Fatalf("FromKVPairs failed: %v", err)
+	}
+	if !reflect.DeepEqual(back.Raw(), yv.Raw()) {
+		t.Errorf("Expected round trip, got %v", back.Raw())
+	}
+}
+
+func TestFromKVPairs(t *testing.T) {
+	yv, err := FromKVPairs([]KVPair{
+		{"items/1/name", "second"},
+		{"items/0/name", "first"},
+		{"sparse/0", "x"},
+		{"sparse/2", "y"},
+	})
+	if err != nil {
+		t.Fatalf("FromKVPairs failed: %v", err)
+	}
+	if !yv.Get("items").IsArray() || yv.Path("items.1.name").AsString() != "second" {
+		t.Errorf("Expected items as an array, got %v", yv.Raw())
+	}
+	if !yv.Get("sparse").IsObject() {
+		t.Errorf("Expected non-contiguous indexes to stay an object, got %v", yv.Get("sparse").Raw())
+	}
+
+	if _, err := FromKVPairs([]KVPair{{"a", "1"}, {"a/b", "2"}}); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Expected ErrKeyExists, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package easyyaml
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+)
+
+// docLayout records the original text of a document in a stream so that
+// unchanged documents, and the comments and spacing around changed ones,
+// can be written back byte for byte
+type docLayout struct {
+	separator string
+	leading   string
+	body      string
+	trailing  string
+	snapshot  interface{}
+	meta      *docMeta
+}
+
+// streamSegment is a stretch of a stream between document markers
+type streamSegment struct {
+	separator string
+	lines     []string
+}
+
+// isDocumentMarker checks if a line is a "---" or "..." marker at column 0
+func isDocumentMarker(line string) bool {
+	text := strings.TrimRight(line, "\r\n")
+	if text == "..." {
+		return true
+	}
+	return text == "---" || strings.HasPrefix(text, "--- ") || strings.HasPrefix(text, "---\t")
+}
+
+// isFillerLine checks if a line is blank, a comment or a directive
+func isFillerLine(line string) bool {
+	text := strings.TrimSpace(line)
+	return text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "%")
+}
+
+// hasContent checks if a segment contains anything besides filler lines
+func (s *streamSegment) hasContent() bool {
+	for _, line := range s.lines {
+		if !isFillerLine(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// startsDocument checks if the segment's separator contains a "---" marker
+func (s *streamSegment) startsDocument() bool {
+	for _, line := range strings.SplitAfter(s.separator, "\n") {
+		if strings.HasPrefix(line, "---") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitStream records the layout of each document in a stream. It returns
+// the text preceding the first document and one layout per document, or
+// ok=false when the layout cannot be matched to the decoded documents.
+func splitStream(input []byte, docs []*YAMLValue) (string, []*docLayout, bool) {
+	var segments []*streamSegment
+	current := &streamSegment{}
+	for _, line := range strings.SplitAfter(string(input), "\n") {
+		if line == "" {
+			continue
+		}
+		if isDocumentMarker(line) {
+			startsAgain := strings.HasPrefix(line, "---") && current.startsDocument()
+			if len(current.lines) > 0 || startsAgain || current.separator == "" && len(segments) == 0 {
+				segments = append(segments, current)
+				current = &streamSegment{}
+			}
+			current.separator += line
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+	segments = append(segments, current)
+
+	prefix := ""
+	var layouts []*docLayout
+	for _, seg := range segments {
+		if !seg.hasContent() && !seg.startsDocument() {
+			text := seg.separator + strings.Join(seg.lines, "")
+			if len(layouts) == 0 {
+				prefix += text
+			} else {
+				layouts[len(layouts)-1].trailing += text
+			}
+			continue
+		}
+		layouts = append(layouts, newDocLayout(seg))
+	}
+
+	if len(layouts) != len(docs) {
+		return "", nil, false
+	}
+	for i, layout := range layouts {
+		layout.snapshot = deepCopy(docs[i].data)
+		layout.meta = docs[i].meta.clone()
+	}
+	return prefix, layouts, true
+}
+
+// newDocLayout splits a segment into leading filler, body and trailing filler
+func newDocLayout(seg *streamSegment) *docLayout {
+	start := 0
+	for start < len(seg.lines) && isFillerLine(seg.lines[start]) {
+		start++
+	}
+	end := len(seg.lines)
+	for end > start && isFillerLine(seg.lines[end-1]) && !startsWithSpace(seg.lines[end-1]) {
+		end--
+	}
+	return &docLayout{
+		separator: seg.separator,
+		leading:   strings.Join(seg.lines[:start], ""),
+		body:      strings.Join(seg.lines[start:end], ""),
+		trailing:  strings.Join(seg.lines[end:], ""),
+	}
+}
+
+// startsWithSpace checks if a line is indented
+func startsWithSpace(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// write emits the document using its original text when unchanged, and
+// otherwise re-marshals it between the original surrounding comments
+func (l *docLayout) write(buf *bytes.Buffer, doc *YAMLValue, first bool) error {
+	separator := l.separator
+	if !first && !strings.Contains(separator, "---") {
+		separator += "---\n"
+	}
+	buf.WriteString(separator)
+	buf.WriteString(l.leading)
+	if reflect.DeepEqual(l.snapshot, doc.data) && l.meta.sameDetails(doc.meta) {
+		buf.WriteString(l.body)
+	} else {
+		out, err := doc.dumpBody(doc.options())
+		if err != nil {
+			return err
+		}
+		buf.Write(out)
+	}
+	buf.WriteString(l.trailing)
+	return nil
+}
+
+// deepCopy copies maps and slices recursively so later mutations of the
+// original do not affect the copy
+func deepCopy(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[k] = deepCopy(val)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, val := range v {
+			m[k] = deepCopy(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = deepCopy(val)
+		}
+		return s
+	}
+	return data
+}
@@ -0,0 +1,69 @@
+package easyyaml
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// LoadFileLocked is like LoadFile but holds a shared advisory lock on the
+// file while reading, so it never sees a write from DumpFileLocked half done
+func LoadFileLocked(filename string, opts ...LoadOption) (*YAMLValue, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		logError("easyyaml: read failed", err, "file", filename)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f, false); err != nil {
+		return nil, fmt.Errorf("failed to lock file: %w", err)
+	}
+	yamlBytes, err := io.ReadAll(f)
+	unlockFile(f)
+	if err == nil {
+		yamlBytes, err = decompress(yamlBytes)
+	}
+	if err != nil {
+		logError("easyyaml: read failed", err, "file", filename)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return LoadContext(context.Background(), yamlBytes, opts...)
+}
+
+// DumpFileLocked is like DumpFile but holds an exclusive advisory lock on the
+// file while writing, so processes using the locked functions on the same
+// file do not interleave partial writes. Locks are advisory: writers that do
+// not lock are not excluded.
+func (yv *YAMLValue) DumpFileLocked(filename string) error {
+	yamlBytes, err := yv.Dump()
+	if err == nil {
+		yamlBytes, err = compressFor(filename, yamlBytes)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	// Truncate only once the lock is held, not on open
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f, true); err != nil {
+		return fmt.Errorf("failed to lock file: %w", err)
+	}
+	defer unlockFile(f)
+
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if _, err := f.Write(yamlBytes); err != nil {
+		logError("easyyaml: write failed", err, "file", filename)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
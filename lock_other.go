@@ -0,0 +1,40 @@
+//go:build !unix
+
+package easyyaml
+
+import (
+	"os"
+	"sync"
+)
+
+// fileLocks serializes access within this process on platforms without
+// flock; other processes are not excluded
+var fileLocks = struct {
+	sync.Mutex
+	held map[string]*sync.Mutex
+}{held: make(map[string]*sync.Mutex)}
+
+// lockFile locks f against other goroutines of this process. Shared locks
+// are taken as exclusive.
+func lockFile(f *os.File, exclusive bool) error {
+	fileMutex(f).Lock()
+	return nil
+}
+
+// unlockFile releases a lock taken with lockFile
+func unlockFile(f *os.File) error {
+	fileMutex(f).Unlock()
+	return nil
+}
+
+// fileMutex returns the mutex guarding the file's path
+func fileMutex(f *os.File) *sync.Mutex {
+	fileLocks.Lock()
+	defer fileLocks.Unlock()
+	mu, ok := fileLocks.held[f.Name()]
+	if !ok {
+		mu = &sync.Mutex{}
+		fileLocks.held[f.Name()] = mu
+	}
+	return mu
+}
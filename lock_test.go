@@ -0,0 +1,38 @@
+package easyyaml
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileLocked(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	if err := Object(KV("counter", 0)).DumpFileLocked(filename); err != nil {
+		t.Fatalf("DumpFileLocked failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			doc := Object(KV("counter", i), KV("padding", make([]interface{}, i*50)))
+			if err := doc.DumpFileLocked(filename); err != nil {
+				t.Errorf("DumpFileLocked failed: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			doc, err := LoadFileLocked(filename)
+			if err != nil {
+				t.Errorf("LoadFileLocked saw a partial write: %v", err)
+				return
+			}
+			if !doc.Has("counter") {
+				t.Errorf("LoadFileLocked saw an incomplete document: %v", doc.Raw())
+			}
+		}()
+	}
+	wg.Wait()
+}
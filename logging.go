@@ -0,0 +1,83 @@
+package easyyaml
+
+import (
+	"sync"
+)
+
+// Logger is the logging interface used by easyyaml. It is satisfied by
+// *slog.Logger, so slog.Default() can be passed directly to SetLogger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+var logState = struct {
+	sync.RWMutex
+	logger    Logger
+	mutations bool
+}{}
+
+// SetLogger installs a logger for parse and dump errors, logged at error
+// level, and validation failures, logged at warn level. Pass nil to disable
+// logging, which is the default.
+func SetLogger(logger Logger) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.logger = logger
+}
+
+// LogMutations enables debug-level logging of every Set, SetPath, Delete,
+// Append, Extend, Update and Merge call on any YAMLValue
+func LogMutations(enabled bool) {
+	logState.Lock()
+	defer logState.Unlock()
+	logState.mutations = enabled
+}
+
+// currentLogger returns the installed logger, or nil
+func currentLogger() Logger {
+	logState.RLock()
+	defer logState.RUnlock()
+	return logState.logger
+}
+
+// logError logs an operation failure at error level
+func logError(msg string, err error, args ...any) {
+	if err == nil {
+		return
+	}
+	if logger := currentLogger(); logger != nil {
+		logger.Error(msg, append(args, "error", err)...)
+	}
+}
+
+// logWarn logs input rejected by an operation, such as a document failing
+// validation, at warn level
+func logWarn(msg string, err error, args ...any) {
+	if err == nil {
+		return
+	}
+	if logger := currentLogger(); logger != nil {
+		logger.Warn(msg, append(args, "error", err)...)
+	}
+}
+
+// parseLogArgs returns the log attributes of a failed parse: the input size
+// and, when known, the file it was read from
+func parseLogArgs(filename string, size int) []any {
+	if filename != "" {
+		return []any{"file", filename, "size", size}
+	}
+	return []any{"size", size}
+}
+
+// logMutation logs a mutation at debug level when mutation logging is enabled
+func logMutation(op string, args ...any) {
+	logState.RLock()
+	logger, enabled := logState.logger, logState.mutations
+	logState.RUnlock()
+	if logger != nil && enabled {
+		logger.Debug("easyyaml: "+op, args...)
+	}
+}
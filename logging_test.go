@@ -0,0 +1,72 @@
+package easyyaml
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	if _, err := Loads("a: [1"); err == nil {
+		t.Fatal("Expected parse error")
+	}
+	if !strings.Contains(buf.String(), "level=ERROR") || !strings.Contains(buf.String(), "parse failed") {
+		t.Errorf("Expected parse error to be logged, got %q", buf.String())
+	}
+
+	buf.Reset()
+	yv := NewObject()
+	yv.SetPath("server.port", 8080)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no mutation logs by default, got %q", buf.String())
+	}
+
+	LogMutations(true)
+	defer LogMutations(false)
+
+	yv.SetPath("server.host", "localhost")
+	yv.Delete("server")
+	out := buf.String()
+	if !strings.Contains(out, "level=DEBUG") || !strings.Contains(out, "path=server.host") || !strings.Contains(out, "key=server") {
+		t.Errorf("Expected mutation logs, got %q", out)
+	}
+	if strings.Count(out, "\n") != 2 {
+		t.Errorf("Expected one log line per mutation, got %q", out)
+	}
+}
+
+func TestLoggerFile(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer SetLogger(nil)
+
+	filename := filepath.Join(t.TempDir(), "bad.yaml")
+	os.WriteFile(filename, []byte("a: [1\n"), 0644)
+	if _, err := LoadFile(filename); err == nil {
+		t.Fatal("Expected parse error")
+	}
+	if out := buf.String(); strings.Count(out, "parse failed") != 1 || !strings.Contains(out, "file="+filename) {
+		t.Errorf("Expected one parse failure logged with the file, got %q", out)
+	}
+}
+
+func TestLoggerValidation(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer SetLogger(nil)
+
+	schema, _ := Loads("type: object\nrequired: [name]\n")
+	doc, _ := Loads("port: 80\n")
+	doc.Validate(schema)
+	NewSchemaRegistry().Validate(doc)
+	if out := buf.String(); strings.Count(out, "level=WARN") != 2 || !strings.Contains(out, "violations=1") {
+		t.Errorf("Expected validation failures to be logged, got %q", out)
+	}
+}
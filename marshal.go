@@ -0,0 +1,157 @@
+package easyyaml
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler so a YAMLValue can be embedded in
+// structs encoded by yaml.v3. It uses a value receiver so that non-pointer
+// fields are encoded too.
+func (yv YAMLValue) MarshalYAML() (interface{}, error) {
+	return yv.data, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler so a YAMLValue field can capture
+// an arbitrary subtree of a document decoded by yaml.v3
+func (yv *YAMLValue) UnmarshalYAML(node *yaml.Node) error {
+	if err := yv.checkFrozen("unmarshal", nil); err != nil {
+		return err
+	}
+	var data interface{}
+	if err := node.Decode(&data); err != nil {
+		return err
+	}
+	yv.invalidate()
+	yv.data = data
+	yv.missing = false
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Maps with non-string keys are
+// converted to string-keyed objects as JSON requires.
+func (yv YAMLValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonCompatible(yv.data))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Whole numbers decode as int,
+// matching how integers are represented after parsing YAML.
+func (yv *YAMLValue) UnmarshalJSON(data []byte) error {
+	if err := yv.checkFrozen("unmarshal", nil); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	yv.invalidate()
+	yv.data = fromJSONNumbers(raw)
+	yv.missing = false
+	return nil
+}
+
+// jsonCompatible converts interface-keyed maps to string-keyed maps recursively
+func jsonCompatible(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprintf("%v", k)] = jsonCompatible(val)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[k] = jsonCompatible(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = jsonCompatible(val)
+		}
+		return s
+	case *YAMLValue:
+		return jsonCompatible(v.data)
+	case YAMLValue:
+		return jsonCompatible(v.data)
+	}
+	return data
+}
+
+// fromJSONNumbers replaces json.Number values with int or float64
+func fromJSONNumbers(data interface{}) interface{} {
+	switch v := data.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil && int64(int(i)) == i {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = fromJSONNumbers(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = fromJSONNumbers(val)
+		}
+	}
+	return data
+}
+
+// MarshalText implements encoding.TextMarshaler by dumping the value as YAML
+func (yv YAMLValue) MarshalText() ([]byte, error) {
+	return yv.Dump()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing YAML text.
+// It returns an error wrapping ErrFrozen for a frozen value.
+func (yv *YAMLValue) UnmarshalText(text []byte) error {
+	if err := yv.checkFrozen("unmarshal", nil); err != nil {
+		return err
+	}
+	parsed, err := Load(text)
+	if err != nil {
+		return err
+	}
+	*yv = *parsed
+	return nil
+}
+
+// Scan implements sql.Scanner so YAML stored in a text or blob column can be
+// read directly into a YAMLValue. A NULL column yields a null value.
+func (yv *YAMLValue) Scan(src interface{}) error {
+	if err := yv.checkFrozen("scan", nil); err != nil {
+		return err
+	}
+	switch v := src.(type) {
+	case nil:
+		*yv = YAMLValue{}
+		return nil
+	case []byte:
+		return yv.UnmarshalText(v)
+	case string:
+		return yv.UnmarshalText([]byte(v))
+	}
+	return fmt.Errorf("cannot scan %T into YAMLValue", src)
+}
+
+// Value implements driver.Valuer, storing the value as YAML text. A null
+// value is stored as NULL.
+func (yv YAMLValue) Value() (driver.Value, error) {
+	if yv.data == nil {
+		return nil, nil
+	}
+	out, err := yv.Dumps()
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package easyyaml
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type pluginConfig struct {
+	Name    string    `yaml:"name"`
+	Options YAMLValue `yaml:"options"`
+}
+
+func TestYAMLMarshalerInterfaces(t *testing.T) {
+	var cfg pluginConfig
+	input := "name: cache\noptions:\n    size: 128\n    backends: [redis, memory]\n"
+	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatalf("Failed to decode struct: %v", err)
+	}
+
+	if cfg.Options.Get("size").AsInt() != 128 {
+		t.Errorf("Expected size 128, got %d", cfg.Options.Get("size").AsInt())
+	}
+	if cfg.Options.Q("backends", 1).AsString() != "memory" {
+		t.Errorf("Expected second backend 'memory', got %s", cfg.Options.Q("backends", 1).AsString())
+	}
+
+	cfg.Options.Set("size", 256)
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Failed to encode struct: %v", err)
+	}
+	if !strings.Contains(string(out), "size: 256") {
+		t.Errorf("Expected encoded options, got:\n%s", out)
+	}
+
+	nested := NewObject()
+	nested.Set("inner", New(map[string]interface{}{"a": 1}))
+	out, err = nested.Dump()
+	if err != nil {
+		t.Fatalf("Failed to dump nested value: %v", err)
+	}
+	if string(out) != "inner:\n    a: 1\n" {
+		t.Errorf("Expected nested YAMLValue to marshal as its data, got:\n%s", out)
+	}
+}
+
+type apiResponse struct {
+	ID     int        `json:"id"`
+	Config *YAMLValue `json:"config"`
+}
+
+func TestJSONMarshalerInterfaces(t *testing.T) {
+	var resp apiResponse
+	if err := json.Unmarshal([]byte(`{"id": 7, "config": {"port": 8080, "ratio": 0.5, "tags": ["a"]}}`), &resp); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+
+	if !resp.Config.Get("port").IsNumber() || resp.Config.Get("port").AsInt() != 8080 {
+		t.Errorf("Expected port 8080, got %v", resp.Config.Get("port").Raw())
+	}
+	if _, ok := resp.Config.Get("port").Raw().(int); !ok {
+		t.Errorf("Expected whole number to decode as int, got %T", resp.Config.Get("port").Raw())
+	}
+	if resp.Config.Get("ratio").AsFloat() != 0.5 {
+		t.Errorf("Expected ratio 0.5, got %v", resp.Config.Get("ratio").Raw())
+	}
+
+	obj := NewObject()
+	obj.Set("name", "app")
+	obj.Set(1, "one")
+	out, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	if string(out) != `{"1":"one","name":"app"}` {
+		t.Errorf("Unexpected JSON output %s", out)
+	}
+
+	jv, err := obj.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to convert to JSON value: %v", err)
+	}
+	if jv.Get("name").AsString() != "app" {
+		t.Errorf("Expected ToJSON to handle interface-keyed maps, got %s", jv.Get("name").AsString())
+	}
+}
+
+func TestTextAndSQLInterfaces(t *testing.T) {
+	var yv YAMLValue
+	if err := yv.UnmarshalText([]byte("a: 1\n")); err != nil {
+		t.Fatalf("Failed to unmarshal text: %v", err)
+	}
+	text, err := yv.MarshalText()
+	if err != nil || string(text) != "a: 1\n" {
+		t.Errorf("MarshalText returned %q, %v", text, err)
+	}
+
+	var scanned YAMLValue
+	if err := scanned.Scan([]byte("name: app\n")); err != nil {
+		t.Fatalf("Failed to scan bytes: %v", err)
+	}
+	if scanned.Get("name").AsString() != "app" {
+		t.Errorf("Expected name 'app', got %s", scanned.Get("name").AsString())
+	}
+	if err := scanned.Scan("port: 80\n"); err != nil || scanned.Get("port").AsInt() != 80 {
+		t.Errorf("Failed to scan string: %v", err)
+	}
+	if err := scanned.Scan(nil); err != nil || !scanned.IsNull() {
+		t.Errorf("Expected NULL to scan as null, got %v", err)
+	}
+	if err := scanned.Scan(42); err == nil {
+		t.Error("Expected error scanning unsupported type")
+	}
+
+	value, err := yv.Value()
+	if err != nil || value != "a: 1\n" {
+		t.Errorf("Value returned %v, %v", value, err)
+	}
+	if value, _ := (YAMLValue{}).Value(); value != nil {
+		t.Errorf("Expected null value to be stored as NULL, got %v", value)
+	}
+}
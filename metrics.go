@@ -0,0 +1,215 @@
+package easyyaml
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Operation names reported in MetricsEvent
+const (
+	OpParse = "parse"
+	OpDump  = "dump"
+)
+
+// MetricsEvent describes a single parse or dump
+type MetricsEvent struct {
+	// Op is OpParse or OpDump
+	Op string
+	// Documents is the number of documents parsed or dumped
+	Documents int
+	// Size is the input or output size in bytes
+	Size int
+	// Duration is the time the operation took
+	Duration time.Duration
+	// Err is the error returned by the operation, if any
+	Err error
+}
+
+// MetricsHook receives an event for every parse and dump
+type MetricsHook interface {
+	Observe(event MetricsEvent)
+}
+
+// MetricsHookFunc adapts a function to the MetricsHook interface
+type MetricsHookFunc func(event MetricsEvent)
+
+// Observe calls f(event)
+func (f MetricsHookFunc) Observe(event MetricsEvent) {
+	f(event)
+}
+
+// Histogram counts observations into cumulative upper-bound buckets, as
+// Prometheus does
+type Histogram struct {
+	// Bounds are the inclusive upper bounds of each bucket
+	Bounds []float64
+	// Counts holds, per bound, the number of observations at or below it,
+	// plus a final bucket counting every observation
+	Counts []uint64
+	// Count is the total number of observations
+	Count uint64
+	// Sum is the total of all observed values
+	Sum float64
+}
+
+// observe records a value in the histogram
+func (h *Histogram) observe(v float64) {
+	h.Count++
+	h.Sum += v
+	for i, bound := range h.Bounds {
+		if v <= bound {
+			h.Counts[i]++
+		}
+	}
+	h.Counts[len(h.Bounds)]++
+}
+
+// clone returns an independent copy of the histogram
+func (h *Histogram) clone() Histogram {
+	c := *h
+	c.Bounds = append([]float64(nil), h.Bounds...)
+	c.Counts = append([]uint64(nil), h.Counts...)
+	return c
+}
+
+// newHistogram creates a histogram with the given bucket bounds
+func newHistogram(bounds ...float64) Histogram {
+	return Histogram{Bounds: bounds, Counts: make([]uint64, len(bounds)+1)}
+}
+
+// OpStats aggregates metrics for one kind of operation
+type OpStats struct {
+	// Calls is the number of operations performed
+	Calls uint64
+	// Documents is the number of documents processed
+	Documents uint64
+	// Errors is the number of operations that failed
+	Errors uint64
+	// Bytes is the total input or output size
+	Bytes uint64
+	// DurationMs is a histogram of operation durations in milliseconds
+	DurationMs Histogram
+	// SizeBytes is a histogram of document sizes in bytes
+	SizeBytes Histogram
+}
+
+// Metrics is a snapshot of the package-wide parse and dump metrics
+type Metrics struct {
+	Parse OpStats
+	Dump  OpStats
+}
+
+var metricsState = struct {
+	sync.Mutex
+	stats Metrics
+	hooks map[int]MetricsHook
+	next  int
+	// collect and hookCount are read without the lock so parses and dumps
+	// skip it when nothing is recorded
+	collect   atomic.Bool
+	hookCount atomic.Int64
+}{stats: newStats(), hooks: make(map[int]MetricsHook)}
+
+// CollectMetrics turns collection of the package-wide Stats on or off. It
+// is off by default; hooks added with AddMetricsHook are called either way.
+// Usage: easyyaml.CollectMetrics(true)
+func CollectMetrics(on bool) {
+	metricsState.collect.Store(on)
+}
+
+// newStats creates empty stats with the default histogram buckets
+func newStats() Metrics {
+	op := func() OpStats {
+		return OpStats{
+			DurationMs: newHistogram(0.1, 1, 10, 100, 1000),
+			SizeBytes:  newHistogram(1<<10, 10<<10, 100<<10, 1<<20, 10<<20),
+		}
+	}
+	return Metrics{Parse: op(), Dump: op()}
+}
+
+// Stats returns a snapshot of the metrics collected since CollectMetrics
+// was turned on or the last ResetStats
+func Stats() Metrics {
+	metricsState.Lock()
+	defer metricsState.Unlock()
+	s := metricsState.stats
+	s.Parse.DurationMs = metricsState.stats.Parse.DurationMs.clone()
+	s.Parse.SizeBytes = metricsState.stats.Parse.SizeBytes.clone()
+	s.Dump.DurationMs = metricsState.stats.Dump.DurationMs.clone()
+	s.Dump.SizeBytes = metricsState.stats.Dump.SizeBytes.clone()
+	return s
+}
+
+// ResetStats clears all collected metrics
+func ResetStats() {
+	metricsState.Lock()
+	defer metricsState.Unlock()
+	metricsState.stats = newStats()
+}
+
+// AddMetricsHook registers a hook called after every parse and dump. The
+// returned function unregisters it.
+func AddMetricsHook(hook MetricsHook) func() {
+	metricsState.Lock()
+	defer metricsState.Unlock()
+	id := metricsState.next
+	metricsState.next++
+	metricsState.hooks[id] = hook
+	metricsState.hookCount.Add(1)
+	return func() {
+		metricsState.Lock()
+		defer metricsState.Unlock()
+		if _, ok := metricsState.hooks[id]; ok {
+			delete(metricsState.hooks, id)
+			metricsState.hookCount.Add(-1)
+		}
+	}
+}
+
+// PublishExpvar exposes Stats under the given name via expvar and turns on
+// CollectMetrics
+func PublishExpvar(name string) {
+	CollectMetrics(true)
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return Stats()
+	}))
+}
+
+// recordMetrics updates the stats and notifies hooks about an operation
+func recordMetrics(op string, documents, size int, start time.Time, err error) {
+	collect := metricsState.collect.Load()
+	if !collect && metricsState.hookCount.Load() == 0 {
+		return
+	}
+	event := MetricsEvent{Op: op, Documents: documents, Size: size, Duration: time.Since(start), Err: err}
+
+	metricsState.Lock()
+	if collect {
+		s := &metricsState.stats.Parse
+		if op == OpDump {
+			s = &metricsState.stats.Dump
+		}
+		s.Calls++
+		if err != nil {
+			s.Errors++
+		} else {
+			s.Documents += uint64(documents)
+		}
+		s.Bytes += uint64(size)
+		s.DurationMs.observe(float64(event.Duration) / float64(time.Millisecond))
+		s.SizeBytes.observe(float64(size))
+	}
+
+	hooks := make([]MetricsHook, 0, len(metricsState.hooks))
+	for _, hook := range metricsState.hooks {
+		hooks = append(hooks, hook)
+	}
+	metricsState.Unlock()
+
+	for _, hook := range hooks {
+		hook.Observe(event)
+	}
+}
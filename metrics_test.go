@@ -0,0 +1,86 @@
+package easyyaml
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	ResetStats()
+	CollectMetrics(true)
+	defer CollectMetrics(false)
+
+	var events []MetricsEvent
+	remove := AddMetricsHook(MetricsHookFunc(func(event MetricsEvent) {
+		events = append(events, event)
+	}))
+	defer remove()
+
+	yv, err := Loads(testYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	if _, err := Loads("a: [1"); err == nil {
+		t.Fatal("Expected parse error")
+	}
+	if _, err := LoadAlls("a: 1\n---\nb: 2\n"); err != nil {
+		t.Fatalf("Failed to load documents: %v", err)
+	}
+	out, err := yv.Dump()
+	if err != nil {
+		t.Fatalf("Failed to dump YAML: %v", err)
+	}
+
+	stats := Stats()
+	if stats.Parse.Calls != 3 || stats.Parse.Errors != 1 || stats.Parse.Documents != 3 {
+		t.Errorf("Unexpected parse stats: %+v", stats.Parse)
+	}
+	if stats.Parse.Bytes != uint64(len(testYAML)+len("a: [1")+len("a: 1\n---\nb: 2\n")) {
+		t.Errorf("Unexpected parsed bytes: %d", stats.Parse.Bytes)
+	}
+	if stats.Parse.DurationMs.Count != 3 || stats.Parse.SizeBytes.Counts[0] != 3 || stats.Parse.SizeBytes.Counts[5] != 3 {
+		t.Errorf("Unexpected parse histograms: %+v %+v", stats.Parse.DurationMs, stats.Parse.SizeBytes)
+	}
+	if stats.Dump.Calls != 1 || stats.Dump.Bytes != uint64(len(out)) {
+		t.Errorf("Unexpected dump stats: %+v", stats.Dump)
+	}
+
+	if len(events) != 4 || events[1].Err == nil || events[2].Documents != 2 || events[3].Op != OpDump {
+		t.Errorf("Unexpected hook events: %+v", events)
+	}
+
+	remove()
+	Loads("a: 1")
+	if len(events) != 4 {
+		t.Error("Expected removed hook to not be called")
+	}
+
+	ResetStats()
+	if Stats().Parse.Calls != 0 {
+		t.Error("Expected stats to be reset")
+	}
+
+	CollectMetrics(false)
+	Loads("a: 1")
+	if Stats().Parse.Calls != 0 {
+		t.Error("Expected no stats while collection is off")
+	}
+}
+
+func TestHistogramCumulative(t *testing.T) {
+	h := newHistogram(1, 10)
+	for _, v := range []float64{0.5, 5, 50} {
+		h.observe(v)
+	}
+	if h.Counts[0] != 1 || h.Counts[1] != 2 || h.Counts[2] != 3 {
+		t.Errorf("Expected cumulative counts [1 2 3], got %v", h.Counts)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	defer CollectMetrics(false)
+	PublishExpvar("easyyaml_test")
+	if expvar.Get("easyyaml_test") == nil {
+		t.Error("Expected stats to be published")
+	}
+}
@@ -0,0 +1,240 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WarnFn receives a warning for each deprecated path that MigrateKeys moves
+type WarnFn func(Warning)
+
+// KeyMigration records one deprecated path that MigrateKeys found
+type KeyMigration struct {
+	From string
+	To   string
+	// Ignored is set when the new path already had a value, so the value
+	// at the deprecated path was dropped instead of moved
+	Ignored bool
+}
+
+// MigrateKeys moves values from deprecated dot-separated paths to their
+// replacements, given as a map of old path to new path, and returns the
+// migrations that fired in old path order. If the new path is already set,
+// the old value is dropped and the migration is marked Ignored. Objects left
+// empty by a move are removed, and either path may lie inside the other, so
+// db can move to db.primary. warn, if not nil, is called for every
+// migration; its Line is set when the value was loaded WithProvenance.
+// Usage: fired, err := cfg.MigrateKeys(map[string]string{"server.hostname": "server.host"}, func(w easyyaml.Warning) { log.Println(w) })
+func (yv *YAMLValue) MigrateKeys(paths map[string]string, warn WarnFn) ([]KeyMigration, error) {
+	logMutation("migrate_keys", "count", len(paths))
+	if err := yv.checkFrozen("migrate_keys", nil); err != nil {
+		return nil, err
+	}
+	from := make([]string, 0, len(paths))
+	for old := range paths {
+		from = append(from, old)
+	}
+	sort.Strings(from)
+
+	var fired []KeyMigration
+	for _, old := range from {
+		value, err := yv.PathE(old)
+		if err != nil {
+			continue
+		}
+		newPath := paths[old]
+		origin, hasOrigin := yv.Provenance(old)
+
+		// When one path holds the other, the old value has to go first:
+		// moving db to db.primary would otherwise delete the new value with
+		// the old, and db.primary to db would find db set by the old value
+		data := deepCopy(value.data)
+		segments := splitPaths([]string{old, newPath})
+		nested := pathWithin(segments[0], segments[1]) || pathWithin(segments[1], segments[0])
+		if nested {
+			yv.removePath(old)
+		}
+		migration := KeyMigration{From: old, To: newPath, Ignored: yv.Path(newPath).Exists()}
+		if !migration.Ignored {
+			if err := yv.SetPath(newPath, data); err != nil {
+				return fired, fmt.Errorf("failed to migrate %s to %s: %w", old, newPath, err)
+			}
+		}
+		if !nested {
+			yv.removePath(old)
+		}
+		yv.invalidate()
+		fired = append(fired, migration)
+
+		if warn != nil {
+			w := Warning{Kind: WarnDeprecatedKey, Path: old, Message: fmt.Sprintf("%s is deprecated; use %s", old, newPath)}
+			if migration.Ignored {
+				w.Message = fmt.Sprintf("%s is deprecated and ignored because %s is set", old, newPath)
+			}
+			if hasOrigin {
+				w.Line = origin.Line
+			}
+			warn(w)
+		}
+	}
+	return fired, nil
+}
+
+// MigrateFile applies MigrateKeys to a file and writes it back with
+// DumpFileWith when any migration fired, so opts can request a backup or a
+// dry run
+// Usage: fired, err := easyyaml.MigrateFile("config.yaml", renames, nil, easyyaml.FileOptions{Backup: true})
+func MigrateFile(filename string, paths map[string]string, warn WarnFn, opts FileOptions) ([]KeyMigration, error) {
+	yv, err := LoadFile(filename, WithProvenance())
+	if err != nil {
+		return nil, err
+	}
+	fired, err := yv.MigrateKeys(paths, warn)
+	if err != nil || len(fired) == 0 {
+		return fired, err
+	}
+	if _, err := yv.DumpFileWith(filename, opts); err != nil {
+		return fired, err
+	}
+	return fired, nil
+}
+
+// removePath deletes the key at a dot-separated path, then removes any
+// parent objects the deletion left empty
+func (yv *YAMLValue) removePath(path string) {
+	segments := splitPaths([]string{path})[0]
+	for len(segments) > 0 {
+		parent := yv.Path(strings.Join(segments[:len(segments)-1], "."))
+		if !parent.IsObject() {
+			return
+		}
+		parent.deleteKey(segments[len(segments)-1])
+		if parent.Len() > 0 {
+			return
+		}
+		segments = segments[:len(segments)-1]
+	}
+}
+
+// pathWithin reports whether path is base or lies under it, comparing
+// whole segments so that dbx is not within db
+func pathWithin(path, base []string) bool {
+	if len(path) < len(base) {
+		return false
+	}
+	for i, segment := range base {
+		if path[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultVersionField is the key Migrator reads and bumps when none is given
+const DefaultVersionField = "configVersion"
+
+// MigrationFunc upgrades a document by one version in place
+type MigrationFunc func(doc *YAMLValue) error
+
+// Migrator applies registered migrations in version order to bring old
+// documents up to date. A document without the version field is version 0.
+type Migrator struct {
+	mu    sync.RWMutex
+	field string
+	steps map[int]MigrationFunc
+}
+
+// NewMigrator creates a migrator that tracks versions in the given
+// top-level field, or DefaultVersionField if field is ""
+func NewMigrator(field string) *Migrator {
+	if field == "" {
+		field = DefaultVersionField
+	}
+	return &Migrator{field: field, steps: make(map[int]MigrationFunc)}
+}
+
+// Register adds the migration that upgrades a document to version,
+// replacing any registered for the same version
+// Usage: m.Register(2, func(doc *easyyaml.YAMLValue) error { return doc.RenameKey("", "host", "hostname") })
+func (m *Migrator) Register(version int, fn MigrationFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps[version] = fn
+}
+
+// Latest returns the highest registered version, or 0 for none
+func (m *Migrator) Latest() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	latest := 0
+	for version := range m.steps {
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// Migrate runs every migration newer than the document's version in
+// order, setting the version field after each, and returns the versions
+// applied. If a migration fails the document is left unchanged. A document
+// newer than Latest returns an error wrapping ErrUnsupportedVersion.
+// Usage: applied, err := migrator.Migrate(cfg)
+func (m *Migrator) Migrate(doc *YAMLValue) ([]int, error) {
+	if err := doc.checkFrozen("migrate", nil); err != nil {
+		return nil, err
+	}
+	current, err := m.Version(doc)
+	if err != nil {
+		return nil, err
+	}
+	if latest := m.Latest(); current > latest {
+		return nil, fmt.Errorf("%w: %s %d, latest is %d", ErrUnsupportedVersion, m.field, current, latest)
+	}
+
+	m.mu.RLock()
+	var pending []int
+	for version := range m.steps {
+		if version > current {
+			pending = append(pending, version)
+		}
+	}
+	sort.Ints(pending)
+	steps := make([]MigrationFunc, len(pending))
+	for i, version := range pending {
+		steps[i] = m.steps[version]
+	}
+	m.mu.RUnlock()
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	logMutation("migrate", "from", current, "to", pending[len(pending)-1])
+	work := &YAMLValue{data: deepCopy(doc.data)}
+	for i, version := range pending {
+		if err := steps[i](work); err != nil {
+			return nil, fmt.Errorf("migration to %s %d: %w", m.field, version, err)
+		}
+		if err := work.Set(m.field, version); err != nil {
+			return nil, fmt.Errorf("migration to %s %d: %w", m.field, version, err)
+		}
+	}
+	doc.data = work.data
+	doc.invalidate()
+	return pending, nil
+}
+
+// Version returns the document's version, 0 if the field is absent
+func (m *Migrator) Version(doc *YAMLValue) (int, error) {
+	value, ok := doc.Lookup(m.field)
+	if !ok || value.IsNull() {
+		return 0, nil
+	}
+	version, ok := value.data.(int)
+	if !ok {
+		return 0, opError("migrate", m.field, ErrTypeMismatch, "version must be an integer, got "+typeName(value.data))
+	}
+	return version, nil
+}
@@ -0,0 +1,164 @@
+package easyyaml
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrateKeys(t *testing.T) {
+	yv, _ := Loads("server:\n  hostname: example.com\n  port: 80\nlegacy:\n  timeout: 30\nlog_level: debug\nlogging:\n  level: info\n")
+
+	var warnings []Warning
+	fired, err := yv.MigrateKeys(map[string]string{
+		"server.hostname": "server.host",
+		"legacy.timeout":  "server.timeout",
+		"log_level":       "logging.level",
+		"missing.key":     "other.key",
+	}, func(w Warning) { warnings = append(warnings, w) })
+	if err != nil {
+		t.Fatalf("MigrateKeys failed: %v", err)
+	}
+
+	expected := []KeyMigration{
+		{From: "legacy.timeout", To: "server.timeout"},
+		{From: "log_level", To: "logging.level", Ignored: true},
+		{From: "server.hostname", To: "server.host"},
+	}
+	if len(fired) != len(expected) {
+		t.Fatalf("Expected %d migrations, got %v", len(expected), fired)
+	}
+	for i := range expected {
+		if fired[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], fired[i])
+		}
+	}
+
+	if yv.Path("server.host").AsString() != "example.com" || yv.Path("server.timeout").AsInt() != 30 {
+		t.Errorf("Expected values at new paths, got %v", yv.Raw())
+	}
+	if yv.Has("legacy") || yv.Has("log_level") || yv.Path("server.hostname").Exists() {
+		t.Errorf("Expected deprecated paths removed, got %v", yv.Raw())
+	}
+	if yv.Path("logging.level").AsString() != "info" {
+		t.Errorf("Expected existing logging.level kept, got %v", yv.Path("logging.level").Raw())
+	}
+
+	if len(warnings) != 3 || warnings[0].Kind != WarnDeprecatedKey || warnings[0].Message != "legacy.timeout is deprecated; use server.timeout" {
+		t.Errorf("Unexpected warnings: %v", warnings)
+	}
+	if warnings[1].Message != "log_level is deprecated and ignored because logging.level is set" {
+		t.Errorf("Unexpected ignored warning: %s", warnings[1].Message)
+	}
+}
+
+func TestMigrateKeysNested(t *testing.T) {
+	yv, _ := Loads("db:\n  host: a\n  port: 1\ncache:\n  inner: {size: 2}\nqueue:\n  inner: x\n  other: y\nname: app\n")
+	fired, err := yv.MigrateKeys(map[string]string{
+		"db":          "db.primary",
+		"cache.inner": "cache",
+		"queue.inner": "queue",
+		"name":        "name",
+	}, nil)
+	if err != nil || len(fired) != 4 {
+		t.Fatalf("Expected 4 migrations, got %v, %v", fired, err)
+	}
+	if yv.Path("db.primary.host").AsString() != "a" || yv.Path("db.primary.port").AsInt() != 1 || yv.Get("db").Len() != 1 {
+		t.Errorf("Expected db moved under db.primary, got %v", yv.Get("db").Raw())
+	}
+	if yv.Path("cache.size").AsInt() != 2 || yv.Path("cache.inner").Exists() {
+		t.Errorf("Expected cache.inner moved up to cache, got %v", yv.Get("cache").Raw())
+	}
+	if !fired[3].Ignored || yv.Path("queue.other").AsString() != "y" || yv.Path("queue.inner").Exists() {
+		t.Errorf("Expected queue.inner ignored as queue is set, got %v, %v", fired[3], yv.Get("queue").Raw())
+	}
+	if fired[2].Ignored || yv.Get("name").AsString() != "app" {
+		t.Errorf("Expected name kept, got %v, %v", fired[2], yv.Raw())
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("name: app\nhostname: example.com\n"), 0644)
+
+	var line int
+	fired, err := MigrateFile(path, map[string]string{"hostname": "server.host"}, func(w Warning) { line = w.Line }, FileOptions{})
+	if err != nil {
+		t.Fatalf("MigrateFile failed: %v", err)
+	}
+	if len(fired) != 1 || line != 2 {
+		t.Errorf("Expected one migration reported at line 2, got %v at line %d", fired, line)
+	}
+
+	migrated, _ := LoadFile(path)
+	if migrated.Path("server.host").AsString() != "example.com" || migrated.Has("hostname") {
+		t.Errorf("Expected file migrated, got %v", migrated.Raw())
+	}
+
+	before, _ := os.Stat(path)
+	fired, err = MigrateFile(path, map[string]string{"hostname": "server.host"}, nil, FileOptions{})
+	after, _ := os.Stat(path)
+	if err != nil || len(fired) != 0 || !after.ModTime().Equal(before.ModTime()) {
+		t.Errorf("Expected no rewrite when nothing fires, got %v, %v", fired, err)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	m := NewMigrator("")
+	m.Register(2, func(doc *YAMLValue) error {
+		return doc.RenameKey("", "host", "hostname")
+	})
+	m.Register(1, func(doc *YAMLValue) error {
+		return doc.Set("port", 8080)
+	})
+	m.Register(3, func(doc *YAMLValue) error {
+		_, err := doc.MigrateKeys(map[string]string{"hostname": "server.host"}, nil)
+		return err
+	})
+
+	doc, _ := Loads("host: example.com\n")
+	applied, err := m.Migrate(doc)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if len(applied) != 3 || applied[0] != 1 || applied[2] != 3 {
+		t.Errorf("Expected versions 1-3 applied, got %v", applied)
+	}
+	if doc.Get("configVersion").AsInt() != 3 || doc.Path("server.host").AsString() != "example.com" || doc.Get("port").AsInt() != 8080 {
+		t.Errorf("Unexpected migrated document: %v", doc.Raw())
+	}
+
+	applied, err = m.Migrate(doc)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("Expected nothing pending, got %v, %v", applied, err)
+	}
+
+	partial, _ := Loads("configVersion: 2\nhostname: a\n")
+	if applied, _ := m.Migrate(partial); len(applied) != 1 || applied[0] != 3 {
+		t.Errorf("Expected only version 3 applied, got %v", applied)
+	}
+}
+
+func TestMigratorErrors(t *testing.T) {
+	m := NewMigrator("schemaVersion")
+	m.Register(1, func(doc *YAMLValue) error { return doc.Set("a", 1) })
+	m.Register(2, func(doc *YAMLValue) error { return doc.RenameKey("", "missing", "b") })
+
+	doc, _ := Loads("name: x\n")
+	if _, err := m.Migrate(doc); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+	if doc.Has("a") || doc.Has("schemaVersion") {
+		t.Errorf("Expected document unchanged after a failed migration, got %v", doc.Raw())
+	}
+
+	newer, _ := Loads("schemaVersion: 5\n")
+	if _, err := m.Migrate(newer); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion, got %v", err)
+	}
+	bad, _ := Loads("schemaVersion: two\n")
+	if _, err := m.Migrate(bad); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package easyyaml
+
+import "math"
+
+// Increment adds delta to the number at a dot-separated path, which is
+// created as delta if it is missing or null. An integer stays an integer
+// when delta is whole; otherwise the result is a float. It returns an error
+// wrapping ErrTypeMismatch for a value that is not a number.
+// Usage: err := stats.Increment("deploys.total", 1)
+func (yv *YAMLValue) Increment(path string, delta float64) error {
+	logMutation("increment", "path", path)
+	if err := yv.checkFrozen("increment", path); err != nil {
+		return err
+	}
+	whole := delta == math.Trunc(delta) && math.Abs(delta) < 1<<53
+	current := yv.Path(path)
+	switch n := current.data.(type) {
+	case nil:
+		if whole {
+			return yv.SetPath(path, int(delta))
+		}
+		return yv.SetPath(path, delta)
+	case int:
+		if whole {
+			return yv.SetPath(path, n+int(delta))
+		}
+	}
+	f, ok := toFloat(current.data)
+	if _, isBool := current.data.(bool); !ok || isBool {
+		return opError("increment", path, ErrTypeMismatch, "cannot increment "+typeName(current.data))
+	}
+	return yv.SetPath(path, f+delta)
+}
+
+// AppendPath appends a value to the array at a dot-separated path, which
+// is created if it is missing. It returns an error wrapping ErrNotAnArray
+// for a value that is not an array.
+// Usage: err := cfg.AppendPath("spec.template.spec.containers", sidecar)
+func (yv *YAMLValue) AppendPath(path string, value interface{}) error {
+	logMutation("append path", "path", path)
+	if err := yv.checkFrozen("append path", path); err != nil {
+		return err
+	}
+	target := yv.Path(path)
+	if !target.Exists() {
+		if err := yv.SetPath(path, []interface{}{}); err != nil {
+			return err
+		}
+		target = yv.Path(path)
+	}
+	arr, ok := target.data.([]interface{})
+	if !ok {
+		return opError("append path", path, ErrNotAnArray, typeName(target.data))
+	}
+	yv.invalidate()
+	target.replaceData(append(arr, nil))
+	if err := target.set(len(arr), value); err != nil {
+		target.replaceData(arr)
+		return err
+	}
+	return nil
+}
+
+// Toggle flips the boolean at a dot-separated path, which is created as
+// true if it is missing or null, and returns the new value. It returns an
+// error wrapping ErrTypeMismatch for a value that is not a boolean.
+// Usage: enabled, err := flags.Toggle("features.new_checkout")
+func (yv *YAMLValue) Toggle(path string) (bool, error) {
+	logMutation("toggle", "path", path)
+	if err := yv.checkFrozen("toggle", path); err != nil {
+		return false, err
+	}
+	current := yv.Path(path)
+	value := true
+	switch b := current.data.(type) {
+	case nil:
+	case bool:
+		value = !b
+	default:
+		return false, opError("toggle", path, ErrTypeMismatch, "cannot toggle "+typeName(current.data))
+	}
+	if err := yv.SetPath(path, value); err != nil {
+		return false, err
+	}
+	return value, nil
+}
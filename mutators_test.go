@@ -0,0 +1,76 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIncrement(t *testing.T) {
+	yv, _ := Loads("stats:\n  runs: 2\n  ratio: 0.5\n  empty: null\n  name: x\n  ok: true\n")
+	for _, step := range []struct {
+		path  string
+		delta float64
+	}{{"stats.runs", 1}, {"stats.ratio", 0.25}, {"stats.empty", 3}, {"stats.new.count", -1}} {
+		if err := yv.Increment(step.path, step.delta); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.path, err)
+		}
+	}
+	expected := map[string]interface{}{"stats.runs": 3, "stats.ratio": 0.75, "stats.empty": 3, "stats.new.count": -1}
+	for path, want := range expected {
+		if got := yv.Path(path).Raw(); got != want {
+			t.Errorf("%s: expected %v (%T), got %v (%T)", path, want, want, got, got)
+		}
+	}
+	if err := yv.Increment("stats.runs", 0.5); err != nil || yv.Path("stats.runs").Raw() != 3.5 {
+		t.Errorf("Expected fractional delta to give a float, got %v, %v", yv.Path("stats.runs").Raw(), err)
+	}
+	for _, path := range []string{"stats.name", "stats.ok"} {
+		if err := yv.Increment(path, 1); !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("%s: expected ErrTypeMismatch, got %v", path, err)
+		}
+	}
+}
+
+func TestAppendPath(t *testing.T) {
+	yv, _ := Loads("spec:\n  containers:\n    - name: app\n  name: web\nlists: [[a]]\n")
+	if err := yv.AppendPath("spec.containers", map[string]interface{}{"name": "sidecar"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := yv.AppendPath("spec.volumes", "data"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := yv.AppendPath("lists.0", "b"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := yv.Query("spec.containers.*.name").Strings(); len(got) != 2 || got[1] != "sidecar" {
+		t.Errorf("Unexpected containers: %v", got)
+	}
+	if yv.Path("spec.volumes.0").AsString() != "data" || yv.Path("lists.0.1").AsString() != "b" {
+		t.Errorf("Unexpected document: %s", yv)
+	}
+	if err := yv.AppendPath("spec.name", "x"); !errors.Is(err, ErrNotAnArray) {
+		t.Errorf("Expected ErrNotAnArray, got %v", err)
+	}
+
+	root := NewArray()
+	if err := root.AppendPath("", 1); err != nil || root.Len() != 1 {
+		t.Errorf("Expected root array to be appended to, got %v, %v", root.Raw(), err)
+	}
+}
+
+func TestToggle(t *testing.T) {
+	yv, _ := Loads("features:\n  beta: true\n  name: x\n")
+	if value, err := yv.Toggle("features.beta"); value || err != nil {
+		t.Errorf("Expected false, got %v, %v", value, err)
+	}
+	if value, err := yv.Toggle("features.new"); !value || err != nil || !yv.Path("features.new").AsBool() {
+		t.Errorf("Expected missing flag to become true, got %v, %v", value, err)
+	}
+	if _, err := yv.Toggle("features.name"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+	yv.Freeze()
+	if _, err := yv.Toggle("features.beta"); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected ErrFrozen, got %v", err)
+	}
+}
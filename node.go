@@ -0,0 +1,79 @@
+package easyyaml
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithNode makes a load keep the parsed yaml.v3 node tree, so Node can
+// return it without parsing the input again
+// Usage: doc, err := easyyaml.LoadFile("app.yaml", easyyaml.WithNode())
+func WithNode() LoadOption {
+	return func(opts *Options) {
+		opts.KeepNode = true
+	}
+}
+
+// Node returns the yaml.v3 node the value was loaded from, for features
+// easyyaml does not cover, such as positions and comments. It
+// returns nil unless the document was loaded WithNode or built with
+// FromNode. The node tree is the one that was parsed: changes made through
+// the YAMLValue are not reflected in it, and changes to it are not
+// reflected in the YAMLValue.
+// Usage: line := doc.Path("server.port").Node().Line
+func (yv *YAMLValue) Node() *yaml.Node {
+	if yv.meta == nil || yv.meta.node == nil {
+		return nil
+	}
+	node := contentNode(yv.meta.node)
+	for _, segment := range splitChildPath(yv.at) {
+		node = childNode(node, segment)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// FromNode creates a YAMLValue from a yaml.v3 document or value node, such
+// as one built or edited with the yaml.v3 API. The value keeps the node for
+// Node, along with its scalar styles and tags.
+// Usage: doc, err := easyyaml.FromNode(&node)
+func FromNode(node *yaml.Node) (*YAMLValue, error) {
+	data, _, err := decodeNode(node, nil)
+	if err != nil {
+		return nil, err
+	}
+	meta := recordMeta(node)
+	meta.node = node
+	return &YAMLValue{data: data, meta: meta}, nil
+}
+
+// contentNode returns the value node of a document node, following aliases
+func contentNode(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+// childNode returns the value node for a mapping key or sequence index
+func childNode(n *yaml.Node, segment string) *yaml.Node {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := len(n.Content) - 2; i >= 0; i -= 2 {
+			if n.Content[i].Value == segment {
+				return contentNode(n.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(n.Content) {
+			return contentNode(n.Content[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package easyyaml
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestNode(t *testing.T) {
+	input := "# app settings\nserver:\n  # listen port\n  port: 8080\n  hosts: [a, b]\nbase: &base {x: 1}\ncopy: *base\n"
+	plain, _ := Loads(input)
+	if plain.Node() != nil {
+		t.Error("Expected no node without WithNode")
+	}
+
+	doc, err := Loads(input, WithNode())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if root := doc.Node(); root == nil || root.Kind != yaml.MappingNode || root.Content[1].Content[0].HeadComment != "# listen port" {
+		t.Errorf("Expected the root mapping node, got %+v", root)
+	}
+	port := doc.Path("server.port").Node()
+	if port == nil || port.Value != "8080" || port.Line != 4 {
+		t.Errorf("Expected the port node at line 4, got %+v", port)
+	}
+	if doc.Q("server", "hosts", 1).Node().Value != "b" {
+		t.Error("Expected sequence items to resolve")
+	}
+	if doc.Path("copy.x").Node().Value != "1" {
+		t.Error("Expected aliases to resolve")
+	}
+
+	docs, _ := LoadAll([]byte("a: 1\n---\nb: 2\n"), WithNode())
+	if docs.Get(1).Get("b").Node().Line != 3 {
+		t.Error("Expected LoadAll documents to keep their nodes")
+	}
+}
+
+func TestFromNode(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("name: 'app'\nkind: !Widget {size: 2}\n"), &node); err != nil {
+		t.Fatal(err)
+	}
+	node.Content[0].Content = append(node.Content[0].Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: "added"},
+		&yaml.Node{Kind: yaml.ScalarNode, Value: "true", Tag: "!!bool"})
+
+	doc, err := FromNode(&node)
+	if err != nil {
+		t.Fatalf("FromNode failed: %v", err)
+	}
+	if doc.Get("added").AsBool() != true || doc.Path("kind.size").AsInt() != 2 {
+		t.Errorf("Unexpected data: %v", doc.Raw())
+	}
+	if doc.Get("kind").Tag() != "!Widget" || doc.Get("name").Style() != SingleQuotedStyle {
+		t.Error("Expected tags and styles taken from the node")
+	}
+	if doc.Get("name").Node() != node.Content[0].Content[1] {
+		t.Error("Expected Node to return the original node")
+	}
+
+	if _, err := FromNode(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "x"}); err == nil {
+		t.Error("Expected an invalid node to fail")
+	}
+}
+
+func TestNodeDottedKey(t *testing.T) {
+	yv, _ := Loads("a.b: 1\na:\n  b: 2\n", WithNode())
+	if n := yv.Get("a.b").Node(); n == nil || n.Value != "1" {
+		t.Errorf("Expected the node of the dotted key, got %v", n)
+	}
+	if n := yv.Path("a.b").Node(); n == nil || n.Value != "2" {
+		t.Errorf("Expected the nested node, got %v", n)
+	}
+}
@@ -0,0 +1,276 @@
+package easyyaml
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javanhut/easyjson"
+	"gopkg.in/yaml.v3"
+)
+
+// NewNormalized creates a YAMLValue from any Go value like New, but first
+// converts it deeply into the types a loaded document holds: objects become
+// map[string]interface{} (or map[interface{}]interface{} for non-string
+// keys), slices and arrays become []interface{}, integers become int and
+// floats float64. Structs follow yaml tags and custom marshalers are
+// honored. Values YAML cannot represent, such as channels, functions,
+// complex numbers, non-scalar keys and reference cycles, return an error
+// wrapping ErrTypeMismatch that names their path.
+// Usage: cfg, err := easyyaml.NewNormalized(map[int][]uint8{1: {2, 3}})
+func NewNormalized(v interface{}) (*YAMLValue, error) {
+	data, err := canonical(reflect.ValueOf(v), "", make(map[uintptr]bool))
+	if err != nil {
+		return nil, err
+	}
+	return &YAMLValue{data: data, meta: &docMeta{}}, nil
+}
+
+// canonical converts rv into generic YAML data; seen holds the pointers,
+// maps and slices on the current path to detect cycles
+func canonical(rv reflect.Value, path string, seen map[uintptr]bool) (interface{}, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if rv.CanInterface() {
+		data, done, err := canonicalSpecial(rv, path, seen)
+		if done {
+			return data, err
+		}
+	}
+	return canonicalKind(rv, path, seen)
+}
+
+// canonicalSpecial converts wrappers, times, byte slices and values with
+// custom marshalers; done is false for anything else
+func canonicalSpecial(rv reflect.Value, path string, seen map[uintptr]bool) (data interface{}, done bool, err error) {
+	switch v := rv.Interface().(type) {
+	case *YAMLValue:
+		if v == nil {
+			return nil, true, nil
+		}
+		data, err = canonical(reflect.ValueOf(v.data), path, seen)
+		return data, true, err
+	case *easyjson.JSONValue:
+		if v == nil {
+			return nil, true, nil
+		}
+		data, err = canonical(reflect.ValueOf(v.Raw()), path, seen)
+		return data, true, err
+	case time.Time:
+		return v, true, nil
+	case []byte:
+		return append([]byte(nil), v...), true, nil
+	}
+	if rv.Kind() == reflect.Interface || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, false, nil
+	}
+	switch m := rv.Interface().(type) {
+	case yaml.Marshaler:
+		out, err := m.MarshalYAML()
+		if err != nil {
+			return nil, true, opError("normalize", path, ErrTypeMismatch, err.Error())
+		}
+		if node, ok := out.(*yaml.Node); ok {
+			if err := node.Decode(&data); err != nil {
+				return nil, true, opError("normalize", path, ErrTypeMismatch, err.Error())
+			}
+			return data, true, nil
+		}
+		data, err = canonical(reflect.ValueOf(out), path, seen)
+		return data, true, err
+	case encoding.TextMarshaler:
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, true, opError("normalize", path, ErrTypeMismatch, err.Error())
+		}
+		return string(text), true, nil
+	}
+	return nil, false, nil
+}
+
+// canonicalKind converts rv by its reflect.Kind
+func canonicalKind(rv reflect.Value, path string, seen map[uintptr]bool) (interface{}, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u <= math.MaxInt {
+			return int(u), nil
+		}
+		return rv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Ptr {
+			if seen[rv.Pointer()] {
+				return nil, opError("normalize", path, ErrTypeMismatch, "reference cycle")
+			}
+			seen[rv.Pointer()] = true
+			defer delete(seen, rv.Pointer())
+		}
+		return canonical(rv.Elem(), path, seen)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice {
+			if rv.IsNil() {
+				return nil, nil
+			}
+			if rv.Len() > 0 {
+				ptr := rv.Pointer()
+				if seen[ptr] {
+					return nil, opError("normalize", path, ErrTypeMismatch, "reference cycle")
+				}
+				seen[ptr] = true
+				defer delete(seen, ptr)
+			}
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			item, err := canonical(rv.Index(i), joinPath(path, strconv.Itoa(i)), seen)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case reflect.Map:
+		return canonicalMap(rv, path, seen)
+	case reflect.Struct:
+		obj := make(map[string]interface{})
+		if err := canonicalFields(rv, path, seen, obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	return nil, opError("normalize", path, ErrTypeMismatch, "cannot represent "+rv.Type().String())
+}
+
+// canonicalMap converts a map, keeping string keys in a
+// map[string]interface{} and any other scalar keys in a
+// map[interface{}]interface{}
+func canonicalMap(rv reflect.Value, path string, seen map[uintptr]bool) (interface{}, error) {
+	if rv.IsNil() {
+		return nil, nil
+	}
+	if seen[rv.Pointer()] {
+		return nil, opError("normalize", path, ErrTypeMismatch, "reference cycle")
+	}
+	seen[rv.Pointer()] = true
+	defer delete(seen, rv.Pointer())
+
+	keys := make([]interface{}, 0, rv.Len())
+	values := make([]interface{}, 0, rv.Len())
+	stringKeys := true
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := canonical(iter.Key(), path, seen)
+		if err != nil {
+			return nil, err
+		}
+		switch k := key.(type) {
+		case string:
+		case bool, int, uint64, time.Time:
+			stringKeys = false
+		case float64:
+			if math.IsNaN(k) {
+				return nil, opError("normalize", path, ErrTypeMismatch, "NaN map key")
+			}
+			stringKeys = false
+		default:
+			return nil, opError("normalize", path, ErrTypeMismatch, fmt.Sprintf("map key %v is not a scalar", iter.Key()))
+		}
+		value, err := canonical(iter.Value(), joinPath(path, fmt.Sprintf("%v", key)), seen)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	if stringKeys {
+		obj := make(map[string]interface{}, len(keys))
+		for i, key := range keys {
+			obj[key.(string)] = values[i]
+		}
+		return obj, nil
+	}
+	obj := make(map[interface{}]interface{}, len(keys))
+	for i, key := range keys {
+		if _, dup := obj[key]; dup {
+			return nil, opError("normalize", joinPath(path, fmt.Sprintf("%v", key)), ErrKeyExists, "keys convert to the same value")
+		}
+		obj[key] = values[i]
+	}
+	return obj, nil
+}
+
+// canonicalFields adds the exported fields of a struct to obj, named and
+// filtered by their yaml tags like yaml.v3 does, flattening ",inline" fields
+func canonicalFields(rv reflect.Value, path string, seen map[uintptr]bool, obj map[string]interface{}) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		fv := rv.Field(i)
+		if strings.Contains(flags, "inline") {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			switch fv.Kind() {
+			case reflect.Struct:
+				if err := canonicalFields(fv, path, seen, obj); err != nil {
+					return err
+				}
+			case reflect.Map:
+				inlined, err := canonicalMap(fv, path, seen)
+				if err != nil {
+					return err
+				}
+				if m, ok := inlined.(map[string]interface{}); ok {
+					for k, v := range m {
+						obj[k] = v
+					}
+				} else if inlined != nil {
+					return opError("normalize", path, ErrTypeMismatch, "inline map "+field.Name+" must have string keys")
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if strings.Contains(flags, "omitempty") && fv.IsZero() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		value, err := canonical(fv, joinPath(path, name), seen)
+		if err != nil {
+			return err
+		}
+		obj[name] = value
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type normalizedLevel int
+
+func (l normalizedLevel) MarshalText() ([]byte, error) {
+	return []byte(strings.Repeat("*", int(l))), nil
+}
+
+type normalizedBase struct {
+	Region string `yaml:"region"`
+}
+
+type normalizedServer struct {
+	normalizedBase `yaml:",inline"`
+	Host           string            `yaml:"host"`
+	Ports          []uint16          `yaml:"ports"`
+	Labels         map[string]string `yaml:"labels,omitempty"`
+	Level          normalizedLevel   `yaml:"level"`
+	Secret         string            `yaml:"-"`
+	Timeout        float32
+	hidden         int
+}
+
+func TestNewNormalized(t *testing.T) {
+	yv, err := NewNormalized(map[string]interface{}{
+		"server": &normalizedServer{
+			normalizedBase: normalizedBase{Region: "eu"},
+			Host:           "localhost",
+			Ports:          []uint16{80, 443},
+			Level:          3,
+			Secret:         "x",
+			Timeout:        1.5,
+		},
+		"codes":  map[int]string{1: "one"},
+		"matrix": [2][]int8{{1}, {2}},
+	})
+	if err != nil {
+		t.Fatalf("NewNormalized failed: %v", err)
+	}
+
+	server, ok := yv.Get("server").Raw().(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected server to be an object, got %T", yv.Get("server").Raw())
+	}
+	if server["region"] != "eu" || server["host"] != "localhost" || server["level"] != "***" || server["timeout"] != 1.5 {
+		t.Errorf("Unexpected server: %v", server)
+	}
+	if _, ok := server["secret"]; ok {
+		t.Errorf("Expected yaml:\"-\" field skipped, got %v", server)
+	}
+	if _, ok := server["labels"]; ok {
+		t.Errorf("Expected empty omitempty field skipped, got %v", server)
+	}
+	if ports, ok := server["ports"].([]interface{}); !ok || ports[1] != 443 {
+		t.Errorf("Expected ports as []interface{} of int, got %#v", server["ports"])
+	}
+	if codes, ok := yv.Get("codes").Raw().(map[interface{}]interface{}); !ok || codes[1] != "one" {
+		t.Errorf("Expected int-keyed map, got %#v", yv.Get("codes").Raw())
+	}
+	if yv.Path("matrix.1.0").Raw() != 2 {
+		t.Errorf("Expected nested array converted, got %#v", yv.Get("matrix").Raw())
+	}
+	if _, err := yv.Dumps(); err != nil {
+		t.Errorf("Expected normalized value to dump, got %v", err)
+	}
+}
+
+func TestNewNormalizedErrors(t *testing.T) {
+	type cyclic struct {
+		Next *cyclic
+	}
+	loop := &cyclic{}
+	loop.Next = loop
+
+	tests := []struct {
+		name  string
+		value interface{}
+		path  string
+	}{
+		{"channel", map[string]interface{}{"events": make(chan int)}, "events"},
+		{"func", []interface{}{1, func() {}}, "1"},
+		{"complex", map[string]interface{}{"a": map[string]interface{}{"z": 1i}}, "a.z"},
+		{"slice key", map[interface{}]int{[2]int{1, 2}: 1}, ""},
+		{"cycle", loop, "next"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNormalized(tt.value)
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("Expected ErrTypeMismatch, got %v", err)
+			}
+			var opErr *OpError
+			if !errors.As(err, &opErr) || opErr.Key != tt.path {
+				t.Errorf("Expected error at %q, got %v", tt.path, err)
+			}
+		})
+	}
+}
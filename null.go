@@ -0,0 +1,56 @@
+package easyyaml
+
+import "gopkg.in/yaml.v3"
+
+// NullStyle selects how Dump writes null values
+type NullStyle int
+
+const (
+	// NullKeyword writes nulls as "key: null"
+	NullKeyword NullStyle = iota
+	// NullEmpty writes nulls as "key:" with no value
+	NullEmpty
+	// NullTilde writes nulls as "key: ~"
+	NullTilde
+)
+
+// Null returns an explicit YAML null. Unlike a missing value it exists, so
+// Set(key, easyyaml.Null()) keeps the key with a null value, and it can be
+// used as an entry of Object or Array.
+// Usage: obj := easyyaml.Object(easyyaml.KV("name", "app"), easyyaml.KV("parent", easyyaml.Null()))
+func Null() *YAMLValue {
+	return &YAMLValue{data: nil}
+}
+
+// SetNull sets a key (for objects) or index (for arrays) to an explicit
+// null, keeping the key, where Delete would remove it
+// Usage: cfg.SetNull("proxy") // dumps as "proxy: null"
+func (yv *YAMLValue) SetNull(key interface{}) error {
+	logMutation("set_null", "key", key)
+	return yv.set(key, nil)
+}
+
+// WithNullStyle returns a view of the value that dumps nulls in the given style
+// Usage: cfg.WithNullStyle(easyyaml.NullEmpty).DumpFile("out.yaml")
+func (yv *YAMLValue) WithNullStyle(style NullStyle) *YAMLValue {
+	opts := yv.options()
+	opts.NullStyle = style
+	return yv.WithOptions(opts)
+}
+
+// setNullStyle rewrites the null scalars of a node tree in the given style
+func setNullStyle(n *yaml.Node, style NullStyle) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		switch style {
+		case NullEmpty:
+			n.Value = ""
+		case NullTilde:
+			n.Value = "~"
+		default:
+			n.Value = "null"
+		}
+	}
+	for _, child := range n.Content {
+		setNullStyle(child, style)
+	}
+}
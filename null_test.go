@@ -0,0 +1,53 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestSetNull(t *testing.T) {
+	yv, _ := Loads("name: app\nproxy: http://proxy\n")
+	if err := yv.SetNull("proxy"); err != nil {
+		t.Fatalf("SetNull failed: %v", err)
+	}
+	value, ok := yv.Lookup("proxy")
+	if !ok || !value.IsNull() {
+		t.Errorf("Expected proxy kept with a null value, got %v, %v", value.Raw(), ok)
+	}
+	if out, _ := yv.Dumps(); out != "name: app\nproxy: null\n" {
+		t.Errorf("Unexpected output: %q", out)
+	}
+
+	if err := yv.Set("parent", Null()); err != nil || !yv.Has("parent") {
+		t.Errorf("Expected Set with Null to keep the key, got %v", err)
+	}
+	if !Null().Exists() || !Null().IsNull() {
+		t.Error("Expected Null to exist and be null")
+	}
+
+	obj := Object(KV("a", Null()), KV("b", 1))
+	if !obj.Has("a") || !obj.Get("a").IsNull() {
+		t.Errorf("Expected Object to hold an explicit null, got %v", obj.Raw())
+	}
+}
+
+func TestWithNullStyle(t *testing.T) {
+	yv, _ := Loads("a: null\nb:\n  - ~\n  - 1\nc: text\n")
+	expected := map[NullStyle]string{
+		NullKeyword: "a: null\nb:\n    - null\n    - 1\nc: text\n",
+		NullEmpty:   "a:\nb:\n    -\n    - 1\nc: text\n",
+		NullTilde:   "a: ~\nb:\n    - ~\n    - 1\nc: text\n",
+	}
+	for style, want := range expected {
+		got, err := yv.WithNullStyle(style).Dumps()
+		if err != nil {
+			t.Fatalf("Dumps failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("Style %d: expected %q, got %q", style, want, got)
+		}
+		back, err := Loads(got)
+		if err != nil || !back.Get("a").IsNull() || !back.Has("a") {
+			t.Errorf("Style %d: expected output to reload with a null, got %v", style, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package openapi
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// Bundle returns a copy of the spec in a single document: every "$ref"
+// into another file under baseDir is replaced by a local ref to a copy of
+// its target added to the spec's components (or Swagger 2.0 definitions).
+// Local refs are kept, so recursive schemas bundle fine. Targets under
+// /components/<section>/<name> keep their section and name; others become
+// schemas named after the last pointer token or the file. Names already in
+// use get a numeric suffix.
+// Usage: bundled, err := openapi.Bundle(spec, "api"); bundled.DumpFile("dist/openapi.yaml")
+func Bundle(spec *easyyaml.YAMLValue, baseDir string) (*easyyaml.YAMLValue, error) {
+	b := &bundler{
+		swagger: spec.Has("swagger"),
+		files:   make(map[string]*easyyaml.YAMLValue),
+		refs:    make(map[string]string),
+		taken:   make(map[string]bool),
+	}
+	for _, section := range componentSections(spec) {
+		for _, key := range spec.Path(strings.Join(section, ".")).Keys() {
+			b.taken[b.localRef(section[len(section)-1], fmt.Sprintf("%v", key))] = true
+		}
+	}
+
+	data, err := b.bundle(spec.Raw(), "", baseDir)
+	if err != nil {
+		return nil, err
+	}
+	bundled := easyyaml.New(data)
+	for _, c := range b.added {
+		keys := []string{c.section, c.name}
+		if !b.swagger {
+			keys = append([]string{"components"}, keys...)
+		}
+		if err := mergeEntry(bundled, keys, easyyaml.New(c.value)); err != nil {
+			return nil, err
+		}
+	}
+	return bundled, nil
+}
+
+// bundler tracks the external targets pulled into a spec
+type bundler struct {
+	swagger bool
+	files   map[string]*easyyaml.YAMLValue
+	// refs maps "file#fragment" to the local ref that replaces it
+	refs  map[string]string
+	taken map[string]bool
+	added []component
+}
+
+// component is an external target added to the bundled spec
+type component struct {
+	section, name string
+	value         interface{}
+}
+
+// bundle copies data, rewriting its refs. file is the absolute path of the
+// external file data comes from, or "" for the spec itself; dir is where
+// its relative refs start.
+func (b *bundler) bundle(data interface{}, file, dir string) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			if ref, ok := child.(string); ok && k == "$ref" {
+				local, err := b.rewrite(ref, file, dir)
+				if err != nil {
+					return nil, err
+				}
+				m[k] = local
+				continue
+			}
+			bundled, err := b.bundle(child, file, dir)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = bundled
+		}
+		return m, nil
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, child := range v {
+			bundled, err := b.bundle(child, file, dir)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = bundled
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, child := range v {
+			bundled, err := b.bundle(child, file, dir)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = bundled
+		}
+		return s, nil
+	}
+	return data, nil
+}
+
+// rewrite returns the local ref that replaces ref, pulling its target into
+// the bundle the first time it is seen
+func (b *bundler) rewrite(ref, file, dir string) (string, error) {
+	target, fragment, _ := strings.Cut(ref, "#")
+	if target == "" && file == "" {
+		return ref, nil
+	}
+	if strings.Contains(target, "://") {
+		return "", fmt.Errorf("%w: remote reference %s is not supported", easyyaml.ErrKeyNotFound, ref)
+	}
+	if target == "" {
+		target = file
+	} else if !filepath.IsAbs(target) {
+		target = filepath.Join(dir, target)
+	}
+	key := target + "#" + fragment
+	if local, ok := b.refs[key]; ok {
+		return local, nil
+	}
+
+	doc, ok := b.files[target]
+	if !ok {
+		var err error
+		doc, err = easyyaml.LoadFile(target)
+		if err != nil {
+			return "", fmt.Errorf("failed to bundle %s: %w", ref, err)
+		}
+		b.files[target] = doc
+	}
+	value := doc.Pointer(fragment)
+	if !value.Exists() {
+		return "", fmt.Errorf("%w: %s", easyyaml.ErrKeyNotFound, ref)
+	}
+	tokens := pointerTokens(fragment)
+
+	section, name := b.placement(tokens, target)
+	local := b.localRef(section, name)
+	for i, base := 2, name; b.taken[local]; i++ {
+		name = base + strconv.Itoa(i)
+		local = b.localRef(section, name)
+	}
+	b.taken[local] = true
+	b.refs[key] = local
+
+	bundled, err := b.bundle(value.Raw(), target, filepath.Dir(target))
+	if err != nil {
+		return "", err
+	}
+	b.added = append(b.added, component{section: section, name: name, value: bundled})
+	return local, nil
+}
+
+// placement picks the section and name of a pulled-in target from its
+// pointer tokens and file
+func (b *bundler) placement(tokens []string, file string) (string, string) {
+	section := "schemas"
+	if b.swagger {
+		section = "definitions"
+	}
+	switch {
+	case len(tokens) == 3 && tokens[0] == "components":
+		return tokens[1], tokens[2]
+	case len(tokens) == 2 && b.swagger && (tokens[0] == "definitions" || tokens[0] == "parameters" || tokens[0] == "responses"):
+		return tokens[0], tokens[1]
+	case len(tokens) > 0:
+		return section, tokens[len(tokens)-1]
+	}
+	base := filepath.Base(file)
+	return section, strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// localRef returns the ref to a component of the bundled spec
+func (b *bundler) localRef(section, name string) string {
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+	if b.swagger {
+		return "#/" + section + "/" + name
+	}
+	return "#/components/" + section + "/" + name
+}
+
+// pointerTokens splits a JSON Pointer fragment into unescaped tokens, to
+// name the component it is bundled as
+func pointerTokens(fragment string) []string {
+	var tokens []string
+	for _, token := range strings.Split(fragment, "/") {
+		if token != "" {
+			tokens = append(tokens, strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"))
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,182 @@
+// Package openapi provides helpers for OpenAPI 3 and Swagger 2.0 specs
+// loaded with easyyaml: listing paths and operations, resolving and
+// bundling "$ref"s, and merging specs split across files.
+//
+// Typical use:
+//
+//	spec, _ := easyyaml.LoadFile("api/openapi.yaml")
+//	bundled, err := openapi.Bundle(spec, "api")
+//	for _, op := range openapi.Operations(bundled) {
+//		fmt.Println(op.Method, op.Path, op.OperationID)
+//	}
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// Methods are the HTTP methods an OpenAPI path item can define, in the
+// order Operations lists them
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Operation is one method of one path of a spec
+type Operation struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Tags        []string
+	// Spec is the operation object
+	Spec *easyyaml.YAMLValue
+}
+
+// Paths returns the paths of a spec, sorted
+func Paths(spec *easyyaml.YAMLValue) []string {
+	var paths []string
+	for _, key := range spec.Get("paths").Keys() {
+		paths = append(paths, fmt.Sprintf("%v", key))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Operations returns the operations of a spec, sorted by path and then in
+// the order of Methods
+// Usage: for _, op := range openapi.Operations(spec) { fmt.Println(op.Method, op.Path) }
+func Operations(spec *easyyaml.YAMLValue) []Operation {
+	var ops []Operation
+	for _, path := range Paths(spec) {
+		item := spec.Get("paths").Get(path)
+		for _, method := range Methods {
+			op, ok := item.Lookup(method)
+			if !ok || !op.IsObject() {
+				continue
+			}
+			var tags []string
+			for _, tag := range op.Get("tags").AsArray() {
+				tags = append(tags, tag.AsString())
+			}
+			ops = append(ops, Operation{
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				OperationID: op.Get("operationId").AsString(),
+				Summary:     op.Get("summary").AsString(),
+				Tags:        tags,
+				Spec:        op,
+			})
+		}
+	}
+	return ops
+}
+
+// Resolve returns a copy of the spec with every "$ref", local or into
+// another file under baseDir, replaced by its target. Recursive schemas
+// cannot be inlined and return an error wrapping easyyaml.ErrRefCycle; use
+// Bundle for those.
+// Usage: resolved, err := openapi.Resolve(spec, "api")
+func Resolve(spec *easyyaml.YAMLValue, baseDir string) (*easyyaml.YAMLValue, error) {
+	return spec.ResolveRefs(baseDir)
+}
+
+// Merge combines specs into one. The first spec provides everything but
+// the paths, the reusable components (or Swagger 2.0 definitions,
+// parameters and responses) and the tags, which are merged from all of
+// them. The same operation or component defined differently by two specs
+// returns an error wrapping easyyaml.ErrKeyExists.
+// Usage: merged, err := openapi.Merge(usersSpec, ordersSpec)
+func Merge(specs ...*easyyaml.YAMLValue) (*easyyaml.YAMLValue, error) {
+	if len(specs) == 0 {
+		return easyyaml.NewObject(), nil
+	}
+	merged := specs[0].Clone()
+	sections := componentSections(merged)
+	for _, spec := range specs[1:] {
+		for _, path := range Paths(spec) {
+			item := spec.Get("paths").Get(path)
+			for _, key := range item.Keys() {
+				name := fmt.Sprintf("%v", key)
+				if err := mergeEntry(merged, []string{"paths", path, name}, item.Get(name)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, section := range sections {
+			components := spec.Path(strings.Join(section, "."))
+			for _, key := range components.Keys() {
+				name := fmt.Sprintf("%v", key)
+				if err := mergeEntry(merged, append(append([]string(nil), section...), name), components.Get(name)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := mergeTags(merged, spec); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// componentSections returns the locations of the reusable objects of a
+// spec, as key segments
+func componentSections(spec *easyyaml.YAMLValue) [][]string {
+	if spec.Has("swagger") {
+		return [][]string{{"definitions"}, {"parameters"}, {"responses"}, {"securityDefinitions"}}
+	}
+	var sections [][]string
+	for _, name := range []string{"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links", "callbacks", "pathItems"} {
+		sections = append(sections, []string{"components", name})
+	}
+	return sections
+}
+
+// mergeEntry sets the value at keys in spec unless an equal value is
+// already there, creating objects on the way
+func mergeEntry(spec *easyyaml.YAMLValue, keys []string, value *easyyaml.YAMLValue) error {
+	parent := spec
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := parent.Lookup(key)
+		if !ok {
+			if err := parent.Set(key, easyyaml.NewObject()); err != nil {
+				return err
+			}
+			next = parent.Get(key)
+		}
+		parent = next
+	}
+	last := keys[len(keys)-1]
+	if existing, ok := parent.Lookup(last); ok {
+		if reflect.DeepEqual(existing.Raw(), value.Raw()) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s is defined differently by two specs", easyyaml.ErrKeyExists, strings.Join(keys, " "))
+	}
+	return parent.Set(last, value.Clone())
+}
+
+// mergeTags appends the tags of spec that merged does not declare yet
+func mergeTags(merged, spec *easyyaml.YAMLValue) error {
+	var tags []interface{}
+	known := make(map[string]bool)
+	for _, tag := range merged.Get("tags").AsArray() {
+		tags = append(tags, tag.Raw())
+		known[tag.Get("name").AsString()] = true
+	}
+	added := false
+	for _, tag := range spec.Get("tags").AsArray() {
+		name := tag.Get("name").AsString()
+		if !known[name] {
+			known[name] = true
+			tags = append(tags, tag.Clone().Raw())
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	return merged.Set("tags", tags)
+}
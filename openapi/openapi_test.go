@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+const petsSpec = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "schemas/pet.yaml#/components/schemas/Pet"
+    get:
+      operationId: listPets
+      summary: List pets
+      tags: [pets]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/PetList"
+components:
+  schemas:
+    PetList:
+      type: array
+      items:
+        $ref: "schemas/pet.yaml#/components/schemas/Pet"
+    Error:
+      type: string
+`
+
+func TestOperations(t *testing.T) {
+	spec, _ := easyyaml.Loads(petsSpec)
+	ops := Operations(spec)
+	if len(ops) != 2 {
+		t.Fatalf("Expected 2 operations, got %v", ops)
+	}
+	if ops[0].Method != "GET" || ops[0].OperationID != "listPets" || ops[0].Summary != "List pets" || len(ops[0].Tags) != 1 {
+		t.Errorf("Unexpected first operation: %+v", ops[0])
+	}
+	if ops[1].Method != "POST" || ops[1].Path != "/pets" || !ops[1].Spec.Has("requestBody") {
+		t.Errorf("Unexpected second operation: %+v", ops[1])
+	}
+	if paths := Paths(spec); len(paths) != 1 || paths[0] != "/pets" {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+}
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "schemas"), 0755)
+	os.WriteFile(filepath.Join(dir, "schemas", "pet.yaml"), []byte(`components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: "owner.yaml"
+        error:
+          $ref: "#/components/schemas/Error"
+        parent:
+          $ref: "#/components/schemas/Pet"
+    Error:
+      type: object
+`), 0644)
+	os.WriteFile(filepath.Join(dir, "schemas", "owner.yaml"), []byte("type: object\nproperties:\n  name: {type: string}\n"), 0644)
+
+	spec, _ := easyyaml.Loads(petsSpec)
+	bundled, err := Bundle(spec, dir)
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	schema := bundled.Path("paths./pets.post.requestBody.content.application/json.schema")
+	if schema.Get("$ref").AsString() != "#/components/schemas/Pet" {
+		t.Errorf("Expected the external ref rewritten, got %v", schema.Raw())
+	}
+	pet := bundled.Path("components.schemas.Pet.properties")
+	if pet.Path("owner.$ref").AsString() != "#/components/schemas/owner" {
+		t.Errorf("Expected a whole-file ref named after the file, got %v", pet.Get("owner").Raw())
+	}
+	if pet.Path("parent.$ref").AsString() != "#/components/schemas/Pet" {
+		t.Errorf("Expected the recursive ref kept local, got %v", pet.Get("parent").Raw())
+	}
+	if pet.Path("error.$ref").AsString() != "#/components/schemas/Error2" {
+		t.Errorf("Expected the clashing name suffixed, got %v", pet.Get("error").Raw())
+	}
+	if bundled.Path("components.schemas.Error.type").AsString() != "string" || bundled.Path("components.schemas.Error2.type").AsString() != "object" {
+		t.Errorf("Unexpected schemas: %v", bundled.Path("components.schemas").Raw())
+	}
+	if bundled.Path("components.schemas.PetList.items.$ref").AsString() != "#/components/schemas/Pet" {
+		t.Errorf("Expected a repeated ref to reuse the component, got %v", bundled.Path("components.schemas.PetList").Raw())
+	}
+	if spec.Path("components.schemas.PetList.items.$ref").AsString() != "schemas/pet.yaml#/components/schemas/Pet" {
+		t.Error("Expected the original spec unchanged")
+	}
+
+	if _, err := Bundle(easyyaml.New(map[string]interface{}{"$ref": "missing.yaml"}), dir); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	users, _ := easyyaml.Loads(`openapi: 3.0.3
+info: {title: Users, version: 1.0.0}
+tags: [{name: users}]
+paths:
+  /users:
+    get: {operationId: listUsers}
+components:
+  schemas:
+    Error: {type: string}
+`)
+	orders, _ := easyyaml.Loads(`openapi: 3.0.3
+info: {title: Orders, version: 2.0.0}
+tags: [{name: orders}, {name: users}]
+paths:
+  /orders:
+    get: {operationId: listOrders}
+  /users:
+    post: {operationId: createUser}
+components:
+  schemas:
+    Error: {type: string}
+    Order: {type: object}
+`)
+	merged, err := Merge(users, orders)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if merged.Path("info.title").AsString() != "Users" {
+		t.Errorf("Expected the first spec's info, got %v", merged.Get("info").Raw())
+	}
+	if ops := Operations(merged); len(ops) != 3 {
+		t.Errorf("Expected 3 operations, got %v", ops)
+	}
+	if !merged.Path("components.schemas.Order").Exists() || merged.Get("tags").Len() != 2 {
+		t.Errorf("Unexpected merged spec: %v", merged.Raw())
+	}
+	if users.Path("paths./orders").Exists() {
+		t.Error("Expected the first spec unchanged")
+	}
+
+	conflicting, _ := easyyaml.Loads("openapi: 3.0.3\npaths:\n  /users:\n    get: {operationId: other}\n")
+	if _, err := Merge(users, conflicting); !errors.Is(err, easyyaml.ErrKeyExists) {
+		t.Errorf("Expected ErrKeyExists, got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package easyyaml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StringFormat selects how AsString renders objects and arrays
+type StringFormat int
+
+const (
+	// StringFlow renders compact YAML flow style, e.g. {a: 1, b: [x, y]}
+	StringFlow StringFormat = iota
+	// StringJSON renders compact JSON, e.g. {"a":1,"b":["x","y"]}
+	StringJSON
+	// StringError makes AsString return "" and AsStringE return an error
+	StringError
+)
+
+// Options configures loading and dumping. Package-wide defaults are set with
+// SetDefaults; the *WithOptions functions take an Options value that
+// replaces the defaults for that call.
+type Options struct {
+	// Indent is the number of spaces per nesting level when dumping
+	// (0 uses the yaml.v3 default of 4)
+	Indent int
+	// SortKeys makes Keys and Values return object entries in sorted key order
+	SortKeys bool
+	// Strict makes loading fail on input containing more than one document
+	// or mapping keys that are not strings. LoadAll accepts any number of
+	// documents but rejects non-string keys in each.
+	Strict bool
+	// StringFormat controls how AsString renders objects and arrays
+	StringFormat StringFormat
+	// Intern deduplicates equal strings while loading, sharing them across
+	// every document of a LoadAll stream
+	Intern bool
+	// Recover turns panics raised while parsing malformed input into errors
+	Recover bool
+	// Fidelity makes Dump of an unmodified document return the exact input
+	Fidelity bool
+	// AlignValues pads keys when dumping so that the scalar values of each
+	// mapping start in the same column
+	AlignValues bool
+	// KeyPriority lists keys that Dump writes first, in the given order,
+	// ahead of the remaining keys in sorted order
+	KeyPriority []string
+	// KeyPriorityNested applies KeyPriority to nested mappings as well as
+	// the top level
+	KeyPriorityNested bool
+	// Provenance records the file, line and column of every value while
+	// loading, for Provenance
+	Provenance bool
+	// StrictEncoding makes loading fail on a byte order mark, UTF-16 input
+	// or CRLF line endings instead of normalizing them
+	StrictEncoding bool
+	// TabWidth, when positive, repairs input that fails to parse because of
+	// tab indentation by replacing each leading tab with TabWidth spaces
+	TabWidth int
+	// Warnings records deprecated syntax and ambiguous scalars in Warnings
+	Warnings bool
+	// AllowDuplicateKeys keeps the last value of a key defined more than once
+	// in a mapping, with a warning, instead of failing
+	AllowDuplicateKeys bool
+	// FoldKeys makes Get, Path and Q on loaded values match object keys
+	// case-insensitively when there is no exact match
+	FoldKeys bool
+	// CoerceKeys makes Get, Has and Delete on loaded values treat integer
+	// keys and their decimal strings as the same key
+	CoerceKeys bool
+	// NullStyle controls how Dump writes null values
+	NullStyle NullStyle
+	// KeepNode keeps the parsed yaml.v3 node tree of loaded values for Node
+	KeepNode bool
+	// MaxArchiveSize limits the decompressed bytes LoadArchive reads. 0 uses
+	// DefaultMaxArchiveSize and a negative value removes the limit.
+	MaxArchiveSize int64
+
+	// sourceName is the file the input was read from, recorded by Provenance
+	// and named in failure logs
+	sourceName string
+}
+
+// LoadOption adjusts the options used by a single Load, LoadFile or LoadAll
+// call on top of the package defaults
+type LoadOption func(*Options)
+
+// WithInterning makes a load deduplicate repeated string keys and values,
+// which cuts memory use for documents full of enum-like fields
+// Usage: docs, err := easyyaml.LoadAllFile("bundle.yaml", easyyaml.WithInterning())
+func WithInterning() LoadOption {
+	return func(opts *Options) {
+		opts.Intern = true
+	}
+}
+
+// loadOptions applies opts to the package defaults
+func loadOptions(opts []LoadOption) Options {
+	resolved := Defaults()
+	for _, opt := range opts {
+		opt(&resolved)
+	}
+	return resolved
+}
+
+var defaultsState = struct {
+	sync.RWMutex
+	opts Options
+}{}
+
+// SetDefaults sets the package-wide options used by Load, Dump and friends
+// Usage: easyyaml.SetDefaults(easyyaml.Options{Indent: 2, SortKeys: true, Strict: true})
+func SetDefaults(opts Options) {
+	defaultsState.Lock()
+	defer defaultsState.Unlock()
+	defaultsState.opts = opts
+}
+
+// Defaults returns the package-wide options
+func Defaults() Options {
+	defaultsState.RLock()
+	defer defaultsState.RUnlock()
+	return defaultsState.opts
+}
+
+// options returns the options attached to this value, or the package
+// defaults when none are attached
+func (yv *YAMLValue) options() Options {
+	if yv.opts != nil {
+		return *yv.opts
+	}
+	return Defaults()
+}
+
+// WithOptions returns a view of the value with opts attached. The view
+// shares the underlying data and its provenance; its Dump, Dumps, DumpFile,
+// Keys and Values honor the attached options, as do values retrieved from it
+// with Get.
+func (yv *YAMLValue) WithOptions(opts Options) *YAMLValue {
+	return &YAMLValue{data: yv.data, missing: yv.missing, directives: yv.directives, opts: &opts, cache: yv.cache, source: yv.source, blanks: yv.blanks, origins: yv.origins, warnings: yv.warnings, access: yv.access, at: yv.at, foldKeys: yv.foldKeys, coerceKeys: yv.coerceKeys, meta: yv.meta}
+}
+
+// WithIndent returns a view of the value that dumps with the given indentation
+// Usage: data.WithIndent(2).WithSortedKeys().DumpFile("out.yaml")
+func (yv *YAMLValue) WithIndent(indent int) *YAMLValue {
+	opts := yv.options()
+	opts.Indent = indent
+	return yv.WithOptions(opts)
+}
+
+// WithStringFormat returns a view of the value whose AsString renders
+// objects and arrays using format
+func (yv *YAMLValue) WithStringFormat(format StringFormat) *YAMLValue {
+	opts := yv.options()
+	opts.StringFormat = format
+	return yv.WithOptions(opts)
+}
+
+// WithSortedKeys returns a view of the value whose Keys and Values are sorted
+func (yv *YAMLValue) WithSortedKeys() *YAMLValue {
+	opts := yv.options()
+	opts.SortKeys = true
+	return yv.WithOptions(opts)
+}
+
+// LoadWithOptions parses YAML from a byte slice using opts instead of the
+// package defaults
+func LoadWithOptions(yamlBytes []byte, opts Options) (*YAMLValue, error) {
+	_, span := startSpan(context.Background(), "easyyaml.Load")
+	span.SetAttribute("yaml.size", len(yamlBytes))
+	yv, err := load(yamlBytes, opts)
+	endSpan(span, err)
+	return yv, err
+}
+
+// DumpWithOptions converts the YAMLValue to YAML bytes using opts instead of
+// the package defaults
+func (yv *YAMLValue) DumpWithOptions(opts Options) ([]byte, error) {
+	return yv.dumpWithOptions(opts)
+}
+
+// marshalIndent marshals data with the given indentation, or the yaml.v3
+// default when indent is 0
+func marshalIndent(data interface{}, indent int) ([]byte, error) {
+	if wrapped, ok := withBinary(data); ok {
+		data = wrapped
+	}
+	if indent <= 0 {
+		return yaml.Marshal(data)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// checkStrict rejects input with extra documents or non-string keys
+func checkStrict(yamlBytes []byte, data interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(yamlBytes))
+	var first, second interface{}
+	if err := dec.Decode(&first); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if err := dec.Decode(&second); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("strict mode: input contains more than one document")
+	}
+	return checkStringKeys(data)
+}
+
+// checkStringKeys rejects data with mapping keys that are not strings, the
+// part of checkStrict that applies to each document of a stream
+func checkStringKeys(data interface{}) error {
+	var found string
+	(&YAMLValue{data: data}).walk("", func(path string, value *YAMLValue) {
+		if m, ok := value.data.(map[interface{}]interface{}); ok && found == "" {
+			for k := range m {
+				if _, isStr := k.(string); !isStr {
+					found = fmt.Sprintf("strict mode: non-string key %v at %q", k, path)
+					return
+				}
+			}
+		}
+	})
+	if found != "" {
+		return errors.New(found)
+	}
+	return nil
+}
+
+// sortKeys sorts object keys when the SortKeys option is in effect
+func (yv *YAMLValue) sortKeys(keys []interface{}) []interface{} {
+	if !yv.options().SortKeys {
+		return keys
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%v", keys[i]) < fmt.Sprintf("%v", keys[j])
+	})
+	return keys
+}
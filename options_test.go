@@ -0,0 +1,93 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetDefaults(t *testing.T) {
+	defer SetDefaults(Options{})
+
+	yv, err := Loads("b: 2\na:\n  c: 3\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	out, _ := yv.Dumps()
+	if out != "a:\n    c: 3\nb: 2\n" {
+		t.Errorf("Expected default 4-space indentation, got:\n%s", out)
+	}
+
+	SetDefaults(Options{Indent: 2, SortKeys: true, Strict: true})
+
+	out, _ = yv.Dumps()
+	if out != "a:\n  c: 3\nb: 2\n" {
+		t.Errorf("Expected 2-space indentation from defaults, got:\n%s", out)
+	}
+
+	keys := yv.Keys()
+	if keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("Expected sorted keys, got %v", keys)
+	}
+	if yv.Values()[1].AsInt() != 2 {
+		t.Errorf("Expected values in sorted key order, got %v", yv.Values()[1].Raw())
+	}
+
+	if _, err := Loads("a: 1\n---\nb: 2\n"); err == nil || !strings.Contains(err.Error(), "more than one document") {
+		t.Errorf("Expected strict mode to reject multiple documents, got %v", err)
+	}
+	if _, err := Loads("1: one\n"); err == nil {
+		t.Error("Expected strict mode to reject non-string keys")
+	}
+	if _, err := LoadAlls("a: 1\n---\nb: 2\n"); err != nil {
+		t.Errorf("Expected strict mode to allow streams in LoadAll, got %v", err)
+	}
+	if _, err := LoadAlls("a: 1\n---\n1: one\n"); err == nil || !strings.Contains(err.Error(), "document 1") {
+		t.Errorf("Expected strict mode to reject non-string keys in LoadAll, got %v", err)
+	}
+
+	// Per-call options override the defaults
+	if _, err := LoadWithOptions([]byte("a: 1\n---\nb: 2\n"), Options{}); err != nil {
+		t.Errorf("Expected per-call options to disable strict mode, got %v", err)
+	}
+	dumped, err := yv.DumpWithOptions(Options{Indent: 6})
+	if err != nil || string(dumped) != "a:\n      c: 3\nb: 2\n" {
+		t.Errorf("Expected per-call indentation, got %q, %v", dumped, err)
+	}
+
+	if Defaults().Indent != 2 {
+		t.Errorf("Expected Defaults to return the configured options")
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	yv, err := Loads("b: 2\na:\n  d: 4\n  c: 3\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	view := yv.WithIndent(2).WithSortedKeys()
+
+	out, err := view.Dumps()
+	if err != nil || out != "a:\n  c: 3\n  d: 4\nb: 2\n" {
+		t.Errorf("Expected view to dump with 2-space indentation, got %q, %v", out, err)
+	}
+
+	if original, _ := yv.Dumps(); original != "a:\n    c: 3\n    d: 4\nb: 2\n" {
+		t.Errorf("Expected original to keep default options, got:\n%s", original)
+	}
+
+	keys := view.Get("a").Keys()
+	if keys[0] != "c" || keys[1] != "d" {
+		t.Errorf("Expected child of view to inherit sorted keys, got %v", keys)
+	}
+
+	view.Set("e", 5)
+	if yv.Get("e").AsInt() != 5 {
+		t.Error("Expected view to share data with the original")
+	}
+
+	if yv.WithOptions(Options{Indent: 3}).WithSortedKeys().options().Indent != 3 {
+		t.Error("Expected With* calls to build on attached options")
+	}
+}
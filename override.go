@@ -0,0 +1,117 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Override deep-merges other into the value like Merge, honoring two key
+// markers in other: "~key" deletes key, whatever its value, and "key+"
+// appends the items of its array to the array at key
+// Usage: base.Override(overlay) // overlay: {"~debug": null, "plugins+": [audit]}
+func (yv *YAMLValue) Override(other *YAMLValue) error {
+	logMutation("override", "keys", other.Len())
+	if err := yv.checkFrozen("override", nil); err != nil {
+		return err
+	}
+	yv.invalidate()
+	return yv.overrideAt(other, "")
+}
+
+// overrideAt applies other to yv, which is at path
+func (yv *YAMLValue) overrideAt(other *YAMLValue, path string) error {
+	if !yv.IsObject() {
+		return opError("override", path, ErrNotAnObject, typeName(yv.data))
+	}
+	if !other.IsObject() {
+		return opError("override", path, ErrTypeMismatch, "can only override with another object")
+	}
+
+	for _, item := range other.ItemsOrdered() {
+		name, isString := item.Key.(string)
+		switch {
+		case isString && strings.HasPrefix(name, "~") && len(name) > 1:
+			yv.deleteKey(strings.TrimPrefix(name, "~"))
+		case isString && strings.HasSuffix(name, "+") && len(name) > 1:
+			key := strings.TrimSuffix(name, "+")
+			keyPath := joinPath(path, key)
+			if !item.Value.IsArray() {
+				return opError("override", keyPath, ErrNotAnArray, "value of "+name+" must be an array")
+			}
+			additions, err := stripMarkers(item.Value.data, keyPath)
+			if err != nil {
+				return err
+			}
+			current, exists := yv.Lookup(key)
+			if !exists {
+				if err := yv.set(key, additions); err != nil {
+					return err
+				}
+				continue
+			}
+			if !current.IsArray() {
+				return opError("override", keyPath, ErrNotAnArray, "cannot append to "+typeName(current.data))
+			}
+			if err := yv.set(key, append(current.data.([]interface{}), additions.([]interface{})...)); err != nil {
+				return err
+			}
+		default:
+			keyPath := joinPath(path, fmt.Sprintf("%v", item.Key))
+			current := yv.Get(item.Key)
+			if current.IsObject() && item.Value.IsObject() {
+				if err := current.overrideAt(item.Value, keyPath); err != nil {
+					return err
+				}
+				continue
+			}
+			value, err := stripMarkers(item.Value.data, keyPath)
+			if err != nil {
+				return err
+			}
+			if err := yv.set(item.Key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteKey removes a key from either kind of map
+func (yv *YAMLValue) deleteKey(key interface{}) {
+	yv.meta.forget(childPath(yv.at, key))
+	switch m := yv.data.(type) {
+	case map[string]interface{}:
+		if keyStr, ok := key.(string); ok {
+			delete(m, keyStr)
+		}
+	case map[interface{}]interface{}:
+		delete(m, key)
+	}
+}
+
+// stripMarkers copies a value that is being added rather than merged,
+// applying its markers to nothing: "~key" entries are dropped and "key+"
+// entries become plain keys
+func stripMarkers(data interface{}, path string) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		empty := &YAMLValue{data: map[string]interface{}{}}
+		err := empty.overrideAt(&YAMLValue{data: v}, path)
+		return empty.data, err
+	case map[interface{}]interface{}:
+		empty := &YAMLValue{data: map[interface{}]interface{}{}}
+		err := empty.overrideAt(&YAMLValue{data: v}, path)
+		return empty.data, err
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, item := range v {
+			stripped, err := stripMarkers(item, joinPath(path, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			s[i] = stripped
+		}
+		return s, nil
+	}
+	return data, nil
+}
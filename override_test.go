@@ -0,0 +1,48 @@
+package easyyaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOverride(t *testing.T) {
+	base, _ := Loads(`
+server:
+  host: localhost
+  debug: true
+  plugins: [auth]
+logging: {level: info}
+`)
+	overlay, _ := Loads(`
+server:
+  host: example.com
+  ~debug: null
+  plugins+: [audit, metrics]
+  tags+: [new]
+~logging:
+cache:
+  ~ttl:
+  sizes+: [1]
+`)
+
+	if err := base.Override(overlay); err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	expected := map[string]interface{}{
+		"server": map[string]interface{}{
+			"host":    "example.com",
+			"plugins": []interface{}{"auth", "audit", "metrics"},
+			"tags":    []interface{}{"new"},
+		},
+		"cache": map[string]interface{}{"sizes": []interface{}{1}},
+	}
+	if !reflect.DeepEqual(base.Raw(), expected) {
+		t.Errorf("Expected %v, got %v", expected, base.Raw())
+	}
+
+	bad, _ := Loads("server:\n  host+: [x]\n")
+	if err := base.Override(bad); !errors.Is(err, ErrNotAnArray) {
+		t.Errorf("Expected ErrNotAnArray, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package easyyaml
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errorLinePattern extracts the line number from yaml.v3 syntax errors
+var errorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// LoadPartial parses YAML like Load, but when the input has a syntax error
+// it also returns the value parsed from the longest leading run of lines that
+// is valid on its own, so editors and linters can still work with the
+// structure of a broken file. The run is found by bisecting the lines before
+// the error, so a large file costs a few parses rather than one per line.
+// The returned error is the original parse error; the value is never nil and
+// is empty if no prefix parses.
+func LoadPartial(yamlBytes []byte, opts ...LoadOption) (*YAMLValue, error) {
+	yv, err := Load(yamlBytes, opts...)
+	if err == nil {
+		return yv, nil
+	}
+	options := loadOptions(opts)
+	normalized, encodingErr := normalizeEncoding(yamlBytes, options.StrictEncoding)
+	if encodingErr != nil {
+		return New(nil), err
+	}
+
+	lines := strings.SplitAfter(string(normalized), "\n")
+	limit := len(lines) - 1
+	if m := errorLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		// The reported line is where the broken token starts, or just past it
+		if line, convErr := strconv.Atoi(m[1]); convErr == nil && line < limit {
+			limit = line
+		}
+	}
+
+	parses := func(n int) bool {
+		stripped, _ := extractDirectives([]byte(strings.Join(lines[:n], "")))
+		var data interface{}
+		return yaml.Unmarshal(stripped, &data) == nil
+	}
+	// The first valid lines parse; find the most that do
+	valid, invalid := 0, limit+1
+	for invalid-valid > 1 {
+		mid := valid + (invalid-valid)/2
+		if parses(mid) {
+			valid = mid
+		} else {
+			invalid = mid
+		}
+	}
+	if valid == 0 {
+		return New(nil), err
+	}
+	partial, partialErr := load([]byte(strings.Join(lines[:valid], "")), options)
+	if partialErr != nil {
+		return New(nil), err
+	}
+	return partial, err
+}
@@ -0,0 +1,51 @@
+package easyyaml
+
+import "testing"
+
+func TestLoadPartial(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(yv *YAMLValue) bool
+	}{
+		{"bad indent", "a: 1\nb:\n  c: 2\n d: 3\n", func(yv *YAMLValue) bool {
+			return yv.Get("a").AsInt() == 1 && yv.Path("b.c").AsInt() == 2
+		}},
+		{"unclosed flow", "a: 1\nb: [1, 2\nc: 3\n", func(yv *YAMLValue) bool {
+			return yv.Get("a").AsInt() == 1 && !yv.Has("b")
+		}},
+		{"unterminated quote", "a: 1\nb: \"open\nc: 3\n", func(yv *YAMLValue) bool {
+			return yv.Get("a").AsInt() == 1
+		}},
+		{"broken first line", "[a, b\n", func(yv *YAMLValue) bool {
+			return yv.IsNull()
+		}},
+	}
+
+	for _, tt := range tests {
+		yv, err := LoadPartial([]byte(tt.input))
+		if err == nil {
+			t.Errorf("%s: expected parse error", tt.name)
+			continue
+		}
+		if yv == nil || !tt.check(yv) {
+			t.Errorf("%s: unexpected partial value %v", tt.name, yv)
+		}
+	}
+
+	yv, err := LoadPartial([]byte("a: 1\n"))
+	if err != nil || yv.Get("a").AsInt() != 1 {
+		t.Errorf("Expected valid input to load normally, got %v", err)
+	}
+}
+
+func TestLoadPartialOptions(t *testing.T) {
+	input := []byte("\xef\xbb\xbfName: app\r\nports: [80\r\n")
+	yv, err := LoadPartial(input, WithCaseInsensitiveKeys())
+	if err == nil {
+		t.Fatal("Expected parse error")
+	}
+	if yv.Get("name").AsString() != "app" {
+		t.Errorf("Expected the prefix decoded and loaded with the options, got %v", yv.Raw())
+	}
+}
@@ -0,0 +1,465 @@
+package easyyaml
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PatchFormat selects the kind of patch GeneratePatch produces
+type PatchFormat int
+
+const (
+	// JSONPatch is an RFC 6902 list of add, remove and replace operations
+	JSONPatch PatchFormat = iota
+	// MergePatch is an RFC 7386 JSON merge patch: changed keys with their new
+	// values and removed keys set to null. Lists are replaced whole.
+	MergePatch
+	// StrategicMerge is a Kubernetes-style strategic merge patch. It is a
+	// merge patch in which lists of objects with a unique "name" are patched
+	// item by item, with "$patch: delete" items for removals. Item order
+	// changes are not recorded.
+	StrategicMerge
+)
+
+// strategicMergeKey is the merge key GeneratePatch uses for lists of objects
+const strategicMergeKey = "name"
+
+// GeneratePatch produces a patch document that turns from into to when
+// applied with ApplyPatch. A null in a merge patch deletes its key, so
+// MergePatch and StrategicMerge return ErrMergePatchNull when to sets a
+// key to null; use JSONPatch for such documents.
+// Usage: patch, err := easyyaml.GeneratePatch(original, edited, easyyaml.MergePatch)
+func GeneratePatch(from, to *YAMLValue, format PatchFormat) (*YAMLValue, error) {
+	a, b := jsonCompatible(from.data), jsonCompatible(to.data)
+	switch format {
+	case JSONPatch:
+		ops := jsonPatchOps(a, b, "", nil)
+		if ops == nil {
+			ops = []interface{}{}
+		}
+		return &YAMLValue{data: ops}, nil
+	case MergePatch, StrategicMerge:
+		if path, ok := mergePatchNull(a, b, format == StrategicMerge, ""); ok {
+			return nil, &OpError{Op: "GeneratePatch", Key: path, Err: ErrMergePatchNull}
+		}
+		patch, _ := mergePatchDiff(a, b, format == StrategicMerge)
+		return &YAMLValue{data: patch}, nil
+	}
+	return nil, fmt.Errorf("unknown patch format %d", format)
+}
+
+// ApplyPatch returns a copy of doc with a patch produced by GeneratePatch,
+// or any patch of that format, applied. JSON patches support the add,
+// remove, replace and test operations. Styles and tags of untouched values
+// are kept, moving with array items when items are added or removed.
+func ApplyPatch(doc, patch *YAMLValue, format PatchFormat) (*YAMLValue, error) {
+	data := deepCopy(doc.data)
+	meta := &docMeta{}
+	if doc.meta != nil {
+		meta.graft(doc.meta, doc.at, "")
+	}
+	switch format {
+	case JSONPatch:
+		for i, op := range patch.AsArray() {
+			var err error
+			if data, err = applyJSONPatchOp(data, op, meta); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+		}
+	case MergePatch:
+		data = applyMergePatch(data, deepCopy(patch.data), meta, "")
+	case StrategicMerge:
+		target := &YAMLValue{data: data, meta: meta}
+		if !patch.IsObject() {
+			return &YAMLValue{data: deepCopy(patch.data), opts: doc.opts}, nil
+		}
+		if !target.IsObject() {
+			target.data = map[string]interface{}{}
+		}
+		if err := target.MergeStrategic(patch, nil); err != nil {
+			return nil, err
+		}
+		data = target.data
+	default:
+		return nil, fmt.Errorf("unknown patch format %d", format)
+	}
+	return &YAMLValue{data: data, opts: doc.opts, directives: doc.directives, meta: meta}, nil
+}
+
+// jsonPatchOps appends the operations turning a into b at pointer to ops
+func jsonPatchOps(a, b interface{}, pointer string, ops []interface{}) []interface{} {
+	if reflect.DeepEqual(a, b) {
+		return ops
+	}
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		for _, k := range sortedKeys(am) {
+			if _, ok := bm[k]; !ok {
+				ops = append(ops, map[string]interface{}{"op": "remove", "path": pointer + "/" + escapePointer(k)})
+			}
+		}
+		for _, k := range sortedKeys(bm) {
+			child := pointer + "/" + escapePointer(k)
+			if av, ok := am[k]; ok {
+				ops = jsonPatchOps(av, bm[k], child, ops)
+			} else {
+				ops = append(ops, map[string]interface{}{"op": "add", "path": child, "value": bm[k]})
+			}
+		}
+		return ops
+	}
+
+	as, aIsList := a.([]interface{})
+	bs, bIsList := b.([]interface{})
+	if aIsList && bIsList {
+		common := len(as)
+		if len(bs) < common {
+			common = len(bs)
+		}
+		for i := 0; i < common; i++ {
+			ops = jsonPatchOps(as[i], bs[i], pointer+"/"+strconv.Itoa(i), ops)
+		}
+		for i := len(as) - 1; i >= len(bs); i-- {
+			ops = append(ops, map[string]interface{}{"op": "remove", "path": pointer + "/" + strconv.Itoa(i)})
+		}
+		for i := len(as); i < len(bs); i++ {
+			ops = append(ops, map[string]interface{}{"op": "add", "path": pointer + "/" + strconv.Itoa(i), "value": bs[i]})
+		}
+		return ops
+	}
+	return append(ops, map[string]interface{}{"op": "replace", "path": pointer, "value": b})
+}
+
+// mergePatchDiff returns the merge patch turning a into b and whether a
+// and b differ
+func mergePatchDiff(a, b interface{}, strategic bool) (interface{}, bool) {
+	if reflect.DeepEqual(a, b) {
+		return map[string]interface{}{}, false
+	}
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if !aIsMap || !bIsMap {
+		if strategic {
+			if patch, ok := strategicListDiff(a, b); ok {
+				// Only the annotation means the items were just reordered
+				return patch, len(patch) > 1
+			}
+		}
+		return b, true
+	}
+
+	patch := make(map[string]interface{})
+	for k := range am {
+		if _, ok := bm[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, bv := range bm {
+		av, ok := am[k]
+		if !ok {
+			patch[k] = bv
+			continue
+		}
+		if child, changed := mergePatchDiff(av, bv, strategic); changed {
+			patch[k] = child
+		}
+	}
+	return patch, true
+}
+
+// mergePatchNull returns the path of a null that the merge patch turning a
+// into b would have to set. Lists are copied whole, so only nulls in maps
+// count, and in strategic patches those of named list items too.
+func mergePatchNull(a, b interface{}, strategic bool, path string) (string, bool) {
+	if strategic {
+		if bs, _, ok := namedItems(b); ok {
+			as, aIndex, _ := namedItems(a)
+			if as == nil {
+				return "", false
+			}
+			for i, item := range bs {
+				var prev interface{}
+				if at, ok := aIndex[fmt.Sprintf("%v", item.(map[string]interface{})[strategicMergeKey])]; ok {
+					prev = as[at]
+				}
+				if p, ok := mergePatchNull(prev, item, true, joinPath(path, strconv.Itoa(i))); ok {
+					return p, true
+				}
+			}
+			return "", false
+		}
+	}
+	bm, ok := b.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	am, _ := a.(map[string]interface{})
+	for k, bv := range bm {
+		av, had := am[k]
+		if bv == nil {
+			if !had || av != nil {
+				return joinPath(path, k), true
+			}
+			continue
+		}
+		if p, ok := mergePatchNull(av, bv, strategic, joinPath(path, k)); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// strategicListDiff patches lists of objects keyed by a unique "name" item
+// by item
+func strategicListDiff(a, b interface{}) ([]interface{}, bool) {
+	as, aIndex, ok := namedItems(a)
+	if !ok {
+		return nil, false
+	}
+	bs, bIndex, ok := namedItems(b)
+	if !ok {
+		return nil, false
+	}
+
+	patch := []interface{}{map[string]interface{}{MergeKeyAnnotation: strategicMergeKey}}
+	for _, item := range bs {
+		m := item.(map[string]interface{})
+		name := m[strategicMergeKey]
+		at, exists := aIndex[fmt.Sprintf("%v", name)]
+		if !exists {
+			patch = append(patch, m)
+			continue
+		}
+		if child, changed := mergePatchDiff(as[at], m, true); changed {
+			child.(map[string]interface{})[strategicMergeKey] = name
+			patch = append(patch, child)
+		}
+	}
+	for _, item := range as {
+		name := item.(map[string]interface{})[strategicMergeKey]
+		if _, kept := bIndex[fmt.Sprintf("%v", name)]; !kept {
+			patch = append(patch, map[string]interface{}{strategicMergeKey: name, PatchDirective: "delete"})
+		}
+	}
+	return patch, true
+}
+
+// namedItems checks that data is a list of objects with unique scalar names
+// and indexes them by name
+func namedItems(data interface{}) ([]interface{}, map[string]int, bool) {
+	items, ok := data.([]interface{})
+	if !ok {
+		return nil, nil, false
+	}
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, nil, false
+		}
+		name, ok := m[strategicMergeKey]
+		if !ok || (&YAMLValue{data: name}).IsObject() || (&YAMLValue{data: name}).IsArray() {
+			return nil, nil, false
+		}
+		id := fmt.Sprintf("%v", name)
+		if _, dup := index[id]; dup {
+			return nil, nil, false
+		}
+		index[id] = i
+	}
+	return items, index, true
+}
+
+// applyMergePatch applies an RFC 7386 merge patch to target, which is at
+// path, forgetting the node details of replaced values in meta. Only the
+// maps along patched keys are changed; a patch key names the target's key
+// with the same text, so "8080" or 8080 both patch an 8080 key.
+func applyMergePatch(target, patch interface{}, meta *docMeta, path string) interface{} {
+	var keys []interface{}
+	values := make(map[interface{}]interface{})
+	switch pm := patch.(type) {
+	case map[string]interface{}:
+		for k, v := range pm {
+			keys = append(keys, k)
+			values[k] = v
+		}
+	case map[interface{}]interface{}:
+		for k, v := range pm {
+			keys = append(keys, k)
+			values[k] = v
+		}
+	default:
+		meta.forget(path)
+		return patch
+	}
+
+	switch target.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+	default:
+		meta.forget(path)
+		target = emptyLike(patch)
+	}
+	for _, pk := range keys {
+		v := values[pk]
+		switch tm := target.(type) {
+		case map[string]interface{}:
+			k := fmt.Sprintf("%v", pk)
+			if v == nil {
+				delete(tm, k)
+				meta.forget(childPath(path, k))
+				continue
+			}
+			tm[k] = applyMergePatch(tm[k], v, meta, childPath(path, k))
+		case map[interface{}]interface{}:
+			k := matchingKey(tm, pk)
+			if v == nil {
+				delete(tm, k)
+				meta.forget(childPath(path, k))
+				continue
+			}
+			tm[k] = applyMergePatch(tm[k], v, meta, childPath(path, k))
+		}
+	}
+	return target
+}
+
+// matchingKey returns the key of m that key refers to: key itself if
+// present, else a key with the same text, else key
+func matchingKey(m map[interface{}]interface{}, key interface{}) interface{} {
+	if _, ok := m[key]; ok {
+		return key
+	}
+	text := fmt.Sprintf("%v", key)
+	for k := range m {
+		if fmt.Sprintf("%v", k) == text {
+			return k
+		}
+	}
+	return key
+}
+
+// applyJSONPatchOp applies one RFC 6902 operation to root, moving the node
+// details in meta along with it
+func applyJSONPatchOp(root interface{}, opValue *YAMLValue, meta *docMeta) (interface{}, error) {
+	op := opValue.Get("op").AsString()
+	tokens := pointerTokens(opValue.Get("path").AsString())
+	value := deepCopy(opValue.Get("value").data)
+
+	switch op {
+	case "test":
+		current, ok := resolveFragment(&YAMLValue{data: root}, opValue.Get("path").AsString())
+		if !ok || !reflect.DeepEqual(jsonCompatible(current.data), jsonCompatible(value)) {
+			return nil, fmt.Errorf("test failed at %q", opValue.Get("path").AsString())
+		}
+		return root, nil
+	case "add", "remove", "replace":
+		return patchAt(root, tokens, op, value, meta, "")
+	}
+	return nil, fmt.Errorf("unsupported operation %q", op)
+}
+
+// patchAt applies op at the location named by tokens within data, which is
+// at path
+func patchAt(data interface{}, tokens []string, op string, value interface{}, meta *docMeta, path string) (interface{}, error) {
+	if len(tokens) == 0 {
+		meta.forget(path)
+		if op == "remove" {
+			return nil, nil
+		}
+		return value, nil
+	}
+	token, rest := tokens[0], tokens[1:]
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		child, exists := v[token]
+		if len(rest) == 0 {
+			switch {
+			case op == "add":
+				v[token] = value
+			case !exists:
+				return nil, opError(op, token, ErrKeyNotFound, "")
+			case op == "remove":
+				delete(v, token)
+			default:
+				v[token] = value
+			}
+			meta.forget(childPath(path, token))
+			return v, nil
+		}
+		if !exists {
+			return nil, opError(op, token, ErrKeyNotFound, "")
+		}
+		updated, err := patchAt(child, rest, op, value, meta, childPath(path, token))
+		if err != nil {
+			return nil, err
+		}
+		v[token] = updated
+		return v, nil
+	case map[interface{}]interface{}:
+		converted := jsonCompatible(v)
+		return patchAt(converted, tokens, op, value, meta, path)
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if token == "-" {
+			index, err = len(v), nil
+		}
+		if err != nil || index < 0 || index > len(v) || (index == len(v) && op != "add") {
+			return nil, opError(op, token, ErrIndexOutOfRange, "")
+		}
+		if len(rest) == 0 {
+			switch op {
+			case "add":
+				meta.reindex(path, inserted(len(v), index))
+				v = append(v, nil)
+				copy(v[index+1:], v[index:])
+				v[index] = value
+			case "remove":
+				meta.reindex(path, without(len(v), index))
+				v = append(v[:index], v[index+1:]...)
+			default:
+				meta.forget(childPath(path, index))
+				v[index] = value
+			}
+			return v, nil
+		}
+		updated, err := patchAt(v[index], rest, op, value, meta, childPath(path, index))
+		if err != nil {
+			return nil, err
+		}
+		v[index] = updated
+		return v, nil
+	}
+	return nil, opError(op, token, ErrTypeMismatch, "cannot index into "+typeName(data))
+}
+
+// pointerTokens splits an RFC 6901 JSON Pointer into unescaped tokens
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	return parts
+}
+
+// escapePointer escapes a key for use as a JSON Pointer token
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
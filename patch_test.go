@@ -0,0 +1,180 @@
+package easyyaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGeneratePatch(t *testing.T) {
+	from, _ := Loads(`
+metadata:
+  name: web
+  labels: {app: web, tier: front}
+spec:
+  replicas: 2
+  containers:
+    - name: app
+      image: app:1
+      ports: [80]
+    - name: sidecar
+      image: proxy:1
+  a/b: 1
+`)
+	to, _ := Loads(`
+metadata:
+  name: web
+  labels: {app: web}
+spec:
+  replicas: 3
+  containers:
+    - name: app
+      image: app:2
+      ports: [80, 443]
+    - name: debug
+      image: busybox
+  a/b: 2
+`)
+
+	for _, format := range []PatchFormat{JSONPatch, MergePatch, StrategicMerge} {
+		patch, err := GeneratePatch(from, to, format)
+		if err != nil {
+			t.Fatalf("GeneratePatch(%d) failed: %v", format, err)
+		}
+		applied, err := ApplyPatch(from, patch, format)
+		if err != nil {
+			t.Fatalf("ApplyPatch(%d) failed: %v", format, err)
+		}
+		if !reflect.DeepEqual(jsonCompatible(applied.Raw()), jsonCompatible(to.Raw())) {
+			patchStr, _ := patch.Dumps()
+			t.Errorf("Format %d: expected %v, got %v with patch:\n%s", format, to.Raw(), applied.Raw(), patchStr)
+		}
+	}
+
+	jsonPatch, _ := GeneratePatch(from, to, JSONPatch)
+	expectedOps := []string{
+		"remove /metadata/labels/tier",
+		"replace /spec/a~1b",
+		"replace /spec/containers/0/image",
+		"add /spec/containers/0/ports/1",
+		"replace /spec/containers/1/image",
+		"replace /spec/containers/1/name",
+		"replace /spec/replicas",
+	}
+	var ops []string
+	for _, op := range jsonPatch.AsArray() {
+		ops = append(ops, op.Get("op").AsString()+" "+op.Get("path").AsString())
+	}
+	if !reflect.DeepEqual(ops, expectedOps) {
+		t.Errorf("Expected %v, got %v", expectedOps, ops)
+	}
+
+	merge, _ := GeneratePatch(from, to, MergePatch)
+	out, _ := merge.WithIndent(2).Dumps()
+	expected := `metadata:
+  labels:
+    tier: null
+spec:
+  a/b: 2
+  containers:
+    - image: app:2
+      name: app
+      ports:
+        - 80
+        - 443
+    - image: busybox
+      name: debug
+  replicas: 3
+`
+	if out != expected {
+		t.Errorf("Expected merge patch:\n%s\ngot:\n%s", expected, out)
+	}
+
+	strategic, _ := GeneratePatch(from, to, StrategicMerge)
+	out, _ = strategic.Path("spec").Get("containers").WithIndent(2).Dumps()
+	expected = `- $mergeKey: name
+- image: app:2
+  name: app
+  ports:
+    - 80
+    - 443
+- image: busybox
+  name: debug
+- $patch: delete
+  name: sidecar
+`
+	if out != expected {
+		t.Errorf("Expected strategic containers patch:\n%s\ngot:\n%s", expected, out)
+	}
+
+	if same, _ := GeneratePatch(from, from, JSONPatch); same.Len() != 0 {
+		t.Errorf("Expected an empty patch, got %v", same.Raw())
+	}
+	if same, _ := GeneratePatch(from, from, MergePatch); same.Len() != 0 {
+		t.Errorf("Expected an empty merge patch, got %v", same.Raw())
+	}
+}
+
+func TestGeneratePatchNull(t *testing.T) {
+	from, _ := Loads("a: 1\nitems:\n  - {name: x, v: 1}\nkeep: ~\nlist: [1]\n")
+	for _, tc := range []struct {
+		to      string
+		format  PatchFormat
+		invalid bool
+	}{
+		{"a: ~\nitems:\n  - {name: x, v: 1}\nkeep: ~\nlist: [1]\n", MergePatch, true},
+		{"a: {b: ~}\nitems:\n  - {name: x, v: 1}\nkeep: ~\nlist: [1]\n", MergePatch, true},
+		{"a: 1\nitems:\n  - {name: x, v: ~}\nkeep: ~\nlist: [1]\n", StrategicMerge, true},
+		{"a: 1\nitems:\n  - {name: x, v: 1}\n  - {name: y, v: ~}\nkeep: ~\nlist: [1]\n", StrategicMerge, true},
+		{"a: 2\nitems:\n  - {name: x, v: 1}\nkeep: ~\nlist: [1, ~]\n", MergePatch, false},
+		{"a: 1\nitems:\n  - {name: x, v: ~}\nkeep: ~\nlist: [1]\n", MergePatch, false},
+	} {
+		to, _ := Loads(tc.to)
+		_, err := GeneratePatch(from, to, tc.format)
+		if tc.invalid != errors.Is(err, ErrMergePatchNull) {
+			t.Errorf("GeneratePatch(%d) to %q: got %v", tc.format, tc.to, err)
+		}
+
+		patch, err := GeneratePatch(from, to, JSONPatch)
+		if err != nil {
+			t.Fatalf("GeneratePatch(JSONPatch) failed: %v", err)
+		}
+		applied, err := ApplyPatch(from, patch, JSONPatch)
+		if err != nil || !reflect.DeepEqual(jsonCompatible(applied.Raw()), jsonCompatible(to.Raw())) {
+			t.Errorf("JSONPatch to %q: got %v, %v", tc.to, applied, err)
+		}
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	doc, _ := Loads("a: [1, 2]\n")
+	patch, _ := Loads("- {op: test, path: /a/0, value: 1}\n- {op: add, path: /a/-, value: 3}\n")
+	out, err := ApplyPatch(doc, patch, JSONPatch)
+	if err != nil || out.Get("a").Len() != 3 {
+		t.Errorf("Expected append, got %v, %v", out, err)
+	}
+
+	for _, bad := range []string{
+		"- {op: test, path: /a/0, value: 5}\n",
+		"- {op: remove, path: /missing}\n",
+		"- {op: replace, path: /a/9, value: 1}\n",
+		"- {op: move, from: /a, path: /b}\n",
+	} {
+		patch, _ := Loads(bad)
+		if _, err := ApplyPatch(doc, patch, JSONPatch); err == nil {
+			t.Errorf("Expected an error for %s", bad)
+		}
+	}
+}
+
+func TestApplyMergePatchKeepsKeyTypes(t *testing.T) {
+	doc, _ := Loads("ports: {8080: web, true: yes}\nname: app\nlimits: {1: a, 2: b}\n")
+	patch, _ := Loads("name: svc\nlimits: {'1': null, 3: c}\n")
+	patched, err := ApplyPatch(doc, patch, MergePatch)
+	if err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if out, _ := patched.Dumps(); out != "limits:\n    2: b\n    3: c\nname: svc\nports:\n    true: \"yes\"\n    8080: web\n" {
+		t.Errorf("Expected untouched keys to keep their types, got %q", out)
+	}
+}
@@ -0,0 +1,63 @@
+package easyyaml
+
+import "sync"
+
+// pathCache memoizes Path lookups for a document. It is shared by every
+// value retrieved from the document and cleared by any mutation through them.
+type pathCache struct {
+	sync.RWMutex
+	owner   *YAMLValue
+	entries map[string]*YAMLValue
+}
+
+// EnablePathCache turns on memoization of Path lookups made on this value.
+// Values retrieved from it with Get, Path or Q share the cache, and mutating
+// any of them clears it. Changes made to the underlying data by other means,
+// such as through Raw or Values, are not seen and may leave stale entries.
+// Usage: cfg.EnablePathCache()
+func (yv *YAMLValue) EnablePathCache() {
+	if yv.cache == nil {
+		yv.cache = &pathCache{owner: yv, entries: make(map[string]*YAMLValue)}
+	}
+}
+
+// DisablePathCache turns off path memoization and drops cached entries
+func (yv *YAMLValue) DisablePathCache() {
+	yv.invalidate()
+	yv.cache = nil
+}
+
+// get returns a copy of the cached result for path, if any
+func (c *pathCache) get(path string) (*YAMLValue, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	entry, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	value := *entry
+	return &value, true
+}
+
+// put stores the result of a Path lookup
+func (c *pathCache) put(path string, value *YAMLValue) {
+	entry := *value
+	c.Lock()
+	defer c.Unlock()
+	c.entries[path] = &entry
+}
+
+// cachesPaths reports whether Path lookups on this value are memoized
+func (yv *YAMLValue) cachesPaths() bool {
+	return yv.cache != nil && yv.cache.owner == yv
+}
+
+// invalidate clears the path cache shared with this value, if any
+func (yv *YAMLValue) invalidate() {
+	if yv.cache == nil {
+		return
+	}
+	yv.cache.Lock()
+	defer yv.cache.Unlock()
+	yv.cache.entries = make(map[string]*YAMLValue)
+}
@@ -0,0 +1,62 @@
+package easyyaml
+
+import "testing"
+
+func TestPathCache(t *testing.T) {
+	yv, err := Loads("server:\n  host: localhost\n  ports: [80, 443]\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	yv.EnablePathCache()
+
+	if yv.Path("server.host").AsString() != "localhost" {
+		t.Fatalf("Expected localhost, got %s", yv.Path("server.host").AsString())
+	}
+	if _, ok := yv.cache.get("server.host"); !ok {
+		t.Fatal("Expected server.host to be cached")
+	}
+
+	// Mutations through a retrieved child clear the shared cache
+	if err := yv.Get("server").Set("host", "example.com"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got := yv.Path("server.host").AsString(); got != "example.com" {
+		t.Errorf("Expected example.com after mutation, got %s", got)
+	}
+
+	if err := yv.SetPath("server.tls", true); err != nil {
+		t.Fatalf("SetPath failed: %v", err)
+	}
+	if !yv.Path("server.tls").AsBool() {
+		t.Error("Expected server.tls after SetPath")
+	}
+
+	// Lookups relative to a child are not cached under the root's keys
+	server := yv.Get("server")
+	if server.Path("host").AsString() != "example.com" {
+		t.Errorf("Expected child lookup to work, got %s", server.Path("host").AsString())
+	}
+	if yv.Path("host").Exists() {
+		t.Error("Expected root lookup of host to be missing")
+	}
+
+	yv.DisablePathCache()
+	if yv.cache != nil {
+		t.Error("Expected cache to be removed")
+	}
+}
+
+func BenchmarkPathCached(b *testing.B) {
+	yv, _ := Loads("a:\n  b:\n    c:\n      d: 1\n")
+	yv.EnablePathCache()
+	for i := 0; i < b.N; i++ {
+		yv.Path("a.b.c.d")
+	}
+}
+
+func BenchmarkPathUncached(b *testing.B) {
+	yv, _ := Loads("a:\n  b:\n    c:\n      d: 1\n")
+	for i := 0; i < b.N; i++ {
+		yv.Path("a.b.c.d")
+	}
+}
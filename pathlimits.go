@@ -0,0 +1,78 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultMaxPathSegments is the default limit on segments in a path
+	DefaultMaxPathSegments = 256
+	// DefaultMaxPathDepth is the default limit on levels SetPath may create
+	DefaultMaxPathDepth = 64
+)
+
+// pathLimits bounds the paths accepted by Path and SetPath
+type pathLimits struct {
+	maxSegments int
+	maxDepth    int
+}
+
+// PathOption adjusts the limits of a single Path or SetPath call
+type PathOption func(*pathLimits)
+
+// MaxPathSegments limits the number of segments a path may have. A value of
+// 0 or less removes the limit.
+// Usage: data.SetPath(userPath, value, easyyaml.MaxPathSegments(8))
+func MaxPathSegments(n int) PathOption {
+	return func(l *pathLimits) {
+		l.maxSegments = n
+	}
+}
+
+// MaxPathDepth limits how many nested objects or arrays SetPath may create
+// for missing segments. A value of 0 or less removes the limit.
+func MaxPathDepth(n int) PathOption {
+	return func(l *pathLimits) {
+		l.maxDepth = n
+	}
+}
+
+// resolvePathLimits applies opts to the default limits
+func resolvePathLimits(opts []PathOption) pathLimits {
+	limits := pathLimits{maxSegments: DefaultMaxPathSegments, maxDepth: DefaultMaxPathDepth}
+	for _, opt := range opts {
+		opt(&limits)
+	}
+	return limits
+}
+
+// checkSegments rejects paths with more segments than allowed
+func (l pathLimits) checkSegments(path string) error {
+	if l.maxSegments <= 0 {
+		return nil
+	}
+	if n := strings.Count(path, ".") + 1; n > l.maxSegments {
+		return fmt.Errorf("%w: %d segments, limit is %d", ErrPathTooLong, n, l.maxSegments)
+	}
+	return nil
+}
+
+// countSegments counts the non-empty path segments in parts
+func countSegments(parts []string) int {
+	n := 0
+	for _, part := range parts {
+		if part != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// checkDepth rejects creating more nested levels than allowed
+func (l pathLimits) checkDepth(created int) error {
+	if l.maxDepth > 0 && created > l.maxDepth {
+		return fmt.Errorf("%w: limit is %d", ErrPathTooDeep, l.maxDepth)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetPathLimits(t *testing.T) {
+	obj := NewObject()
+
+	long := strings.Repeat("a.", DefaultMaxPathSegments) + "z"
+	if err := obj.SetPath(long, 1); !errors.Is(err, ErrPathTooLong) {
+		t.Errorf("Expected ErrPathTooLong, got %v", err)
+	}
+
+	deep := strings.Repeat("a.", DefaultMaxPathDepth+1) + "z"
+	if err := obj.SetPath(deep, 1); !errors.Is(err, ErrPathTooDeep) {
+		t.Errorf("Expected ErrPathTooDeep, got %v", err)
+	}
+	if obj.Len() != 0 {
+		t.Errorf("Expected nothing to be created on error, got %v", obj.Raw())
+	}
+
+	if err := obj.SetPath("a.b.c", 1, MaxPathDepth(1)); !errors.Is(err, ErrPathTooDeep) {
+		t.Errorf("Expected ErrPathTooDeep with per-call limit, got %v", err)
+	}
+	if err := obj.SetPath("a.b.c", 1, MaxPathSegments(2)); !errors.Is(err, ErrPathTooLong) {
+		t.Errorf("Expected ErrPathTooLong with per-call limit, got %v", err)
+	}
+
+	// Existing levels do not count towards the depth limit
+	if err := obj.SetPath("a.b", 1, MaxPathDepth(1)); err != nil {
+		t.Fatalf("SetPath failed: %v", err)
+	}
+	obj.SetPath("a.b", map[string]interface{}{})
+	if err := obj.SetPath("a.b.c.d", 1, MaxPathDepth(1)); err != nil {
+		t.Errorf("Expected existing levels to be free, got %v", err)
+	}
+
+	if err := obj.SetPath(deep, 1, MaxPathDepth(0)); err != nil {
+		t.Errorf("Expected no depth limit, got %v", err)
+	}
+}
+
+func TestPathLimits(t *testing.T) {
+	yv, err := Loads("a:\n  b:\n    c: 1\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+	if yv.Path("a.b.c").AsInt() != 1 {
+		t.Error("Expected a.b.c to resolve")
+	}
+	if yv.Path("a.b.c", MaxPathSegments(2)).Exists() {
+		t.Error("Expected path over the segment limit to be missing")
+	}
+}
@@ -0,0 +1,79 @@
+package easyyaml
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Placeholder is a template variable found in a scalar value
+type Placeholder struct {
+	// Path is the dot-separated path of the scalar containing it
+	Path string
+	// Name is the variable name, e.g. "DB_HOST" or ".Values.image"
+	Name string
+	// Default is the fallback given with ${NAME:-default}
+	Default    string
+	HasDefault bool
+	// Raw is the placeholder as written, e.g. "${DB_HOST}"
+	Raw string
+}
+
+var placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.]*)(?:(:?-)([^}]*))?\}|\{\{-?\s*([.$]?[A-Za-z_][A-Za-z0-9_.]*)\s*-?\}\}`)
+
+// ExtractPlaceholders finds the ${VAR}, ${VAR:-default} and {{ .Var }}
+// placeholders in every string scalar of doc, in path order
+// Usage: for _, p := range easyyaml.ExtractPlaceholders(doc) { fmt.Println(p.Path, p.Name) }
+func ExtractPlaceholders(doc *YAMLValue) []Placeholder {
+	var placeholders []Placeholder
+	doc.walk("", func(path string, value *YAMLValue) {
+		str, ok := value.data.(string)
+		if !ok || !strings.ContainsAny(str, "${") {
+			return
+		}
+		for _, m := range placeholderPattern.FindAllStringSubmatch(str, -1) {
+			p := Placeholder{Path: path, Name: m[1], Raw: m[0]}
+			if m[4] != "" {
+				p.Name = m[4]
+			}
+			if m[2] != "" {
+				p.Default, p.HasDefault = m[3], true
+			}
+			placeholders = append(placeholders, p)
+		}
+	})
+	return placeholders
+}
+
+// RequiredVariables returns the sorted names of the placeholders in doc
+// that have no default
+func RequiredVariables(doc *YAMLValue) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, p := range ExtractPlaceholders(doc) {
+		if !p.HasDefault && !seen[p.Name] {
+			seen[p.Name] = true
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckPlaceholders reports every placeholder without a default that lookup
+// cannot resolve, with its path, as a *MultiError wrapping
+// ErrUndefinedVariable
+// Usage: err := easyyaml.CheckPlaceholders(doc, os.LookupEnv)
+func CheckPlaceholders(doc *YAMLValue, lookup func(name string) (string, bool)) error {
+	c := &ErrorCollector{}
+	for _, p := range ExtractPlaceholders(doc) {
+		if p.HasDefault {
+			continue
+		}
+		if _, ok := lookup(p.Name); !ok {
+			c.Add("placeholder", p.Path, fmt.Errorf("%w: %s", ErrUndefinedVariable, p.Name))
+		}
+	}
+	return c.Err()
+}
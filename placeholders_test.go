@@ -0,0 +1,49 @@
+package easyyaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExtractPlaceholders(t *testing.T) {
+	doc, _ := Loads(`
+database:
+  url: postgres://${DB_USER}:${DB_PASS}@${DB_HOST:-localhost}/app
+  pool: ${POOL-10}
+image: "{{ .Values.image }}:{{.Values.tag}}"
+replicas: 3
+notes: costs $5 and {braces}
+`)
+
+	got := ExtractPlaceholders(doc)
+	expected := []Placeholder{
+		{Path: "database.pool", Name: "POOL", Default: "10", HasDefault: true, Raw: "${POOL-10}"},
+		{Path: "database.url", Name: "DB_USER", Raw: "${DB_USER}"},
+		{Path: "database.url", Name: "DB_PASS", Raw: "${DB_PASS}"},
+		{Path: "database.url", Name: "DB_HOST", Default: "localhost", HasDefault: true, Raw: "${DB_HOST:-localhost}"},
+		{Path: "image", Name: ".Values.image", Raw: "{{ .Values.image }}"},
+		{Path: "image", Name: ".Values.tag", Raw: "{{.Values.tag}}"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	required := RequiredVariables(doc)
+	if !reflect.DeepEqual(required, []string{".Values.image", ".Values.tag", "DB_PASS", "DB_USER"}) {
+		t.Errorf("Unexpected required variables: %v", required)
+	}
+
+	env := map[string]string{"DB_USER": "app", ".Values.image": "x", ".Values.tag": "1"}
+	err := CheckPlaceholders(doc, func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	})
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 1 || !errors.Is(err, ErrUndefinedVariable) {
+		t.Fatalf("Expected one undefined variable, got %v", err)
+	}
+	if msg := err.Error(); msg != "placeholder database.url: undefined variable: DB_PASS" {
+		t.Errorf("Unexpected error message: %q", msg)
+	}
+}
@@ -0,0 +1,152 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pointer retrieves a nested value using an RFC 6901 JSON Pointer such as
+// "/contact/email" or "/items/0", with "~1" for "/" and "~0" for "~" in
+// keys. "" is the whole document and "/" the key "". Keys that are not
+// strings are matched by their text, so "/ports/9090" finds a 9090 key. A
+// pointer that does not resolve, or does not start with "/", gives a
+// missing value.
+// Usage: email := doc.Pointer("/contact/email").AsString()
+func (yv *YAMLValue) Pointer(pointer string) *YAMLValue {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return missingValue()
+	}
+	current := yv
+	for _, token := range pointerTokens(pointer) {
+		key, ok := pointerKey(current, token)
+		if !ok {
+			return missingValue()
+		}
+		next, ok := current.Lookup(key)
+		if !ok {
+			return missingValue()
+		}
+		current = next
+	}
+	return current
+}
+
+// SetPointer sets the value an RFC 6901 JSON Pointer refers to, as the
+// "add" operation of a JSON Patch would for objects: the parent must exist,
+// an object key is added or replaced, an array index is replaced and "-"
+// appends to an array. "" replaces the whole document. Errors wrap
+// ErrKeyNotFound, ErrIndexOutOfRange or ErrTypeMismatch with the failing
+// pointer prefix.
+// Usage: err := doc.SetPointer("/paths/~1users/get/summary", "List users")
+func (yv *YAMLValue) SetPointer(pointer string, value interface{}) error {
+	logMutation("set pointer", "pointer", pointer)
+	if err := yv.checkFrozen("set pointer", pointer); err != nil {
+		return err
+	}
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", pointer)
+	}
+	tokens := pointerTokens(pointer)
+	if len(tokens) == 0 {
+		data, err := normalize(value)
+		if err != nil {
+			return err
+		}
+		yv.invalidate()
+		yv.meta.forget(yv.at)
+		if src, ok := value.(*YAMLValue); ok && yv.meta != nil && src.meta != nil && src.meta != yv.meta {
+			yv.meta.graft(src.meta, src.at, yv.at)
+		}
+		yv.data = data
+		return nil
+	}
+
+	parent := yv
+	prefix := ""
+	for _, token := range tokens[:len(tokens)-1] {
+		prefix += "/" + escapePointer(token)
+		key, ok := pointerKey(parent, token)
+		if !ok {
+			return pointerError(parent, prefix, token)
+		}
+		next, ok := parent.Lookup(key)
+		if !ok {
+			return pointerError(parent, prefix, token)
+		}
+		parent = next
+	}
+
+	last := tokens[len(tokens)-1]
+	switch {
+	case parent.IsObject():
+		key, _ := pointerKey(parent, last)
+		return parent.set(key, value)
+	case parent.IsArray() && last == "-":
+		arr := parent.data.([]interface{})
+		parent.replaceData(append(arr, nil))
+		if err := parent.set(len(arr), value); err != nil {
+			parent.replaceData(arr)
+			return err
+		}
+		return nil
+	case parent.IsArray():
+		index, ok := pointerKey(parent, last)
+		if !ok || index.(int) >= parent.Len() {
+			return opError("set pointer", pointer, ErrIndexOutOfRange, "")
+		}
+		return parent.set(index, value)
+	}
+	return opError("set pointer", pointer, ErrTypeMismatch, "cannot index "+typeName(parent.data))
+}
+
+// replaceData sets the value's data, storing it in the parent the value
+// was reached through as well, for an array that was replaced rather than
+// changed in place
+func (yv *YAMLValue) replaceData(data interface{}) {
+	yv.data = data
+	if yv.parent == nil {
+		return
+	}
+	switch container := yv.parent.data.(type) {
+	case map[string]interface{}:
+		if k, ok := yv.key.(string); ok {
+			container[k] = data
+		}
+	case map[interface{}]interface{}:
+		container[yv.key] = data
+	case []interface{}:
+		if i, ok := yv.key.(int); ok && i >= 0 && i < len(container) {
+			container[i] = data
+		}
+	}
+}
+
+// pointerKey converts a pointer token to the key it names in current: an
+// index for arrays, which must be decimal digits without leading zeros,
+// the key with the same text for objects with non-string keys, so "9090"
+// names a 9090 key, and the token itself otherwise
+func pointerKey(current *YAMLValue, token string) (interface{}, bool) {
+	if m, ok := current.data.(map[interface{}]interface{}); ok {
+		return matchingKey(m, token), true
+	}
+	if !current.IsArray() {
+		return token, true
+	}
+	if token == "" || len(token) > 1 && token[0] == '0' || strings.TrimLeft(token, "0123456789") != "" {
+		return nil, false
+	}
+	index, err := strconv.Atoi(token)
+	return index, err == nil
+}
+
+// pointerError explains why token did not resolve in current
+func pointerError(current *YAMLValue, prefix, token string) error {
+	switch {
+	case current.IsArray():
+		return opError("set pointer", prefix, ErrIndexOutOfRange, "")
+	case !current.IsObject():
+		return opError("set pointer", prefix, ErrTypeMismatch, "cannot index "+typeName(current.data))
+	}
+	return opError("set pointer", prefix, ErrKeyNotFound, "")
+}
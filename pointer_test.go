@@ -0,0 +1,92 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPointer(t *testing.T) {
+	yv, _ := Loads(`contact:
+  email: a@example.com
+paths:
+  /users:
+    get: {summary: List}
+"a~b": tilde
+"": empty
+items: [x, y]
+`)
+	cases := map[string]interface{}{
+		"/contact/email":             "a@example.com",
+		"/paths/~1users/get/summary": "List",
+		"/a~0b":                      "tilde",
+		"/":                          "empty",
+		"/items/1":                   "y",
+	}
+	for pointer, expected := range cases {
+		if got := yv.Pointer(pointer).Raw(); got != expected {
+			t.Errorf("%s: expected %v, got %v", pointer, expected, got)
+		}
+	}
+	if yv.Pointer("").Len() != 5 {
+		t.Error("Expected empty pointer to be the whole document")
+	}
+	for _, pointer := range []string{"contact/email", "/items/01", "/items/-", "/items/2", "/missing/x", "/contact/email/x"} {
+		if yv.Pointer(pointer).Exists() {
+			t.Errorf("%s: expected a missing value", pointer)
+		}
+	}
+}
+
+func TestPointerNonStringKeys(t *testing.T) {
+	yv, _ := Loads("ports:\n  9090: web\n  true: flag\n")
+	if got := yv.Pointer("/ports/9090").AsString(); got != "web" {
+		t.Errorf("Expected int key found by its text, got %q", got)
+	}
+	if err := yv.SetPointer("/ports/9090", "api"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if yv.Get("ports").Len() != 2 || yv.Get("ports").Get(9090).AsString() != "api" {
+		t.Errorf("Expected the int key replaced, got %v", yv.Get("ports").Raw())
+	}
+	if yv.Pointer("/ports/true").AsString() != "flag" {
+		t.Errorf("Expected bool key found by its text, got %v", yv.Get("ports").Raw())
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	yv, _ := Loads("paths: {}\nitems: [x]\nname: app\n")
+	if err := yv.SetPointer("/paths/~1users", map[string]interface{}{"get": "list"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := yv.SetPointer("/items/-", "y"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := yv.SetPointer("/items/0", "w"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if yv.Path("paths./users.get").AsString() != "list" {
+		t.Errorf("Expected key with slash to be set: %s", yv)
+	}
+	if items := yv.Get("items"); items.Len() != 2 || items.Get(0).AsString() != "w" || items.Get(1).AsString() != "y" {
+		t.Errorf("Unexpected items: %v", items.Raw())
+	}
+
+	errs := map[string]error{
+		"/missing/key": ErrKeyNotFound,
+		"/items/5":     ErrIndexOutOfRange,
+		"/items/01":    ErrIndexOutOfRange,
+		"/name/x":      ErrTypeMismatch,
+	}
+	for pointer, expected := range errs {
+		if err := yv.SetPointer(pointer, 1); !errors.Is(err, expected) {
+			t.Errorf("%s: expected %v, got %v", pointer, expected, err)
+		}
+	}
+	if err := yv.SetPointer("name", 1); err == nil {
+		t.Error("Expected error for a pointer without a leading slash")
+	}
+
+	if err := yv.SetPointer("", []interface{}{1}); err != nil || yv.Pointer("/0").AsInt() != 1 {
+		t.Errorf("Expected document to be replaced, got %v, %v", yv.Raw(), err)
+	}
+}
@@ -0,0 +1,135 @@
+package easyyaml
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PollURL fetches an http or https URL every interval until ctx is done,
+// calling onChange with the first document and then only when the parsed
+// document changes structurally, including changes of type such as 1 to
+// 1.0, so reformatting or a new ETag alone does not trigger it. Requests
+// are conditional on the last ETag and Last-Modified, and use the client
+// and headers of the HTTPFetcher registered for the scheme. The first
+// fetch must succeed; later failures are logged and retried at the next
+// tick. interval must be positive. It returns ctx.Err() when ctx is done.
+// Usage: go easyyaml.PollURL(ctx, "https://config.internal/app.yaml", 30*time.Second, func(cfg *easyyaml.YAMLValue) { current.Store(cfg) })
+func PollURL(ctx context.Context, location string, interval time.Duration, onChange func(*YAMLValue), opts ...LoadOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid poll interval %v: must be positive", interval)
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", location, err)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return fmt.Errorf("%w: PollURL needs http or https, got %q", ErrNoFetcher, u.Scheme)
+	}
+	fetcher := &HTTPFetcher{}
+	fetchersMu.RLock()
+	if registered, ok := fetchers[scheme].(*HTTPFetcher); ok {
+		fetcher = registered
+	}
+	fetchersMu.RUnlock()
+
+	p := &poller{fetcher: fetcher, location: location, opts: opts}
+	doc, err := p.poll(ctx)
+	if err != nil {
+		return err
+	}
+	onChange(doc)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			doc, err := p.poll(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logError("easyyaml: poll failed", err, "location", location)
+				}
+				continue
+			}
+			if doc != nil {
+				onChange(doc)
+			}
+		}
+	}
+}
+
+// poller keeps the validators and structural hash of the last document
+type poller struct {
+	fetcher      *HTTPFetcher
+	location     string
+	opts         []LoadOption
+	etag         string
+	lastModified string
+	hash         [sha256.Size]byte
+}
+
+// poll fetches the URL and returns the document, or nil if it is unchanged
+func (p *poller) poll(ctx context.Context) (*YAMLValue, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.location, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range p.fetcher.Header {
+		req.Header[name] = values
+	}
+	if p.etag != "" {
+		req.Header.Set("If-None-Match", p.etag)
+	}
+	if p.lastModified != "" {
+		req.Header.Set("If-Modified-Since", p.lastModified)
+	}
+	client := p.fetcher.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", p.location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", p.location, resp.Status)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", p.location, err)
+	}
+	yamlBytes, err := decompress(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", p.location, err)
+	}
+	doc, err := LoadContext(ctx, yamlBytes, append(p.opts, WithSourceName(p.location))...)
+	if err != nil {
+		return nil, err
+	}
+	canonical, err := doc.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	p.etag = resp.Header.Get("ETag")
+	p.lastModified = resp.Header.Get("Last-Modified")
+	hash := sha256.Sum256(canonical)
+	if hash == p.hash {
+		return nil, nil
+	}
+	p.hash = hash
+	return doc, nil
+}
@@ -0,0 +1,80 @@
+package easyyaml
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPollURL(t *testing.T) {
+	var mu sync.Mutex
+	versions := []string{"a: 1\nb: 2\n", "b: 2\na: 1\n", "b: 2\na: 1\n", "a: 1.0\nb: 2\n"}
+	requests, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		i := min(requests, len(versions)-1)
+		requests++
+		etag := `"v` + string(rune('0'+i)) + `"`
+		if i == 2 && r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if i == 2 {
+			etag = `"v1"`
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(versions[i]))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan *YAMLValue, 10)
+	done := make(chan error)
+	go func() {
+		done <- PollURL(ctx, server.URL, 5*time.Millisecond, func(doc *YAMLValue) { changes <- doc })
+	}()
+
+	first := <-changes
+	if first.Get("a").AsInt() != 1 {
+		t.Errorf("Unexpected first document: %v", first.Raw())
+	}
+	select {
+	case second := <-changes:
+		if second.Get("a").Raw() != 1.0 {
+			t.Errorf("Expected only the change of type reported, got %v", second.Raw())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a change")
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if notModified != 1 {
+		t.Errorf("Expected one conditional request answered 304, got %d", notModified)
+	}
+}
+
+func TestPollURLErrors(t *testing.T) {
+	if err := PollURL(context.Background(), "file:///tmp/x.yaml", time.Second, func(*YAMLValue) {}); err == nil {
+		t.Error("Expected an error for a non-http URL")
+	}
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	if err := PollURL(context.Background(), server.URL, time.Second, func(*YAMLValue) {}); err == nil {
+		t.Error("Expected the first fetch to fail")
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := PollURL(context.Background(), server.URL, interval, func(*YAMLValue) {}); err == nil || !strings.Contains(err.Error(), "interval") {
+			t.Errorf("Expected an error for interval %v, got %v", interval, err)
+		}
+	}
+}
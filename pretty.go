@@ -0,0 +1,201 @@
+package easyyaml
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ANSI color codes used by Pretty
+const (
+	colorReset   = "\x1b[0m"
+	colorKey     = "\x1b[34m"
+	colorString  = "\x1b[32m"
+	colorNumber  = "\x1b[33m"
+	colorLiteral = "\x1b[35m"
+	colorComment = "\x1b[90m"
+)
+
+// PrettyOptions controls the output of Pretty
+type PrettyOptions struct {
+	// Color enables ANSI syntax highlighting
+	Color bool
+	// Indent is the number of spaces per nesting level (default 2)
+	Indent int
+}
+
+// Pretty writes the value as indented YAML to w, optionally highlighting
+// keys, strings, numbers, literals and comments in distinct colors. Styles
+// and tags are kept as Dump keeps them; comments are kept for a document
+// loaded WithFidelity that has not been changed since.
+func (yv *YAMLValue) Pretty(w io.Writer, opts PrettyOptions) error {
+	indent := opts.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+
+	var out []byte
+	var err error
+	if yv.source != nil && yv.source.unchanged(yv) {
+		// Re-indent the loaded text rather than the data, which has no comments
+		out, err = Format(yv.source.raw, FormatOptions{Indent: indent, KeepQuotes: true})
+	} else {
+		dumpOpts := yv.options()
+		dumpOpts.Indent = indent
+		out, err = yv.marshalStyled(dumpOpts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	if !opts.Color {
+		_, err := w.Write(out)
+		return err
+	}
+
+	_, err = io.WriteString(w, colorizeYAML(string(out)))
+	return err
+}
+
+// colorizeYAML adds ANSI colors to block-style YAML text line by line
+func colorizeYAML(text string) string {
+	var sb strings.Builder
+	blockIndent := -1
+
+	lines := strings.SplitAfter(text, "\n")
+	for _, line := range lines {
+		content := strings.TrimRight(line, "\n")
+		newline := line[len(content):]
+		trimmed := strings.TrimLeft(content, " ")
+		indent := len(content) - len(trimmed)
+
+		// Lines inside a block scalar are string content
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				sb.WriteString(colorString + content + colorReset + newline)
+				continue
+			}
+			blockIndent = -1
+		}
+
+		if trimmed == "" {
+			sb.WriteString(line)
+			continue
+		}
+
+		sb.WriteString(content[:indent])
+		rest := trimmed
+		for strings.HasPrefix(rest, "- ") || rest == "-" {
+			sb.WriteString(rest[:1])
+			rest = strings.TrimPrefix(rest[1:], " ")
+			if rest != "" {
+				sb.WriteString(" ")
+			}
+		}
+
+		if strings.HasPrefix(rest, "#") {
+			sb.WriteString(colorComment + rest + colorReset + newline)
+			continue
+		}
+
+		value := rest
+		if keyEnd := findKeyEnd(rest); keyEnd >= 0 {
+			sb.WriteString(colorKey + rest[:keyEnd] + colorReset + ":")
+			value = strings.TrimLeft(rest[keyEnd+1:], " ")
+			if value != "" {
+				sb.WriteString(" ")
+			}
+		}
+
+		value, comment := splitComment(value)
+		if isBlockIndicator(value) {
+			blockIndent = indent
+			sb.WriteString(value)
+		} else if value != "" {
+			sb.WriteString(colorScalar(value))
+		}
+		if comment != "" {
+			sb.WriteString(colorComment + comment + colorReset)
+		}
+		sb.WriteString(newline)
+	}
+	return sb.String()
+}
+
+// findKeyEnd returns the index of the colon ending a mapping key, or -1
+func findKeyEnd(s string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 {
+				quote = c
+			}
+		case c == '[' || c == '{':
+			if i == 0 {
+				return -1
+			}
+		case c == ':':
+			if i+1 == len(s) || s[i+1] == ' ' {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitComment separates a trailing " # comment" from a scalar
+func splitComment(s string) (string, string) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == '#' && i > 0 && s[i-1] == ' ':
+			return strings.TrimRight(s[:i], " "), s[i-1:]
+		}
+	}
+	return s, ""
+}
+
+// isBlockIndicator checks for a literal or folded block scalar header
+func isBlockIndicator(s string) bool {
+	return s != "" && (s[0] == '|' || s[0] == '>') && len(s) <= 3
+}
+
+// colorScalar colors a scalar according to the type it resolves to
+func colorScalar(s string) string {
+	if s[0] == '"' || s[0] == '\'' {
+		return colorString + s + colorReset
+	}
+	if s[0] == '[' || s[0] == '{' || s[0] == '&' || s[0] == '*' || s[0] == '!' {
+		return s
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	switch v.(type) {
+	case nil, bool:
+		return colorLiteral + s + colorReset
+	case string:
+		return colorString + s + colorReset
+	}
+	if _, ok := toFloat(v); ok {
+		return colorNumber + s + colorReset
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package easyyaml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrettyPlain(t *testing.T) {
+	yv, err := Loads(testYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := yv.Pretty(&buf, PrettyOptions{Indent: 4}); err != nil {
+		t.Fatalf("Failed to pretty print: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "\n    city: New York\n") {
+		t.Errorf("Expected 4-space indentation, got:\n%s", buf.String())
+	}
+
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Error("Expected no color codes without Color option")
+	}
+}
+
+func TestPrettyColor(t *testing.T) {
+	yv, err := Loads("name: app\nport: 8080\nenabled: true\ntags:\n  - web\nnotes: |\n  line one\n  line two\n")
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := yv.Pretty(&buf, PrettyOptions{Color: true}); err != nil {
+		t.Fatalf("Failed to pretty print: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		colorKey + "name" + colorReset + ": " + colorString + "app" + colorReset,
+		colorKey + "port" + colorReset + ": " + colorNumber + "8080" + colorReset,
+		colorKey + "enabled" + colorReset + ": " + colorLiteral + "true" + colorReset,
+		"  - " + colorString + "web" + colorReset,
+		colorString + "  line two" + colorReset,
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("Expected output to contain %q, got:\n%q", e, out)
+		}
+	}
+}
+
+func TestPrettyKeepsDetails(t *testing.T) {
+	yv, _ := Loads("ref: !Ref svc\nport: '8080'\n")
+	var buf bytes.Buffer
+	if err := yv.Pretty(&buf, PrettyOptions{}); err != nil {
+		t.Fatalf("Failed to pretty print: %v", err)
+	}
+	if out := buf.String(); out != "port: '8080'\nref: !Ref svc\n" {
+		t.Errorf("Expected styles and tags to be kept, got %q", out)
+	}
+
+	faithful, _ := Loads("# config\nserver:\n    host: x # primary\n", WithFidelity())
+	buf.Reset()
+	faithful.Pretty(&buf, PrettyOptions{Color: true})
+	if out := buf.String(); !strings.Contains(out, colorComment+"# config"+colorReset) || !strings.Contains(out, "\n  "+colorKey+"host") {
+		t.Errorf("Expected comments to be kept and re-indented, got %q", out)
+	}
+}
+
+func TestColorizeComments(t *testing.T) {
+	out := colorizeYAML("# header\nkey: value # trailing\n")
+	if !strings.Contains(out, colorComment+"# header"+colorReset) {
+		t.Errorf("Expected header comment to be colored, got %q", out)
+	}
+	if !strings.Contains(out, colorComment+" # trailing"+colorReset) {
+		t.Errorf("Expected trailing comment to be colored, got %q", out)
+	}
+}
@@ -0,0 +1,264 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// MatchType is the operator of a label matcher
+type MatchType string
+
+const (
+	// MatchEqual matches a label equal to the value
+	MatchEqual MatchType = "="
+	// MatchNotEqual matches a label not equal to the value
+	MatchNotEqual MatchType = "!="
+	// MatchRegexp matches a label the regular expression matches
+	MatchRegexp MatchType = "=~"
+	// MatchNotRegexp matches a label the regular expression does not match
+	MatchNotRegexp MatchType = "!~"
+)
+
+// Matcher is an Alertmanager label matcher, e.g. severity=~"critical|page"
+type Matcher struct {
+	Name  string
+	Type  MatchType
+	Value string
+	re    *regexp.Regexp
+}
+
+// matcherPattern splits a matcher into its name, operator and value
+var matcherPattern = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(=~|!~|!=|=)\s*(.*?)\s*$`)
+
+// ParseMatcher parses a matcher such as `env="prod"`, `env!=dev` or
+// `severity=~"critical|page"`. Regular expressions are anchored at both
+// ends, as in Alertmanager.
+func ParseMatcher(s string) (Matcher, error) {
+	m := matcherPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Matcher{}, fmt.Errorf("%w: invalid matcher %q", easyyaml.ErrSchemaViolation, s)
+	}
+	value := m[3]
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return Matcher{}, fmt.Errorf("%w: invalid matcher %q: %v", easyyaml.ErrSchemaViolation, s, err)
+		}
+		value = unquoted
+	}
+	return newMatcher(m[1], MatchType(m[2]), value)
+}
+
+// newMatcher builds a matcher, compiling regular expressions
+func newMatcher(name string, typ MatchType, value string) (Matcher, error) {
+	matcher := Matcher{Name: name, Type: typ, Value: value}
+	if typ == MatchRegexp || typ == MatchNotRegexp {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return Matcher{}, fmt.Errorf("%w: invalid regular expression in %s: %v", easyyaml.ErrSchemaViolation, matcher, err)
+		}
+		matcher.re = re
+	}
+	return matcher, nil
+}
+
+// String formats the matcher as name, operator and quoted value
+func (m Matcher) String() string {
+	return m.Name + string(m.Type) + strconv.Quote(m.Value)
+}
+
+// Matches reports whether a label set satisfies the matcher. A missing
+// label matches as the empty string.
+func (m Matcher) Matches(labels map[string]string) bool {
+	value := labels[m.Name]
+	switch m.Type {
+	case MatchEqual:
+		return value == m.Value
+	case MatchNotEqual:
+		return value != m.Value
+	case MatchRegexp:
+		return m.re.MatchString(value)
+	case MatchNotRegexp:
+		return !m.re.MatchString(value)
+	}
+	return false
+}
+
+// Route is a node of an Alertmanager route tree. Fields a child route does
+// not set are inherited from its parent, except Matchers and Continue.
+type Route struct {
+	Receiver       string
+	GroupBy        []string
+	Matchers       []Matcher
+	Continue       bool
+	GroupWait      time.Duration
+	GroupInterval  time.Duration
+	RepeatInterval time.Duration
+	Routes         []*Route
+	// Path is the route's path in the document, e.g. "route.routes.1"
+	Path string
+}
+
+// Alertmanager is a parsed Alertmanager configuration
+type Alertmanager struct {
+	Route *Route
+	// Receivers lists the names of the configured receivers
+	Receivers []string
+	// Config is the whole configuration
+	Config *easyyaml.YAMLValue
+}
+
+// LoadAlertmanager reads an Alertmanager configuration file, as
+// ParseAlertmanager
+// Usage: am, err := prometheus.LoadAlertmanager("alertmanager.yml")
+func LoadAlertmanager(filename string) (*Alertmanager, error) {
+	doc, err := easyyaml.LoadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAlertmanager(doc)
+}
+
+// ParseAlertmanager parses the route tree and receivers of an Alertmanager
+// configuration. The legacy match and match_re maps are converted to
+// matchers. It checks that the root route has a receiver, that every
+// receiver a route names is defined, that receiver names are unique and
+// that matchers and durations parse. Every problem is returned in a
+// *easyyaml.MultiError, each wrapping easyyaml.ErrSchemaViolation with its
+// path.
+func ParseAlertmanager(doc *easyyaml.YAMLValue) (*Alertmanager, error) {
+	c := &easyyaml.ErrorCollector{}
+	violation := func(path, format string, args ...interface{}) {
+		c.Add("validate", path, fmt.Errorf("%w: %s", easyyaml.ErrSchemaViolation, fmt.Sprintf(format, args...)))
+	}
+
+	am := &Alertmanager{Config: doc}
+	defined := make(map[string]bool)
+	for i, r := range doc.Get("receivers").AsArray() {
+		name := r.Get("name").AsString()
+		if defined[name] {
+			violation("receivers."+strconv.Itoa(i), "receiver %q is defined more than once", name)
+		}
+		defined[name] = true
+		am.Receivers = append(am.Receivers, name)
+	}
+
+	root, ok := doc.Lookup("route")
+	if !ok || !root.IsObject() {
+		violation("route", "route is required")
+		return am, c.Err()
+	}
+	am.Route = parseRoute(root, "route", &Route{}, c)
+	if am.Route.Receiver == "" {
+		violation("route", "the root route needs a receiver")
+	}
+	am.Route.Walk(func(r *Route) {
+		if doc.Path(r.Path+".receiver").Exists() && !defined[r.Receiver] {
+			violation(r.Path, "receiver %q is not defined", r.Receiver)
+		}
+	})
+	return am, c.Err()
+}
+
+// parseRoute parses a route and its children, inheriting from parent
+func parseRoute(v *easyyaml.YAMLValue, path string, parent *Route, c *easyyaml.ErrorCollector) *Route {
+	route := &Route{
+		Receiver:       parent.Receiver,
+		GroupBy:        parent.GroupBy,
+		GroupWait:      parent.GroupWait,
+		GroupInterval:  parent.GroupInterval,
+		RepeatInterval: parent.RepeatInterval,
+		Continue:       v.Get("continue").AsBool(),
+		Path:           path,
+	}
+	if receiver := v.Get("receiver").AsString(); receiver != "" {
+		route.Receiver = receiver
+	}
+	if groupBy, ok := v.Lookup("group_by"); ok {
+		route.GroupBy = nil
+		for _, label := range groupBy.AsArray() {
+			route.GroupBy = append(route.GroupBy, label.AsString())
+		}
+	}
+	if v.Has("group_wait") {
+		route.GroupWait = durationField(v, "group_wait", path, c)
+	}
+	if v.Has("group_interval") {
+		route.GroupInterval = durationField(v, "group_interval", path, c)
+	}
+	if v.Has("repeat_interval") {
+		route.RepeatInterval = durationField(v, "repeat_interval", path, c)
+	}
+
+	for i, m := range v.Get("matchers").AsArray() {
+		matcher, err := ParseMatcher(m.AsString())
+		if err != nil {
+			c.Add("validate", path+".matchers."+strconv.Itoa(i), err)
+			continue
+		}
+		route.Matchers = append(route.Matchers, matcher)
+	}
+	for _, key := range []string{"match", "match_re"} {
+		typ := MatchEqual
+		if key == "match_re" {
+			typ = MatchRegexp
+		}
+		legacy := v.Get(key)
+		names := make([]string, 0, legacy.Len())
+		for _, name := range legacy.Keys() {
+			names = append(names, fmt.Sprintf("%v", name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			matcher, err := newMatcher(name, typ, fmt.Sprintf("%v", legacy.Get(name).Raw()))
+			if err != nil {
+				c.Add("validate", path+"."+key+"."+name, err)
+				continue
+			}
+			route.Matchers = append(route.Matchers, matcher)
+		}
+	}
+
+	for i, child := range v.Get("routes").AsArray() {
+		route.Routes = append(route.Routes, parseRoute(child, path+".routes."+strconv.Itoa(i), route, c))
+	}
+	return route
+}
+
+// Walk calls fn for the route and every route below it, depth first
+func (r *Route) Walk(fn func(*Route)) {
+	fn(r)
+	for _, child := range r.Routes {
+		child.Walk(fn)
+	}
+}
+
+// Match returns the routes an alert with the given labels is sent to, as
+// Alertmanager picks them: the deepest matching route of each branch,
+// moving on to the next sibling only after a match with continue set.
+// Usage: for _, r := range am.Route.Match(map[string]string{"severity": "critical"}) { fmt.Println(r.Receiver) }
+func (r *Route) Match(labels map[string]string) []*Route {
+	for _, m := range r.Matchers {
+		if !m.Matches(labels) {
+			return nil
+		}
+	}
+	var matched []*Route
+	for _, child := range r.Routes {
+		found := child.Match(labels)
+		matched = append(matched, found...)
+		if len(found) > 0 && !child.Continue {
+			break
+		}
+	}
+	if len(matched) == 0 {
+		return []*Route{r}
+	}
+	return matched
+}
@@ -0,0 +1,109 @@
+package prometheus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javanhut/easyyaml"
+)
+
+const alertmanagerConfig = `route:
+  receiver: default
+  group_by: [alertname]
+  group_wait: 30s
+  routes:
+    - matchers: ['severity="critical"']
+      receiver: pager
+      continue: true
+    - matchers: [team=~"db|storage"]
+      receiver: db
+      repeat_interval: 1h
+      routes:
+        - match:
+            env: staging
+          receiver: db-staging
+receivers:
+  - name: default
+  - name: pager
+  - name: db
+  - name: db-staging
+`
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		input  string
+		labels map[string]string
+		match  bool
+	}{
+		{`env="prod"`, map[string]string{"env": "prod"}, true},
+		{`env != dev`, map[string]string{"env": "prod"}, true},
+		{`severity=~"critical|page"`, map[string]string{"severity": "page"}, true},
+		{`severity=~"crit"`, map[string]string{"severity": "critical"}, false},
+		{`team!~db.*`, map[string]string{}, true},
+	}
+	for _, tt := range tests {
+		m, err := ParseMatcher(tt.input)
+		if err != nil {
+			t.Fatalf("ParseMatcher(%q) failed: %v", tt.input, err)
+		}
+		if m.Matches(tt.labels) != tt.match {
+			t.Errorf("%s.Matches(%v) = %v", m, tt.labels, !tt.match)
+		}
+	}
+	if _, err := ParseMatcher(`team=~"("`); !errors.Is(err, easyyaml.ErrSchemaViolation) {
+		t.Errorf("Expected a violation for a bad regexp, got %v", err)
+	}
+}
+
+func TestParseAlertmanager(t *testing.T) {
+	doc, _ := easyyaml.Loads(alertmanagerConfig)
+	am, err := ParseAlertmanager(doc)
+	if err != nil {
+		t.Fatalf("ParseAlertmanager failed: %v", err)
+	}
+	db := am.Route.Routes[1]
+	if db.GroupWait != 30*time.Second || db.RepeatInterval != time.Hour || db.GroupBy[0] != "alertname" {
+		t.Errorf("Expected inherited settings, got %+v", db)
+	}
+	if staging := db.Routes[0]; staging.Matchers[0].String() != `env="staging"` || staging.Path != "route.routes.1.routes.0" {
+		t.Errorf("Unexpected legacy matcher route: %+v", staging)
+	}
+
+	receivers := func(labels map[string]string) []string {
+		var names []string
+		for _, r := range am.Route.Match(labels) {
+			names = append(names, r.Receiver)
+		}
+		return names
+	}
+	if got := receivers(map[string]string{"severity": "critical", "team": "db", "env": "staging"}); len(got) != 2 || got[0] != "pager" || got[1] != "db-staging" {
+		t.Errorf("Unexpected receivers: %v", got)
+	}
+	if got := receivers(map[string]string{"team": "web"}); len(got) != 1 || got[0] != "default" {
+		t.Errorf("Expected the root receiver, got %v", got)
+	}
+
+	count := 0
+	am.Route.Walk(func(*Route) { count++ })
+	if count != 4 {
+		t.Errorf("Expected 4 routes, got %d", count)
+	}
+}
+
+func TestParseAlertmanagerViolations(t *testing.T) {
+	doc, _ := easyyaml.Loads(`route:
+  group_wait: later
+  routes:
+    - receiver: missing
+      matchers: ["not a matcher"]
+receivers:
+  - name: a
+  - name: a
+`)
+	_, err := ParseAlertmanager(doc)
+	var multi *easyyaml.MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 5 {
+		t.Fatalf("Expected 5 violations, got %v", err)
+	}
+}
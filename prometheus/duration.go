@@ -0,0 +1,70 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// durationPattern matches a Prometheus duration, e.g. "1h30m" or "2w"
+var durationPattern = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?(?:(\d+)ms)?$`)
+
+// durationUnits are the units of the groups of durationPattern
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour,
+	time.Hour, time.Minute, time.Second, time.Millisecond,
+}
+
+// ParseDuration parses a Prometheus duration such as "90s", "1h30m" or
+// "1w". Units run from y (365 days) to ms and must appear in that order,
+// each at most once. "0" is allowed.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	m := durationPattern.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return 0, fmt.Errorf("%w: invalid duration %q", easyyaml.ErrTypeMismatch, s)
+	}
+	var d time.Duration
+	for i, unit := range durationUnits {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(m[i+1], 10, 64)
+		if err != nil || time.Duration(n) > (1<<63-1)/unit {
+			return 0, fmt.Errorf("%w: duration %q is too long", easyyaml.ErrTypeMismatch, s)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// durationField parses the optional duration at key of v, recording a
+// problem in c
+func durationField(v *easyyaml.YAMLValue, key, path string, c *easyyaml.ErrorCollector) time.Duration {
+	field, ok := v.Lookup(key)
+	if !ok || field.IsNull() {
+		return 0
+	}
+	if !field.IsString() && !field.IsNumber() {
+		c.Add("parse", joinPath(path, key), fmt.Errorf("%w: %s must be a duration", easyyaml.ErrSchemaViolation, key))
+		return 0
+	}
+	d, err := ParseDuration(fmt.Sprintf("%v", field.Raw()))
+	if err != nil {
+		c.Add("parse", joinPath(path, key), fmt.Errorf("%w: %v", easyyaml.ErrSchemaViolation, err))
+	}
+	return d
+}
+
+// joinPath appends a key to a dot-separated path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,160 @@
+// Package prometheus provides helpers for auditing Prometheus rule files
+// and Alertmanager configurations loaded with easyyaml: rule groups and
+// rules with their durations parsed, route trees with their label matchers
+// parsed, and validation that reports every problem with its path.
+//
+// Typical use:
+//
+//	groups, err := prometheus.LoadRules("rules/api.yml")
+//	for _, rule := range prometheus.Rules(groups) {
+//		if rule.Alert != "" && rule.Labels["severity"] == "" {
+//			fmt.Println(rule.Path, rule.Alert, "has no severity")
+//		}
+//	}
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// RuleGroup is a group of a Prometheus rule file
+type RuleGroup struct {
+	Name     string
+	Interval time.Duration
+	Limit    int
+	Rules    []Rule
+	// Path is the group's path in the document, e.g. "groups.0"
+	Path string
+}
+
+// Rule is an alerting or recording rule
+type Rule struct {
+	Group string
+	// Alert is set for alerting rules and Record for recording rules
+	Alert         string
+	Record        string
+	Expr          string
+	For           time.Duration
+	KeepFiringFor time.Duration
+	Labels        map[string]string
+	Annotations   map[string]string
+	// Path is the rule's path in the document, e.g. "groups.0.rules.3"
+	Path string
+	// Spec is the rule object
+	Spec *easyyaml.YAMLValue
+}
+
+var (
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// LoadRules reads a Prometheus rule file, as ParseRules
+// Usage: groups, err := prometheus.LoadRules("rules/api.yml")
+func LoadRules(filename string) ([]RuleGroup, error) {
+	doc, err := easyyaml.LoadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(doc)
+}
+
+// ParseRules parses the groups of a rule file and validates them the way
+// promtool does, apart from parsing PromQL: group names must be set and
+// unique, each rule needs an expr and exactly one of alert or record,
+// record names and label names must be valid, for and keep_firing_for
+// only apply to alerts, and durations must parse. Every problem is returned
+// in a *easyyaml.MultiError, each wrapping easyyaml.ErrSchemaViolation
+// with its path; the groups are returned as far as they could be parsed.
+func ParseRules(doc *easyyaml.YAMLValue) ([]RuleGroup, error) {
+	c := &easyyaml.ErrorCollector{}
+	violation := func(path, format string, args ...interface{}) {
+		c.Add("validate", path, fmt.Errorf("%w: %s", easyyaml.ErrSchemaViolation, fmt.Sprintf(format, args...)))
+	}
+	if !doc.Get("groups").IsArray() {
+		violation("groups", "groups must be a list")
+		return nil, c.Err()
+	}
+
+	var groups []RuleGroup
+	names := make(map[string]bool)
+	for i, g := range doc.Get("groups").AsArray() {
+		path := "groups." + strconv.Itoa(i)
+		group := RuleGroup{Name: g.Get("name").AsString(), Limit: g.Get("limit").AsInt(), Path: path}
+		switch {
+		case group.Name == "":
+			violation(path, "group name is required")
+		case names[group.Name]:
+			violation(path, "group %q is defined more than once", group.Name)
+		}
+		names[group.Name] = true
+		group.Interval = durationField(g, "interval", path, c)
+
+		for j, r := range g.Get("rules").AsArray() {
+			rulePath := path + ".rules." + strconv.Itoa(j)
+			rule := Rule{
+				Group:       group.Name,
+				Alert:       r.Get("alert").AsString(),
+				Record:      r.Get("record").AsString(),
+				Expr:        fmt.Sprintf("%v", r.Get("expr").Raw()),
+				Labels:      stringMap(r.Get("labels")),
+				Annotations: stringMap(r.Get("annotations")),
+				Path:        rulePath,
+				Spec:        r,
+			}
+			if r.Get("expr").IsNull() {
+				rule.Expr = ""
+				violation(rulePath, "expr is required")
+			}
+			switch {
+			case rule.Alert == "" && rule.Record == "":
+				violation(rulePath, "one of alert or record is required")
+			case rule.Alert != "" && rule.Record != "":
+				violation(rulePath, "only one of alert or record may be set")
+			case rule.Record != "" && !metricNamePattern.MatchString(rule.Record):
+				violation(rulePath+".record", "invalid metric name %q", rule.Record)
+			}
+			if rule.Record != "" {
+				for _, key := range []string{"for", "keep_firing_for", "annotations"} {
+					if r.Has(key) {
+						violation(rulePath+"."+key, "%s only applies to alerting rules", key)
+					}
+				}
+			}
+			for name := range rule.Labels {
+				if !labelNamePattern.MatchString(name) {
+					violation(rulePath+".labels", "invalid label name %q", name)
+				}
+			}
+			rule.For = durationField(r, "for", rulePath, c)
+			rule.KeepFiringFor = durationField(r, "keep_firing_for", rulePath, c)
+			group.Rules = append(group.Rules, rule)
+		}
+		groups = append(groups, group)
+	}
+	return groups, c.Err()
+}
+
+// Rules returns the rules of all groups in order
+func Rules(groups []RuleGroup) []Rule {
+	var rules []Rule
+	for _, g := range groups {
+		rules = append(rules, g.Rules...)
+	}
+	return rules
+}
+
+// stringMap returns the entries of an object as strings
+func stringMap(v *easyyaml.YAMLValue) map[string]string {
+	m := make(map[string]string)
+	for _, key := range v.Keys() {
+		name := fmt.Sprintf("%v", key)
+		m[name] = fmt.Sprintf("%v", v.Get(name).Raw())
+	}
+	return m
+}
@@ -0,0 +1,107 @@
+package prometheus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javanhut/easyyaml"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"0":     0,
+		"90s":   90 * time.Second,
+		"1h30m": 90 * time.Minute,
+		"1w2d":  9 * 24 * time.Hour,
+		"500ms": 500 * time.Millisecond,
+		"1y":    365 * 24 * time.Hour,
+	}
+	for input, expected := range tests {
+		if got, err := ParseDuration(input); err != nil || got != expected {
+			t.Errorf("ParseDuration(%q) = %v, %v; expected %v", input, got, err, expected)
+		}
+	}
+	for _, input := range []string{"", "5", "1m1h", "1.5h", "-1m"} {
+		if _, err := ParseDuration(input); !errors.Is(err, easyyaml.ErrTypeMismatch) {
+			t.Errorf("Expected ParseDuration(%q) to fail, got %v", input, err)
+		}
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	doc, _ := easyyaml.Loads(`groups:
+  - name: api
+    interval: 30s
+    rules:
+      - record: job:http_requests:rate5m
+        expr: sum by (job) (rate(http_requests_total[5m]))
+      - alert: HighErrorRate
+        expr: job:http_errors:ratio > 0.05
+        for: 10m
+        labels:
+          severity: page
+        annotations:
+          summary: Error rate above 5%
+`)
+	groups, err := ParseRules(doc)
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Interval != 30*time.Second || len(groups[0].Rules) != 2 {
+		t.Fatalf("Unexpected groups: %+v", groups)
+	}
+	alert := Rules(groups)[1]
+	if alert.Alert != "HighErrorRate" || alert.For != 10*time.Minute || alert.Labels["severity"] != "page" || alert.Path != "groups.0.rules.1" || alert.Group != "api" {
+		t.Errorf("Unexpected alert: %+v", alert)
+	}
+}
+
+func TestParseRulesViolations(t *testing.T) {
+	doc, _ := easyyaml.Loads(`groups:
+  - name: api
+    interval: soon
+    rules:
+      - record: bad-name
+        expr: up
+        for: 5m
+      - alert: NoExpr
+      - alert: Both
+        record: both
+        expr: up
+        labels:
+          bad-label: x
+  - name: api
+    rules: []
+`)
+	groups, err := ParseRules(doc)
+	var multi *easyyaml.MultiError
+	if !errors.As(err, &multi) || !errors.Is(err, easyyaml.ErrSchemaViolation) {
+		t.Fatalf("Expected a MultiError of violations, got %v", err)
+	}
+	expected := []string{
+		"groups.0.interval",
+		"groups.0.rules.0.record",
+		"groups.0.rules.0.for",
+		"groups.0.rules.1",
+		"groups.0.rules.2",
+		"groups.0.rules.2.labels",
+		"groups.1",
+	}
+	if len(multi.Errors) != len(expected) {
+		t.Fatalf("Expected %d violations, got:\n%v", len(expected), err)
+	}
+	for i, path := range expected {
+		var opErr *easyyaml.OpError
+		if !errors.As(multi.Errors[i], &opErr) || opErr.Key != path {
+			t.Errorf("Expected violation %d at %s, got %v", i, path, multi.Errors[i])
+		}
+	}
+	if len(groups) != 2 {
+		t.Errorf("Expected groups returned with the violations, got %d", len(groups))
+	}
+	if !strings.Contains(err.Error(), `group "api" is defined more than once`) {
+		t.Errorf("Unexpected message: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package protostruct converts easyyaml documents to and from
+// google.protobuf.Struct, for passing dynamic configuration to gRPC APIs
+// without going through JSON. It lives apart from easyyaml so that only
+// programs using it depend on protobuf.
+//
+// Typical use:
+//
+//	s, err := protostruct.ToStruct(cfg)
+//	cfg = protostruct.FromStruct(resp.GetConfig())
+package protostruct
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/javanhut/easyyaml"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToStruct converts an object to a google.protobuf.Struct. Keys are
+// formatted as strings, timestamps as RFC 3339 and binary data as base64.
+// Numbers become doubles, so integers beyond 2^53 lose precision. It returns
+// an error wrapping easyyaml.ErrTypeMismatch if the value is not an object,
+// and one wrapping easyyaml.ErrKeyExists if two keys format the same.
+// Usage: req := &pb.UpdateRequest{Config: must(protostruct.ToStruct(cfg))}
+func ToStruct(yv *easyyaml.YAMLValue) (*structpb.Struct, error) {
+	plain, err := yv.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	value, err := protoValue(plain, "")
+	if err != nil {
+		return nil, err
+	}
+	return value.GetStructValue(), nil
+}
+
+// FromStruct creates an object from a google.protobuf.Struct. Whole
+// numbers that fit an int become ints, as they would when loading YAML.
+// Usage: cfg := protostruct.FromStruct(resp.GetConfig())
+func FromStruct(s *structpb.Struct) *easyyaml.YAMLValue {
+	return easyyaml.New(fromProto(structpb.NewStructValue(s)))
+}
+
+// protoValue converts data built by ToMap to a structpb.Value
+func protoValue(data interface{}, path string) (*structpb.Value, error) {
+	switch v := data.(type) {
+	case nil:
+		return structpb.NewNullValue(), nil
+	case map[string]interface{}:
+		fields := make(map[string]*structpb.Value, len(v))
+		for k, val := range v {
+			field, err := protoValue(val, joinPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = field
+		}
+		return structpb.NewStructValue(&structpb.Struct{Fields: fields}), nil
+	case []interface{}:
+		items := make([]*structpb.Value, len(v))
+		for i, val := range v {
+			item, err := protoValue(val, joinPath(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return structpb.NewListValue(&structpb.ListValue{Values: items}), nil
+	case time.Time:
+		return structpb.NewStringValue(v.Format(time.RFC3339Nano)), nil
+	case []byte:
+		return structpb.NewStringValue(base64.StdEncoding.EncodeToString(v)), nil
+	}
+	value, err := structpb.NewValue(data)
+	if err != nil {
+		return nil, &easyyaml.OpError{Op: "to_proto", Key: path, Err: fmt.Errorf("%w: %v", easyyaml.ErrTypeMismatch, err)}
+	}
+	return value, nil
+}
+
+// fromProto converts a structpb.Value to generic YAML data
+func fromProto(value *structpb.Value) interface{} {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		obj := make(map[string]interface{}, len(kind.StructValue.GetFields()))
+		for k, field := range kind.StructValue.GetFields() {
+			obj[k] = fromProto(field)
+		}
+		return obj
+	case *structpb.Value_ListValue:
+		items := make([]interface{}, len(kind.ListValue.GetValues()))
+		for i, item := range kind.ListValue.GetValues() {
+			items[i] = fromProto(item)
+		}
+		return items
+	case *structpb.Value_NumberValue:
+		n := kind.NumberValue
+		if n == math.Trunc(n) && n >= math.MinInt && n < math.MaxInt {
+			return int(n)
+		}
+		return n
+	case *structpb.Value_StringValue:
+		return kind.StringValue
+	case *structpb.Value_BoolValue:
+		return kind.BoolValue
+	}
+	return nil
+}
+
+// joinPath appends a segment to a dot-separated path
+func joinPath(base, segment string) string {
+	if base == "" {
+		return segment
+	}
+	return base + "." + segment
+}
@@ -0,0 +1,45 @@
+package protostruct
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+func TestToStruct(t *testing.T) {
+	yv, _ := easyyaml.Loads(`name: app
+port: 8080
+ratio: 0.5
+enabled: true
+proxy: null
+hosts: [a, b]
+codes:
+  200: ok
+`)
+	s, err := ToStruct(yv)
+	if err != nil {
+		t.Fatalf("ToStruct failed: %v", err)
+	}
+	fields := s.GetFields()
+	if fields["name"].GetStringValue() != "app" || fields["port"].GetNumberValue() != 8080 || !fields["enabled"].GetBoolValue() {
+		t.Errorf("Unexpected fields: %v", s)
+	}
+	if fields["hosts"].GetListValue().GetValues()[1].GetStringValue() != "b" {
+		t.Errorf("Unexpected hosts: %v", fields["hosts"])
+	}
+	if fields["codes"].GetStructValue().GetFields()["200"].GetStringValue() != "ok" {
+		t.Errorf("Expected int keys formatted as strings, got %v", fields["codes"])
+	}
+
+	back := FromStruct(s)
+	expected, _ := easyyaml.Loads("name: app\nport: 8080\nratio: 0.5\nenabled: true\nproxy: null\nhosts: [a, b]\ncodes:\n  \"200\": ok\n")
+	if !reflect.DeepEqual(back.Raw(), expected.Raw()) {
+		t.Errorf("Expected %v, got %v", expected.Raw(), back.Raw())
+	}
+
+	if _, err := ToStruct(easyyaml.New([]interface{}{1})); !errors.Is(err, easyyaml.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Origin is the position a value was loaded from
+type Origin struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String formats the origin as file:line:column
+func (o Origin) String() string {
+	file := o.File
+	if file == "" {
+		file = "<input>"
+	}
+	return fmt.Sprintf("%s:%d:%d", file, o.Line, o.Column)
+}
+
+// WithProvenance makes a load record where every value came from, so
+// Provenance can report it. LoadFile and LoadAllFile record the file name.
+// Usage: base, err := easyyaml.LoadFile("base.yaml", easyyaml.WithProvenance())
+func WithProvenance() LoadOption {
+	return func(opts *Options) {
+		opts.Provenance = true
+	}
+}
+
+// WithSourceName sets the file name recorded by WithProvenance when loading
+// from bytes
+func WithSourceName(name string) LoadOption {
+	return func(opts *Options) {
+		opts.sourceName = name
+	}
+}
+
+// Provenance reports the file and position the value at a dot-separated
+// path was loaded from. Values merged in with Merge or MergeTraced report
+// the overlay's origin, which is how layered configs are traced: there is
+// no include mechanism, so every value comes from the file it was loaded
+// from. It returns false for values loaded without WithProvenance or added
+// programmatically.
+// Usage: origin, ok := cfg.Provenance("server.port") // "prod.yaml:3:9"
+func (yv *YAMLValue) Provenance(path string) (Origin, bool) {
+	path = normalizePath(path)
+	origin, ok := yv.origins[path]
+	if !ok || !yv.Path(path).Exists() {
+		return Origin{}, false
+	}
+	return origin, true
+}
+
+// recordOrigins maps the path of every node in a document to its position.
+// Mapping entries are recorded at their key.
+func recordOrigins(node *yaml.Node, file string) map[string]Origin {
+	origins := make(map[string]Origin)
+	var visit func(n *yaml.Node, path string, at *yaml.Node)
+	visit = func(n *yaml.Node, path string, at *yaml.Node) {
+		origins[path] = Origin{File: file, Line: at.Line, Column: at.Column}
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, child := range n.Content {
+				visit(child, path, child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, value := n.Content[i], n.Content[i+1]
+				visit(value, joinPath(path, key.Value), key)
+			}
+		case yaml.SequenceNode:
+			for i, child := range n.Content {
+				visit(child, joinPath(path, strconv.Itoa(i)), child)
+			}
+		}
+	}
+	if node.Kind != 0 {
+		visit(node, "", node)
+	}
+	return origins
+}
+
+// mergeOrigins updates the origins of yv after other was merged into it.
+// Every path other supplied takes other's origin, or loses its origin if
+// other has none.
+func (yv *YAMLValue) mergeOrigins(other *YAMLValue) {
+	if yv.origins == nil && other.origins == nil {
+		return
+	}
+	if yv.origins == nil {
+		yv.origins = make(map[string]Origin)
+	}
+	other.walk("", func(path string, value *YAMLValue) {
+		if path == "" {
+			return
+		}
+		if origin, ok := other.origins[path]; ok {
+			yv.origins[path] = origin
+		} else {
+			delete(yv.origins, path)
+		}
+	})
+}
+
+// copyOrigins returns a copy of origins, so merges into a copied document
+// do not change the provenance of the original
+func copyOrigins(origins map[string]Origin) map[string]Origin {
+	if origins == nil {
+		return nil
+	}
+	copied := make(map[string]Origin, len(origins))
+	for path, origin := range origins {
+		copied[path] = origin
+	}
+	return copied
+}
+
+// normalizePath drops empty segments from a dot-separated path
+func normalizePath(path string) string {
+	normalized := ""
+	for _, part := range splitPaths([]string{path})[0] {
+		normalized = joinPath(normalized, part)
+	}
+	return normalized
+}
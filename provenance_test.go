@@ -0,0 +1,80 @@
+package easyyaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProvenance(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.yaml")
+	prodPath := filepath.Join(dir, "prod.yaml")
+	os.WriteFile(basePath, []byte("server:\n  host: localhost\n  port: 80\nhosts:\n  - a\n  - b\n"), 0644)
+	os.WriteFile(prodPath, []byte("# production\nserver:\n  port: 443\n"), 0644)
+
+	base, err := LoadFile(basePath, WithProvenance())
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if origin, ok := base.Provenance("hosts.1"); !ok || origin != (Origin{File: basePath, Line: 6, Column: 5}) {
+		t.Errorf("Expected hosts.1 at line 6, got %v, %v", origin, ok)
+	}
+
+	prod, _ := LoadFile(prodPath, WithProvenance())
+	if err := base.Merge(prod); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if origin, _ := base.Provenance("server.port"); origin.String() != prodPath+":3:3" {
+		t.Errorf("Expected port from prod.yaml, got %v", origin)
+	}
+	if origin, _ := base.Provenance("server.host"); origin.File != basePath {
+		t.Errorf("Expected host from base.yaml, got %v", origin)
+	}
+
+	overlay := NewObject()
+	overlay.Set("server", map[string]interface{}{"host": "example.com"})
+	base.Merge(overlay)
+	if _, ok := base.Provenance("server.host"); ok {
+		t.Errorf("Expected no origin for a programmatic value")
+	}
+	if _, ok := base.Provenance("missing"); ok {
+		t.Errorf("Expected no origin for a missing path")
+	}
+
+	plain, _ := Loads("a: 1\n")
+	if _, ok := plain.Provenance("a"); ok {
+		t.Errorf("Expected no origins without WithProvenance")
+	}
+	named, _ := Loads("a: 1\n", WithProvenance(), WithSourceName("inline"))
+	if origin, _ := named.Provenance("a"); origin.String() != "inline:1:1" {
+		t.Errorf("Expected inline:1:1, got %v", origin)
+	}
+}
+
+func TestProvenanceClone(t *testing.T) {
+	base, _ := Loads("a: 1\nb: 2\n", WithProvenance(), WithSourceName("base.yaml"))
+	over, _ := Loads("a: 3\n", WithProvenance(), WithSourceName("over.yaml"))
+
+	c := base.Clone()
+	if err := c.Merge(over); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if origin, _ := c.Provenance("a"); origin.File != "over.yaml" {
+		t.Errorf("Expected the clone's a from over.yaml, got %v", origin)
+	}
+	if origin, _ := base.Provenance("a"); origin.File != "base.yaml" {
+		t.Errorf("Expected the base's a to stay from base.yaml, got %v", origin)
+	}
+
+	merged, _, err := MergeTraced(base, over)
+	if err != nil {
+		t.Fatalf("MergeTraced failed: %v", err)
+	}
+	if origin, _ := merged.Provenance("a"); origin.File != "over.yaml" {
+		t.Errorf("Expected MergeTraced to keep layer provenance, got %v", origin)
+	}
+	if origin, _ := merged.Provenance("b"); origin.File != "base.yaml" {
+		t.Errorf("Expected b from base.yaml, got %v", origin)
+	}
+}
@@ -0,0 +1,94 @@
+package easyyaml
+
+// PruneOptions selects which empty values Prune removes
+type PruneOptions struct {
+	Nulls        bool
+	EmptyMaps    bool
+	EmptyArrays  bool
+	EmptyStrings bool
+}
+
+// Prune removes empty values recursively and returns how many it removed.
+// Children are pruned first, so a map left empty by pruning is removed too
+// when EmptyMaps is set. Matching array elements are dropped; the root is
+// never removed. It returns an error wrapping ErrFrozen for a frozen
+// document.
+// Usage: removed, err := doc.Prune(easyyaml.PruneOptions{Nulls: true, EmptyMaps: true, EmptyArrays: true})
+func (yv *YAMLValue) Prune(opts PruneOptions) (int, error) {
+	if err := yv.checkFrozen("prune", nil); err != nil {
+		return 0, err
+	}
+	logMutation("prune")
+	yv.invalidate()
+	p := &pruner{opts: opts, meta: yv.meta}
+	yv.replaceData(p.prune(yv.data, yv.at))
+	return p.removed, nil
+}
+
+// pruner removes empty values, dropping or moving the node details
+// recorded for them, and counts them
+type pruner struct {
+	opts    PruneOptions
+	meta    *docMeta
+	removed int
+}
+
+// prune removes empty children of data, which is at path
+func (p *pruner) prune(data interface{}, path string) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			child = p.prune(child, childPath(path, k))
+			if p.opts.isEmpty(child) {
+				delete(v, k)
+				p.meta.forget(childPath(path, k))
+				p.removed++
+				continue
+			}
+			v[k] = child
+		}
+	case map[interface{}]interface{}:
+		for k, child := range v {
+			child = p.prune(child, childPath(path, k))
+			if p.opts.isEmpty(child) {
+				delete(v, k)
+				p.meta.forget(childPath(path, k))
+				p.removed++
+				continue
+			}
+			v[k] = child
+		}
+	case []interface{}:
+		kept := make([]interface{}, 0, len(v))
+		from := make([]int, 0, len(v))
+		for i, child := range v {
+			child = p.prune(child, childPath(path, i))
+			if p.opts.isEmpty(child) {
+				p.removed++
+				continue
+			}
+			kept = append(kept, child)
+			from = append(from, i)
+		}
+		p.meta.reindex(path, from)
+		return kept
+	}
+	return data
+}
+
+// isEmpty checks if data is one of the empty values selected by opts
+func (opts PruneOptions) isEmpty(data interface{}) bool {
+	switch v := data.(type) {
+	case nil:
+		return opts.Nulls
+	case string:
+		return opts.EmptyStrings && v == ""
+	case map[string]interface{}:
+		return opts.EmptyMaps && len(v) == 0
+	case map[interface{}]interface{}:
+		return opts.EmptyMaps && len(v) == 0
+	case []interface{}:
+		return opts.EmptyArrays && len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package easyyaml
+
+import "testing"
+
+func TestPrune(t *testing.T) {
+	yv, _ := Loads(`
+name: app
+description: ""
+labels: {}
+annotations:
+  note: null
+ports: []
+env:
+  - null
+  - name: A
+    value: ""
+status: null
+`)
+
+	removed, err := yv.Prune(PruneOptions{Nulls: true, EmptyMaps: true, EmptyArrays: true})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	out, _ := yv.Dumps()
+	expected := `description: ""
+env:
+    - name: A
+      value: ""
+name: app
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+	if removed != 6 {
+		t.Errorf("Expected 6 values removed, got %d", removed)
+	}
+
+	yv.Prune(PruneOptions{EmptyStrings: true})
+	if yv.Has("description") || yv.Path("env.0").Has("value") {
+		t.Errorf("Expected empty strings removed, got %v", yv.Raw())
+	}
+}
+
+func TestPruneChildList(t *testing.T) {
+	yv, _ := Loads("list: [null, 1, null, 2]\n")
+	removed, err := yv.Get("list").Prune(PruneOptions{Nulls: true})
+	if err != nil || removed != 2 {
+		t.Fatalf("Expected 2 values removed, got %d, %v", removed, err)
+	}
+	if out, _ := yv.Dumps(); out != "list:\n    - 1\n    - 2\n" {
+		t.Errorf("Expected the parent to see the pruned list, got %q", out)
+	}
+}
@@ -0,0 +1,64 @@
+package easyyaml
+
+import "fmt"
+
+// WithRecover makes a load return an error instead of panicking if the
+// parser panics on malformed input, for services that must not crash on
+// untrusted documents. The accessors are run over the loaded document too,
+// so one they would panic on fails to load rather than crashing later.
+// Usage: doc, err := easyyaml.Load(untrusted, easyyaml.WithRecover())
+func WithRecover() LoadOption {
+	return func(opts *Options) {
+		opts.Recover = true
+	}
+}
+
+// recoverError converts a panic in the calling function into *err. It must
+// be deferred directly.
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("easyyaml: recovered from panic: %v", r)
+		logError("easyyaml: parse panicked", *err)
+	}
+}
+
+// FuzzLoad is a fuzz entry point that parses data and exercises the
+// accessors and serializers on the result. It returns 1 when data is valid
+// YAML and 0 otherwise, following the go-fuzz convention. Panics are not
+// recovered so fuzzers can report them.
+// Usage: f.Fuzz(func(t *testing.T, data []byte) { easyyaml.FuzzLoad(data) })
+func FuzzLoad(data []byte) int {
+	yv, err := Load(data)
+	if err != nil {
+		return 0
+	}
+	exercise(yv)
+
+	out, err := yv.Dump()
+	if err != nil {
+		return 1
+	}
+	// Keys such as 0 and 0.0 dump as the same text, so only a document
+	// that strict mode accepts must reload
+	if checkStringKeys(yv.data) == nil {
+		if _, err := Load(out); err != nil {
+			panic(fmt.Sprintf("dumped output does not parse: %v\n%s", err, out))
+		}
+	}
+
+	if docs, err := LoadAll(data); err == nil {
+		docs.DumpAll()
+	}
+	return 1
+}
+
+// exercise runs the accessors over every value of yv
+func exercise(yv *YAMLValue) {
+	yv.walk("", func(path string, value *YAMLValue) {
+		value.AsString()
+		value.Keys()
+		value.Len()
+		yv.Path(path)
+	})
+	yv.ToJSON()
+}
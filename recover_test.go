@@ -0,0 +1,36 @@
+package easyyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecoverError(t *testing.T) {
+	parse := func() (err error) {
+		defer recoverError(&err)
+		panic("boom")
+	}
+	err := parse()
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Expected recovered panic error, got %v", err)
+	}
+
+	yv, err := Loads("a: 1\n", WithRecover())
+	if err != nil || yv.Get("a").AsInt() != 1 {
+		t.Errorf("Expected normal load with WithRecover, got %v", err)
+	}
+	if _, err := LoadAlls("a: [\n", WithRecover()); err == nil {
+		t.Error("Expected parse error to be returned")
+	}
+}
+
+func FuzzLoadInput(f *testing.F) {
+	f.Add([]byte("a: 1\nb: [x, y]\n"))
+	f.Add([]byte(podYAML))
+	f.Add([]byte("%YAML 1.2\n---\nkey: &a {x: 1}\nref: *a\n---\n- 1\n"))
+	f.Add([]byte("? [a, b]\n: value\n"))
+	f.Add([]byte("0: 0\n0.0:"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		FuzzLoad(data)
+	})
+}
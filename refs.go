@@ -0,0 +1,158 @@
+package easyyaml
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ResolveRefs returns a copy of the document with every JSON-Schema-style
+// "$ref" replaced by the value it refers to. References are either local,
+// like "#/definitions/db", or point into another file relative to the
+// referring one, like "./common.yaml#/definitions/db"; baseDir is the
+// directory of this document. Other keys next to a "$ref" are merged over
+// the referenced object. Cycles return an error wrapping ErrRefCycle.
+// Usage: resolved, err := schema.ResolveRefs("schemas")
+func (yv *YAMLValue) ResolveRefs(baseDir string) (*YAMLValue, error) {
+	r := &refResolver{files: make(map[string]*YAMLValue), active: make(map[string]bool)}
+	data, err := r.resolve(yv.data, yv, baseDir, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return &YAMLValue{data: data, opts: yv.opts, directives: yv.directives}, nil
+}
+
+// refResolver caches referenced files and tracks the refs being resolved
+type refResolver struct {
+	files  map[string]*YAMLValue
+	active map[string]bool
+}
+
+// resolve copies data with its refs inlined. doc and file identify the
+// document data belongs to, for local refs; dir is where file refs start.
+func (r *refResolver) resolve(data interface{}, doc *YAMLValue, dir, file, path string) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return r.resolveRef(ref, v, doc, dir, file, path)
+		}
+		m := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			resolved, err := r.resolve(child, doc, dir, file, joinPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			m[k] = resolved
+		}
+		return m, nil
+	case map[interface{}]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			siblings := make(map[string]interface{}, len(v))
+			for k, child := range v {
+				siblings[fmt.Sprintf("%v", k)] = child
+			}
+			return r.resolveRef(ref, siblings, doc, dir, file, path)
+		}
+		m := make(map[interface{}]interface{}, len(v))
+		for k, child := range v {
+			resolved, err := r.resolve(child, doc, dir, file, joinPath(path, fmt.Sprintf("%v", k)))
+			if err != nil {
+				return nil, err
+			}
+			m[k] = resolved
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, child := range v {
+			resolved, err := r.resolve(child, doc, dir, file, joinPath(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			s[i] = resolved
+		}
+		return s, nil
+	}
+	return data, nil
+}
+
+// resolveRef inlines the target of ref, merging the other keys of the
+// object holding it over the result
+func (r *refResolver) resolveRef(ref string, holder map[string]interface{}, doc *YAMLValue, dir, file, path string) (interface{}, error) {
+	target, fragment, _ := strings.Cut(ref, "#")
+	if target != "" {
+		if strings.Contains(target, "://") {
+			return nil, opError("resolve_ref", path, ErrKeyNotFound, "remote reference "+ref+" is not supported")
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		loaded, ok := r.files[target]
+		if !ok {
+			var err error
+			loaded, err = LoadFile(target)
+			if err != nil {
+				return nil, fmt.Errorf("resolve_ref %s: %w", path, err)
+			}
+			r.files[target] = loaded
+		}
+		doc, dir, file = loaded, filepath.Dir(target), target
+	}
+
+	key := file + "#" + fragment
+	if r.active[key] {
+		return nil, opError("resolve_ref", path, ErrRefCycle, ref)
+	}
+	value, ok := resolveFragment(doc, fragment)
+	if !ok {
+		return nil, opError("resolve_ref", path, ErrKeyNotFound, ref)
+	}
+
+	r.active[key] = true
+	resolved, err := r.resolve(value.data, doc, dir, file, path)
+	delete(r.active, key)
+	if err != nil || len(holder) == 1 {
+		return resolved, err
+	}
+
+	overrides := make(map[string]interface{}, len(holder)-1)
+	for k, v := range holder {
+		if k != "$ref" {
+			overrides[k] = v
+		}
+	}
+	overridden, err := r.resolve(overrides, doc, dir, file, path)
+	if err != nil {
+		return nil, err
+	}
+	base := &YAMLValue{data: resolved}
+	if !base.IsObject() {
+		return nil, opError("resolve_ref", path, ErrNotAnObject, "cannot merge keys next to "+ref+" into "+typeName(resolved))
+	}
+	if err := base.merge(&YAMLValue{data: overridden}); err != nil {
+		return nil, fmt.Errorf("resolve_ref %s: %w", path, err)
+	}
+	return base.data, nil
+}
+
+// resolveFragment follows a JSON Pointer fragment, like "#/a/b" or "/a/b",
+// from root
+func resolveFragment(root *YAMLValue, fragment string) (*YAMLValue, bool) {
+	current := root
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "#"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		next, ok := current.Lookup(token)
+		if index, err := strconv.Atoi(token); err == nil && current.IsArray() {
+			next, ok = current.Lookup(index)
+		}
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
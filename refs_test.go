@@ -0,0 +1,61 @@
+package easyyaml
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveRefs(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "shared"), 0755)
+	os.WriteFile(filepath.Join(dir, "common.yaml"), []byte(`
+definitions:
+  db:
+    host: localhost
+    port: 5432
+    pool: {$ref: "./shared/pool.yaml#/pool"}
+`), 0644)
+	os.WriteFile(filepath.Join(dir, "shared", "pool.yaml"), []byte("pool:\n  size: 10\n  limits: {$ref: '#/limits'}\nlimits: [1, 2]\n"), 0644)
+
+	doc, _ := Loads(`
+primary: {$ref: "./common.yaml#/definitions/db"}
+replica:
+  $ref: "./common.yaml#/definitions/db"
+  host: replica
+ports: [{$ref: "#/defaults/port"}]
+defaults:
+  port: 80
+`)
+
+	resolved, err := doc.ResolveRefs(dir)
+	if err != nil {
+		t.Fatalf("ResolveRefs failed: %v", err)
+	}
+	if resolved.Path("primary.host").AsString() != "localhost" || resolved.Path("primary.pool.size").AsInt() != 10 {
+		t.Errorf("Expected primary inlined, got %v", resolved.Get("primary").Raw())
+	}
+	if !reflect.DeepEqual(resolved.Path("primary.pool.limits").Raw(), []interface{}{1, 2}) {
+		t.Errorf("Expected local ref resolved within the referenced file, got %v", resolved.Path("primary.pool.limits").Raw())
+	}
+	if resolved.Path("replica.host").AsString() != "replica" || resolved.Path("replica.port").AsInt() != 5432 {
+		t.Errorf("Expected sibling keys merged over the ref, got %v", resolved.Get("replica").Raw())
+	}
+	if resolved.Path("ports.0").AsInt() != 80 {
+		t.Errorf("Expected local ref resolved, got %v", resolved.Get("ports").Raw())
+	}
+	if !doc.Path("primary").Has("$ref") {
+		t.Errorf("Expected the original document unchanged")
+	}
+
+	cycle, _ := Loads("a: {$ref: '#/b'}\nb: {$ref: '#/a'}\n")
+	if _, err := cycle.ResolveRefs(dir); !errors.Is(err, ErrRefCycle) {
+		t.Errorf("Expected ErrRefCycle, got %v", err)
+	}
+	missing, _ := Loads("a: {$ref: '#/nowhere'}\n")
+	if _, err := missing.ResolveRefs(dir); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package easyyaml
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// SchemaRegistry maps document kinds to JSON Schemas so heterogeneous
+// documents, such as the manifests of a multi-document stream, can each be
+// validated against the right schema
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]*YAMLValue
+}
+
+// NewSchemaRegistry creates an empty registry
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: make(map[string]*YAMLValue)}
+}
+
+// Register adds the schema for a kind. The kind is matched against a
+// document's "$schema" value, its "apiVersion/kind" (e.g. "apps/v1/Deployment")
+// or its bare "kind", in that order.
+// Usage: registry.Register("apps/v1/Deployment", deploymentSchema)
+func (r *SchemaRegistry) Register(kind string, schema *YAMLValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[kind] = schema
+}
+
+// Lookup returns the schema for a document and the kind it was found under
+func (r *SchemaRegistry) Lookup(doc *YAMLValue) (*YAMLValue, string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, kind := range documentKinds(doc) {
+		if schema, ok := r.schemas[kind]; ok {
+			return schema, kind, true
+		}
+	}
+	return nil, "", false
+}
+
+// Validate validates doc against the schema registered for its kind. It
+// returns an error wrapping ErrNoSchema if there is none.
+func (r *SchemaRegistry) Validate(doc *YAMLValue) error {
+	return r.ValidateContext(context.Background(), doc)
+}
+
+// validate implements Validate, tracing the document's validation as a
+// child span of ctx
+func (r *SchemaRegistry) validate(ctx context.Context, doc *YAMLValue, span Span) error {
+	schema, kind, ok := r.Lookup(doc)
+	if !ok {
+		kinds := documentKinds(doc)
+		err := fmt.Errorf("%w: document has no $schema or kind", ErrNoSchema)
+		if len(kinds) > 0 {
+			err = fmt.Errorf("%w: for %s", ErrNoSchema, kinds[0])
+		}
+		logWarn("easyyaml: validation failed", err)
+		return err
+	}
+	span.SetAttribute("yaml.kind", kind)
+	return doc.ValidateContext(ctx, schema)
+}
+
+// ValidateAll validates every document of a stream and returns a
+// *MultiError whose paths are prefixed with the document index
+func (r *SchemaRegistry) ValidateAll(docs *Documents) error {
+	return r.ValidateAllContext(context.Background(), docs)
+}
+
+// validateAll implements ValidateAll, parenting each document's span on ctx
+func (r *SchemaRegistry) validateAll(ctx context.Context, docs *Documents) error {
+	c := &ErrorCollector{}
+	docs.Each(func(i int, doc *YAMLValue) {
+		prefix := strconv.Itoa(i)
+		err := r.ValidateContext(ctx, doc)
+		multi, ok := err.(*MultiError)
+		if !ok {
+			c.Add("validate", prefix, err)
+			return
+		}
+		for _, e := range multi.Errors {
+			path := prefix
+			if opErr, ok := e.(*OpError); ok && opErr.Key != nil {
+				path = joinPath(prefix, fmt.Sprintf("%v", opErr.Key))
+			}
+			c.Add("validate", path, e)
+		}
+	})
+	return c.Err()
+}
+
+// documentKinds returns the registry keys a document may match, most
+// specific first
+func documentKinds(doc *YAMLValue) []string {
+	var kinds []string
+	if schema := doc.Get("$schema"); schema.IsString() {
+		kinds = append(kinds, schema.AsString())
+	}
+	if kind := doc.Get("kind"); kind.IsString() {
+		if apiVersion := doc.Get("apiVersion"); apiVersion.IsString() {
+			kinds = append(kinds, apiVersion.AsString()+"/"+kind.AsString())
+		}
+		kinds = append(kinds, kind.AsString())
+	}
+	return kinds
+}
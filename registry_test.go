@@ -0,0 +1,48 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	deployment, _ := Loads("type: object\nrequired: [spec]\n")
+	service, _ := Loads("type: object\nproperties:\n  spec: {required: [ports]}\n")
+	config, _ := Loads("type: object\nrequired: [name]\n")
+
+	registry := NewSchemaRegistry()
+	registry.Register("apps/v1/Deployment", deployment)
+	registry.Register("Service", service)
+	registry.Register("https://example.com/app.schema.json", config)
+
+	docs, _ := LoadAlls(`
+apiVersion: apps/v1
+kind: Deployment
+spec: {}
+---
+apiVersion: v1
+kind: Service
+spec: {}
+---
+$schema: https://example.com/app.schema.json
+`)
+
+	if _, kind, ok := registry.Lookup(docs.Get(1)); !ok || kind != "Service" {
+		t.Errorf("Expected Service schema by bare kind, got %q", kind)
+	}
+	if err := registry.Validate(docs.Get(0)); err != nil {
+		t.Errorf("Expected deployment to validate, got %v", err)
+	}
+
+	err := registry.ValidateAll(docs)
+	expected := "validate 1.spec.ports: schema violation: required property is missing\n" +
+		"validate 2.name: schema violation: required property is missing"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%v", expected, err)
+	}
+
+	unknown, _ := Loads("kind: Secret\n")
+	if err := registry.Validate(unknown); !errors.Is(err, ErrNoSchema) {
+		t.Errorf("Expected ErrNoSchema, got %v", err)
+	}
+}
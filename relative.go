@@ -0,0 +1,58 @@
+package easyyaml
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Parent returns the object or array the value was reached through with
+// Get, Lookup, Path, Query, FindAll or Resolve, or a missing value for a
+// document root or a value built on its own
+// Usage: deployment := doc.FindAll(isContainer).First().Parent().Parent()
+func (yv *YAMLValue) Parent() *YAMLValue {
+	if yv.parent == nil {
+		return missingValue()
+	}
+	return yv.parent
+}
+
+// Resolve navigates from the value along a "/"-separated relative path:
+// ".." moves to the parent, "." stays and any other segment is a key, or an
+// index for arrays. A leading "/" starts from the root the value was
+// reached from. Segments are unescaped as in a JSON Pointer, "~1" for "/"
+// and "~0" for "~". A path that leaves the document or names a missing key
+// gives a missing value.
+// Usage: replicas := container.Resolve("../../replicas").AsInt()
+func (yv *YAMLValue) Resolve(path string) *YAMLValue {
+	current := yv
+	if strings.HasPrefix(path, "/") {
+		for current.parent != nil {
+			current = current.parent
+		}
+	}
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "", ".":
+			continue
+		case "..":
+			if current.parent == nil {
+				return missingValue()
+			}
+			current = current.parent
+			continue
+		}
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		var next *YAMLValue
+		var ok bool
+		if index, err := strconv.Atoi(segment); err == nil && current.IsArray() {
+			next, ok = current.Lookup(index)
+		} else {
+			next, ok = current.Lookup(segment)
+		}
+		if !ok {
+			return missingValue()
+		}
+		current = next
+	}
+	return current
+}
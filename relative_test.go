@@ -0,0 +1,48 @@
+package easyyaml
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	yv, _ := Loads(`spec:
+  replicas: 3
+  template:
+    containers:
+      - name: app
+        image: app:1
+      - name: sidecar
+        image: proxy:2
+"a/b": slash
+`)
+	app := yv.Path("spec.template.containers.0")
+	if got := app.Resolve("../../../replicas").AsInt(); got != 3 {
+		t.Errorf("Expected replicas 3, got %d", got)
+	}
+	if got := app.Resolve("../1/image").AsString(); got != "proxy:2" {
+		t.Errorf("Expected sibling image, got %q", got)
+	}
+	if got := app.Resolve("./name").AsString(); got != "app" {
+		t.Errorf("Expected own name, got %q", got)
+	}
+	if got := app.Resolve("/a~1b").AsString(); got != "slash" {
+		t.Errorf("Expected root lookup, got %q", got)
+	}
+	if app.Resolve("../../../../..").Exists() || app.Resolve("../missing").Exists() {
+		t.Error("Expected a missing value for paths leaving the document")
+	}
+
+	images := yv.Query("spec.template.containers.*.image")
+	if got := images.At(1).Resolve("../name").AsString(); got != "sidecar" {
+		t.Errorf("Expected sidecar for query result, got %q", got)
+	}
+	found := yv.FindAll(func(path string, v *YAMLValue) bool { return v.AsString() == "proxy:2" })
+	if got := found.First().Parent().Parent().Parent().Get("replicas"); got.Exists() {
+		t.Errorf("Expected template, got %v", got.Raw())
+	}
+	if err := found.First().Parent().Parent().Parent().Parent().Set("replicas", 5); err != nil || yv.Path("spec.replicas").AsInt() != 5 {
+		t.Errorf("Expected sibling to be set through Parent, got %v", err)
+	}
+
+	if yv.Parent().Exists() || New(1).Parent().Exists() {
+		t.Error("Expected roots to have no parent")
+	}
+}
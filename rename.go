@@ -0,0 +1,207 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// KeyCase is a naming convention for ConvertKeys
+type KeyCase int
+
+const (
+	// SnakeCase converts keys like "maxRetries" to "max_retries"
+	SnakeCase KeyCase = iota
+	// CamelCase converts keys like "max_retries" to "maxRetries"
+	CamelCase
+	// KebabCase converts keys like "maxRetries" to "max-retries"
+	KebabCase
+)
+
+// RenameKey renames a key of the object at path, keeping its value. Use ""
+// for the root. The new key must not already exist.
+// Usage: cfg.RenameKey("server", "hostname", "host")
+func (yv *YAMLValue) RenameKey(path, oldKey, newKey string) error {
+	logMutation("rename_key", "path", path, "key", oldKey)
+	if err := yv.checkFrozen("rename_key", oldKey); err != nil {
+		return err
+	}
+	target, err := yv.PathE(path)
+	if err != nil {
+		return err
+	}
+	if !target.IsObject() {
+		return opError("rename_key", path, ErrNotAnObject, "")
+	}
+	value, ok := target.Lookup(oldKey)
+	if !ok {
+		return opError("rename_key", oldKey, ErrKeyNotFound, "")
+	}
+	if oldKey == newKey {
+		return nil
+	}
+	if target.Has(newKey) {
+		return opError("rename_key", newKey, ErrKeyExists, "")
+	}
+
+	yv.invalidate()
+	switch m := target.data.(type) {
+	case map[string]interface{}:
+		delete(m, oldKey)
+		m[newKey] = value.data
+	case map[interface{}]interface{}:
+		delete(m, oldKey)
+		m[newKey] = value.data
+	}
+	if yv.meta != nil {
+		from, to := childPath(target.at, oldKey), childPath(target.at, newKey)
+		yv.meta.graft(yv.meta.clone(), from, to)
+		yv.meta.forget(from)
+	}
+	return nil
+}
+
+// ConvertKeys renames every string key, recursively, to the given naming
+// convention. If two keys of one object would convert to the same name, it
+// returns an error wrapping ErrKeyExists and leaves the value unchanged.
+// Usage: cfg.ConvertKeys(easyyaml.SnakeCase)
+func (yv *YAMLValue) ConvertKeys(style KeyCase) error {
+	logMutation("convert_keys")
+	if err := yv.checkFrozen("convert_keys", nil); err != nil {
+		return err
+	}
+	converted, err := convertKeys(yv.data, style, "")
+	if err != nil {
+		return err
+	}
+	yv.invalidate()
+	yv.data = converted
+	return nil
+}
+
+// convertKeys returns a copy of data with its keys converted
+func convertKeys(data interface{}, style KeyCase, path string) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		result := make(map[string]interface{}, len(v))
+		origins := make(map[string]string, len(v))
+		for _, k := range keys {
+			name := style.Convert(k)
+			if origin, exists := origins[name]; exists {
+				return nil, opError("convert_keys", joinPath(path, k), ErrKeyExists,
+					fmt.Sprintf("%q and %q both convert to %q", origin, k, name))
+			}
+			origins[name] = k
+			child, err := convertKeys(v[k], style, joinPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			result[name] = child
+		}
+		return result, nil
+	case map[interface{}]interface{}:
+		keys := make([]interface{}, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%v", keys[i]) < fmt.Sprintf("%v", keys[j])
+		})
+
+		result := make(map[interface{}]interface{}, len(v))
+		origins := make(map[interface{}]interface{}, len(v))
+		for _, k := range keys {
+			name := k
+			if s, ok := k.(string); ok {
+				name = style.Convert(s)
+			}
+			keyPath := joinPath(path, fmt.Sprintf("%v", k))
+			if origin, exists := origins[name]; exists {
+				return nil, opError("convert_keys", keyPath, ErrKeyExists,
+					fmt.Sprintf("%v and %v both convert to %v", origin, k, name))
+			}
+			origins[name] = k
+			child, err := convertKeys(v[k], style, keyPath)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = child
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			child, err := convertKeys(item, style, joinPath(path, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = child
+		}
+		return result, nil
+	}
+	return data, nil
+}
+
+// Convert converts a single name to the naming convention
+func (style KeyCase) Convert(name string) string {
+	words := splitWords(name)
+	switch style {
+	case CamelCase:
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				runes := []rune(word)
+				runes[0] = unicode.ToUpper(runes[0])
+				word = string(runes)
+			}
+			words[i] = word
+		}
+		return strings.Join(words, "")
+	case KebabCase:
+		return strings.ToLower(strings.Join(words, "-"))
+	default:
+		return strings.ToLower(strings.Join(words, "_"))
+	}
+}
+
+// splitWords splits a name at separators and case changes, keeping
+// acronyms together: "HTTPServer_port" gives "HTTP", "Server", "port"
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		boundary := unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) ||
+			unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	if len(words) == 0 {
+		return []string{name}
+	}
+	return words
+}
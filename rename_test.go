@@ -0,0 +1,72 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenameKey(t *testing.T) {
+	yv, _ := Loads(`
+server:
+  hostname: example.com
+  port: 80
+`)
+
+	if err := yv.RenameKey("server", "hostname", "host"); err != nil {
+		t.Fatalf("RenameKey failed: %v", err)
+	}
+	if yv.Path("server.host").AsString() != "example.com" || yv.Path("server").Has("hostname") {
+		t.Errorf("Expected hostname renamed to host, got %v", yv.Get("server").Raw())
+	}
+
+	if err := yv.RenameKey("server", "missing", "x"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+	if err := yv.RenameKey("server", "host", "port"); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Expected ErrKeyExists, got %v", err)
+	}
+	if err := yv.RenameKey("server.port", "a", "b"); !errors.Is(err, ErrNotAnObject) {
+		t.Errorf("Expected ErrNotAnObject, got %v", err)
+	}
+	if err := yv.RenameKey("", "server", "backend"); err != nil || !yv.Has("backend") {
+		t.Errorf("Expected root key renamed, got %v, %v", err, yv.Raw())
+	}
+}
+
+func TestConvertKeys(t *testing.T) {
+	yv, _ := Loads(`
+maxRetries: 3
+HTTPServer:
+  listen_addr: ":80"
+  tls-cert: a.pem
+items:
+  - itemName: x
+`)
+
+	if err := yv.ConvertKeys(SnakeCase); err != nil {
+		t.Fatalf("ConvertKeys failed: %v", err)
+	}
+	for _, path := range []string{"max_retries", "http_server.listen_addr", "http_server.tls_cert", "items.0.item_name"} {
+		if !yv.Path(path).Exists() {
+			t.Errorf("Expected %s after SnakeCase, got %v", path, yv.Raw())
+		}
+	}
+
+	yv.ConvertKeys(CamelCase)
+	if !yv.Path("httpServer.tlsCert").Exists() || !yv.Has("maxRetries") {
+		t.Errorf("Expected camelCase keys, got %v", yv.Raw())
+	}
+
+	yv.ConvertKeys(KebabCase)
+	if yv.Path("http-server.listen-addr").AsString() != ":80" {
+		t.Errorf("Expected kebab-case keys, got %v", yv.Raw())
+	}
+
+	clash, _ := Loads("max_retries: 1\nmaxRetries: 2\n")
+	if err := clash.ConvertKeys(SnakeCase); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Expected ErrKeyExists, got %v", err)
+	}
+	if !clash.Has("maxRetries") {
+		t.Errorf("Expected value unchanged after a clash, got %v", clash.Raw())
+	}
+}
@@ -0,0 +1,79 @@
+package easyyaml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var renderPattern = regexp.MustCompile(`\{\{\s*\.?([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// Render returns a copy of the document with {{name}} placeholders in
+// string scalars replaced from vars. Names may be dot-separated paths into
+// nested maps. A scalar that is exactly one placeholder takes the variable's
+// value as is, so "{{replicas}}" can become a number or an object; otherwise
+// values are formatted as with AsString. Nothing is executed. Undefined
+// names are left in place and returned as a *MultiError wrapping
+// ErrUndefinedVariable.
+// Usage: out, err := tmpl.Render(map[string]interface{}{"env": "prod", "replicas": 3})
+func (yv *YAMLValue) Render(vars map[string]interface{}) (*YAMLValue, error) {
+	lookup, err := normalize(vars)
+	if err != nil {
+		return nil, err
+	}
+	scope := &YAMLValue{data: lookup}
+	c := &ErrorCollector{}
+	data := renderData(deepCopy(yv.data), scope, "", c)
+	return &YAMLValue{data: data, opts: yv.opts, directives: yv.directives}, c.Err()
+}
+
+// renderData substitutes placeholders in data in place
+func renderData(data interface{}, scope *YAMLValue, path string, c *ErrorCollector) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = renderData(child, scope, joinPath(path, k), c)
+		}
+	case map[interface{}]interface{}:
+		for k, child := range v {
+			v[k] = renderData(child, scope, joinPath(path, fmt.Sprintf("%v", k)), c)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = renderData(child, scope, joinPath(path, strconv.Itoa(i)), c)
+		}
+	case string:
+		return renderString(v, scope, path, c)
+	}
+	return data
+}
+
+// renderString substitutes the placeholders of one scalar
+func renderString(s string, scope *YAMLValue, path string, c *ErrorCollector) interface{} {
+	if m := renderPattern.FindStringSubmatchIndex(s); m != nil && m[0] == 0 && m[1] == len(s) {
+		if value, ok := renderLookup(scope, s[m[2]:m[3]]); ok {
+			return deepCopy(value.data)
+		}
+		c.Add("render", path, fmt.Errorf("%w: %s", ErrUndefinedVariable, s[m[2]:m[3]]))
+		return s
+	}
+	return renderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := renderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := renderLookup(scope, name)
+		if !ok {
+			c.Add("render", path, fmt.Errorf("%w: %s", ErrUndefinedVariable, name))
+			return placeholder
+		}
+		return value.AsString()
+	})
+}
+
+// renderLookup finds a variable by name, trying the whole name before
+// treating it as a dot-separated path
+func renderLookup(scope *YAMLValue, name string) (*YAMLValue, bool) {
+	if value, ok := scope.Lookup(name); ok {
+		return value, true
+	}
+	value, err := scope.PathE(name)
+	return value, err == nil
+}
@@ -0,0 +1,53 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tmpl, _ := Loads(`
+name: "{{app}}-{{ env }}"
+replicas: "{{replicas}}"
+db: "{{db}}"
+url: "postgres://{{db.host}}:{{db.port}}/{{ .app }}"
+literal: "{{not closed"
+`)
+	vars := map[string]interface{}{
+		"app":      "shop",
+		"env":      "prod",
+		"replicas": 3,
+		"db":       map[string]interface{}{"host": "db.internal", "port": 5432},
+	}
+
+	out, err := tmpl.Render(vars)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if out.Get("name").AsString() != "shop-prod" {
+		t.Errorf("Expected shop-prod, got %v", out.Get("name").Raw())
+	}
+	if out.Get("replicas").Raw() != 3 {
+		t.Errorf("Expected typed replicas, got %#v", out.Get("replicas").Raw())
+	}
+	if out.Path("db.port").AsInt() != 5432 {
+		t.Errorf("Expected db object, got %v", out.Get("db").Raw())
+	}
+	if out.Get("url").AsString() != "postgres://db.internal:5432/shop" {
+		t.Errorf("Unexpected url: %v", out.Get("url").Raw())
+	}
+	if out.Get("literal").AsString() != "{{not closed" {
+		t.Errorf("Expected text without placeholders unchanged, got %v", out.Get("literal").Raw())
+	}
+	if tmpl.Get("name").AsString() != "{{app}}-{{ env }}" {
+		t.Errorf("Expected template unchanged")
+	}
+
+	out, err = tmpl.Render(map[string]interface{}{"app": "shop"})
+	if !errors.Is(err, ErrUndefinedVariable) {
+		t.Fatalf("Expected ErrUndefinedVariable, got %v", err)
+	}
+	if out.Get("name").AsString() != "shop-{{ env }}" {
+		t.Errorf("Expected undefined placeholder left in place, got %v", out.Get("name").Raw())
+	}
+}
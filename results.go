@@ -0,0 +1,192 @@
+package easyyaml
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Results holds the values matched by a multi-match query together with
+// the dot-separated path of each match
+type Results struct {
+	values []*YAMLValue
+	paths  []string
+}
+
+// add appends a match to the result set
+func (r *Results) add(path string, value *YAMLValue) {
+	r.paths = append(r.paths, path)
+	r.values = append(r.values, value)
+}
+
+// Len returns the number of matches
+func (r *Results) Len() int {
+	return len(r.values)
+}
+
+// IsEmpty checks if the query matched nothing
+func (r *Results) IsEmpty() bool {
+	return len(r.values) == 0
+}
+
+// First returns the first match, or a missing value if there are none
+func (r *Results) First() *YAMLValue {
+	if len(r.values) == 0 {
+		return missingValue()
+	}
+	return r.values[0]
+}
+
+// At returns the match at index i, or a missing value if out of range
+func (r *Results) At(i int) *YAMLValue {
+	if i < 0 || i >= len(r.values) {
+		return missingValue()
+	}
+	return r.values[i]
+}
+
+// Values returns all matched values
+func (r *Results) Values() []*YAMLValue {
+	values := make([]*YAMLValue, len(r.values))
+	copy(values, r.values)
+	return values
+}
+
+// Paths returns the dot-separated path of every match
+func (r *Results) Paths() []string {
+	paths := make([]string, len(r.paths))
+	copy(paths, r.paths)
+	return paths
+}
+
+// Strings returns every match converted with AsString
+func (r *Results) Strings() []string {
+	strs := make([]string, len(r.values))
+	for i, v := range r.values {
+		strs[i] = v.AsString()
+	}
+	return strs
+}
+
+// Ints returns every match converted with AsInt
+func (r *Results) Ints() []int {
+	ints := make([]int, len(r.values))
+	for i, v := range r.values {
+		ints[i] = v.AsInt()
+	}
+	return ints
+}
+
+// Each calls fn for every match in order
+func (r *Results) Each(fn func(path string, value *YAMLValue)) {
+	for i, v := range r.values {
+		fn(r.paths[i], v)
+	}
+}
+
+// FindAll walks the document depth-first and returns every node for which
+// pred returns true. Object keys are visited in sorted order.
+func (yv *YAMLValue) FindAll(pred func(path string, value *YAMLValue) bool) *Results {
+	results := &Results{}
+	yv.walk("", func(path string, value *YAMLValue) {
+		if pred(path, value) {
+			results.add(path, value)
+		}
+	})
+	return results
+}
+
+// Query retrieves all values matching a dot-separated path where a "*"
+// segment matches every child of an object or array
+// Usage: data.Query("spec.containers.*.image").Strings()
+func (yv *YAMLValue) Query(path string) *Results {
+	type match struct {
+		path  string
+		value *YAMLValue
+	}
+
+	current := []match{{path: "", value: yv}}
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+
+		var next []match
+		for _, m := range current {
+			if part == "*" {
+				m.value.eachChild(func(key string, child *YAMLValue) {
+					next = append(next, match{path: joinPath(m.path, key), value: child})
+				})
+				continue
+			}
+
+			var child *YAMLValue
+			var ok bool
+			if index, err := strconv.Atoi(part); err == nil {
+				child, ok = m.value.Lookup(index)
+			} else {
+				child, ok = m.value.Lookup(part)
+			}
+			if ok {
+				next = append(next, match{path: joinPath(m.path, part), value: child})
+			}
+		}
+		current = next
+	}
+
+	results := &Results{}
+	for _, m := range current {
+		results.add(m.path, m.value)
+	}
+	return results
+}
+
+// walk visits the value and all of its descendants depth-first
+func (yv *YAMLValue) walk(path string, fn func(path string, value *YAMLValue)) {
+	fn(path, yv)
+	yv.eachChild(func(key string, child *YAMLValue) {
+		child.walk(joinPath(path, key), fn)
+	})
+}
+
+// eachChild calls fn for every direct child of an object or array,
+// visiting object keys in sorted order. The children know yv as their
+// parent but share nothing else with it.
+func (yv *YAMLValue) eachChild(fn func(key string, child *YAMLValue)) {
+	switch v := yv.data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fn(k, &YAMLValue{data: v[k], parent: yv, key: k})
+		}
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(v))
+		lookup := make(map[string]interface{}, len(v))
+		for k := range v {
+			keyStr := fmt.Sprintf("%v", k)
+			keys = append(keys, keyStr)
+			lookup[keyStr] = k
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fn(k, &YAMLValue{data: v[lookup[k]], parent: yv, key: lookup[k]})
+		}
+	case []interface{}:
+		for i, val := range v {
+			fn(strconv.Itoa(i), &YAMLValue{data: val, parent: yv, key: i})
+		}
+	}
+}
+
+// joinPath appends a segment to a dot-separated path
+func joinPath(base, segment string) string {
+	if base == "" {
+		return segment
+	}
+	return base + "." + segment
+}
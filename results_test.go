@@ -0,0 +1,78 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+const podYAML = `
+spec:
+  containers:
+    - name: app
+      image: app:1.0
+      port: 8080
+    - name: sidecar
+      image: proxy:2.3
+      port: 9090
+`
+
+func TestQueryWildcard(t *testing.T) {
+	yv, err := Loads(podYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	results := yv.Query("spec.containers.*.image")
+	if results.Len() != 2 {
+		t.Fatalf("Expected 2 matches, got %d", results.Len())
+	}
+
+	images := results.Strings()
+	if images[0] != "app:1.0" || images[1] != "proxy:2.3" {
+		t.Errorf("Unexpected images: %v", images)
+	}
+
+	paths := results.Paths()
+	if paths[1] != "spec.containers.1.image" {
+		t.Errorf("Expected path 'spec.containers.1.image', got %s", paths[1])
+	}
+
+	ports := yv.Query("spec.containers.*.port").Ints()
+	if ports[0] != 8080 || ports[1] != 9090 {
+		t.Errorf("Unexpected ports: %v", ports)
+	}
+
+	if yv.Query("spec.missing.*").Len() != 0 {
+		t.Error("Expected no matches for missing path")
+	}
+
+	if yv.Query("spec.missing").First().Exists() {
+		t.Error("Expected First on empty results to not exist")
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	yv, err := Loads(podYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	results := yv.FindAll(func(path string, value *YAMLValue) bool {
+		return value.Has("name")
+	})
+
+	if results.Len() != 2 {
+		t.Fatalf("Expected 2 matches, got %d", results.Len())
+	}
+
+	if results.First().Get("name").AsString() != "app" {
+		t.Errorf("Expected first match to be 'app', got %s", results.First().Get("name").AsString())
+	}
+
+	count := 0
+	results.Each(func(path string, value *YAMLValue) {
+		count++
+	})
+	if count != 2 {
+		t.Errorf("Expected Each to visit 2 matches, got %d", count)
+	}
+}
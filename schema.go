@@ -0,0 +1,188 @@
+package easyyaml
+
+import (
+	"reflect"
+	"sort"
+)
+
+// jsonSchemaDraft is the dialect declared by InferSchema
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// InferSchema produces a JSON Schema describing the observed structure and
+// types of the document. Every key seen in an object is listed under
+// properties; keys present in every observed instance are required.
+func (yv *YAMLValue) InferSchema() *YAMLValue {
+	schema := inferSchema(yv.data)
+	schema["$schema"] = jsonSchemaDraft
+	return &YAMLValue{data: schema}
+}
+
+// inferSchema builds the schema for a single raw value
+func inferSchema(data interface{}) map[string]interface{} {
+	value := &YAMLValue{data: data}
+	switch {
+	case value.IsObject():
+		properties := make(map[string]interface{})
+		var required []string
+		value.eachChild(func(key string, child *YAMLValue) {
+			properties[key] = inferSchema(child.data)
+			required = append(required, key)
+		})
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) > 0 {
+			schema["required"] = toInterfaceSlice(required)
+		}
+		return schema
+	case value.IsArray():
+		schema := map[string]interface{}{"type": "array"}
+		var items map[string]interface{}
+		for _, item := range value.AsArray() {
+			itemSchema := inferSchema(item.data)
+			if items == nil {
+				items = itemSchema
+			} else {
+				items = mergeSchemas(items, itemSchema)
+			}
+		}
+		if items != nil {
+			schema["items"] = items
+		}
+		return schema
+	}
+	return map[string]interface{}{"type": scalarSchemaType(data)}
+}
+
+// scalarSchemaType maps a raw scalar to its JSON Schema type name
+func scalarSchemaType(data interface{}) string {
+	switch data.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case int, int64, uint64:
+		return "integer"
+	}
+	if _, ok := toFloat(data); ok {
+		return "number"
+	}
+	return "string"
+}
+
+// mergeSchemas combines two inferred schemas into one that accepts both
+func mergeSchemas(a, b map[string]interface{}) map[string]interface{} {
+	if reflect.DeepEqual(a, b) {
+		return a
+	}
+
+	aType, aOK := a["type"].(string)
+	bType, bOK := b["type"].(string)
+	if aOK && bOK && aType == bType {
+		switch aType {
+		case "object":
+			return mergeObjectSchemas(a, b)
+		case "array":
+			merged := map[string]interface{}{"type": "array"}
+			aItems, aHas := a["items"].(map[string]interface{})
+			bItems, bHas := b["items"].(map[string]interface{})
+			switch {
+			case aHas && bHas:
+				merged["items"] = mergeSchemas(aItems, bItems)
+			case aHas:
+				merged["items"] = aItems
+			case bHas:
+				merged["items"] = bItems
+			}
+			return merged
+		}
+		return a
+	}
+
+	// Numbers subsume integers
+	if aOK && bOK && ((aType == "integer" && bType == "number") || (aType == "number" && bType == "integer")) {
+		return map[string]interface{}{"type": "number"}
+	}
+
+	var variants []interface{}
+	for _, s := range []map[string]interface{}{a, b} {
+		if anyOf, ok := s["anyOf"].([]interface{}); ok {
+			variants = append(variants, anyOf...)
+		} else {
+			variants = append(variants, s)
+		}
+	}
+
+	var unique []interface{}
+	for _, v := range variants {
+		duplicate := false
+		for _, u := range unique {
+			if reflect.DeepEqual(u, v) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, v)
+		}
+	}
+	if len(unique) == 1 {
+		return unique[0].(map[string]interface{})
+	}
+	return map[string]interface{}{"anyOf": unique}
+}
+
+// mergeObjectSchemas unions properties and intersects required keys
+func mergeObjectSchemas(a, b map[string]interface{}) map[string]interface{} {
+	aProps, _ := a["properties"].(map[string]interface{})
+	bProps, _ := b["properties"].(map[string]interface{})
+
+	properties := make(map[string]interface{})
+	for k, v := range aProps {
+		properties[k] = v
+	}
+	for k, v := range bProps {
+		if existing, ok := properties[k].(map[string]interface{}); ok {
+			properties[k] = mergeSchemas(existing, v.(map[string]interface{}))
+		} else {
+			properties[k] = v
+		}
+	}
+
+	inB := make(map[interface{}]bool)
+	if req, ok := b["required"].([]interface{}); ok {
+		for _, k := range req {
+			inB[k] = true
+		}
+	}
+	var required []string
+	if req, ok := a["required"].([]interface{}); ok {
+		for _, k := range req {
+			if inB[k] {
+				required = append(required, k.(string))
+			}
+		}
+	}
+
+	merged := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		merged["required"] = toInterfaceSlice(required)
+	}
+	return merged
+}
+
+// toInterfaceSlice converts a string slice to the generic slice form used in documents
+func toInterfaceSlice(strs []string) []interface{} {
+	result := make([]interface{}, len(strs))
+	for i, s := range strs {
+		result[i] = s
+	}
+	return result
+}
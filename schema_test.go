@@ -0,0 +1,72 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	yv, err := Loads(testYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	schema := yv.InferSchema()
+
+	if schema.Get("$schema").AsString() != jsonSchemaDraft {
+		t.Errorf("Expected $schema to be set, got %s", schema.Get("$schema").AsString())
+	}
+
+	tests := map[string]string{
+		"type":                                              "object",
+		"properties.name.type":                              "string",
+		"properties.age.type":                               "integer",
+		"properties.address.type":                           "object",
+		"properties.address.properties.zip.type":            "integer",
+		"properties.hobbies.type":                           "array",
+		"properties.hobbies.items.type":                     "string",
+		"properties.settings.properties.notifications.type": "boolean",
+	}
+	for path, expected := range tests {
+		if got := schema.Path(path).AsString(); got != expected {
+			t.Errorf("Expected %s to be %s, got %s", path, expected, got)
+		}
+	}
+
+	if schema.Get("required").Len() != 6 {
+		t.Errorf("Expected 6 required keys, got %d", schema.Get("required").Len())
+	}
+}
+
+func TestInferSchemaMergesArrayItems(t *testing.T) {
+	yv, err := Loads(`
+users:
+  - name: alice
+    age: 30
+  - name: bob
+    email: bob@example.com
+values: [1, 2.5]
+mixed: [1, text]
+`)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	schema := yv.InferSchema()
+
+	items := schema.Path("properties.users.items")
+	if !items.Path("properties.age").Exists() || !items.Path("properties.email").Exists() {
+		t.Error("Expected item properties to be unioned")
+	}
+	required := items.Get("required")
+	if required.Len() != 1 || required.Get(0).AsString() != "name" {
+		t.Errorf("Expected only 'name' to be required, got %v", required.Raw())
+	}
+
+	if got := schema.Path("properties.values.items.type").AsString(); got != "number" {
+		t.Errorf("Expected mixed numbers to infer 'number', got %s", got)
+	}
+
+	if schema.Path("properties.mixed.items.anyOf").Len() != 2 {
+		t.Error("Expected mixed types to infer anyOf")
+	}
+}
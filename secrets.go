@@ -0,0 +1,128 @@
+package easyyaml
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultSecretPatterns are the patterns SecretsPaths uses when none are
+// given
+var DefaultSecretPatterns = []string{"password", "passwd", "token", "secret", "credentials", "api_key", "apikey", "access_key", "private_key", "signing_key", "encryption_key"}
+
+// SecretsPaths returns the sorted paths of the scalar values that look
+// like secrets, so CI systems can mask them in logs. A value is a secret
+// when its key, or the key of an object or array containing it, matches
+// one of the patterns, or DefaultSecretPatterns if none are given. A plain
+// pattern matches a run of words of the key case-insensitively, so
+// "api_key" matches "api_key", "apiKey" and "github-api-key" but not
+// "primary_key"; a pattern with *, ? or [ is a glob matched against the
+// whole lower-cased key. Null and empty values are left out. Dots and
+// backslashes within keys are escaped with a backslash, as in
+// AccessedPaths.
+// Usage: for _, p := range cfg.SecretsPaths() { fmt.Printf("::add-mask::%s\n", cfg.Path(p).AsString()) }
+func (yv *YAMLValue) SecretsPaths(patterns ...string) []string {
+	if len(patterns) == 0 {
+		patterns = DefaultSecretPatterns
+	}
+	var paths []string
+	var walk func(data interface{}, at string, secret bool)
+	walk = func(data interface{}, at string, secret bool) {
+		switch v := data.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				walk(child, childPath(at, k), secret || secretKey(k, patterns))
+			}
+		case map[interface{}]interface{}:
+			for k, child := range v {
+				key := fmt.Sprintf("%v", k)
+				walk(child, childPath(at, key), secret || secretKey(key, patterns))
+			}
+		case []interface{}:
+			for i, child := range v {
+				walk(child, joinPath(at, strconv.Itoa(i)), secret)
+			}
+		case nil:
+		case string:
+			if secret && v != "" {
+				paths = append(paths, at)
+			}
+		default:
+			if secret {
+				paths = append(paths, at)
+			}
+		}
+	}
+	walk(yv.data, "", false)
+	sort.Strings(paths)
+	return paths
+}
+
+// secretKey reports whether a key matches one of the patterns
+func secretKey(key string, patterns []string) bool {
+	lower := strings.ToLower(key)
+	words := keyWords(key)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if strings.ContainsAny(pattern, "*?[") {
+			if ok, _ := path.Match(pattern, lower); ok {
+				return true
+			}
+			continue
+		}
+		if lower == pattern || containsWords(words, keyWords(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWords reports whether run appears in words as consecutive words
+func containsWords(words, run []string) bool {
+	if len(run) == 0 {
+		return false
+	}
+	for i := 0; i+len(run) <= len(words); i++ {
+		match := true
+		for j, word := range run {
+			if words[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// keyWords splits a key into lower-cased words at punctuation and
+// camelCase boundaries, e.g. "dbPassword_v2" into db, password and v2
+func keyWords(key string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(key)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])) {
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
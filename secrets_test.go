@@ -0,0 +1,45 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecretsPaths(t *testing.T) {
+	yv, _ := Loads(`database:
+  host: db.local
+  password: hunter2
+  apiKey: abc
+  monkey: banana
+  ssh-key: ""
+  private_key_path: null
+credentials:
+  - user: ci
+    pin: 1234
+tokens: [a, b]
+AWSSecretAccessKey: xyz
+githubToken: ghp
+keyboard: us
+primary_key: id
+sort_key: name
+stripe-api-key: sk
+smtp.password: pw
+`)
+	expected := []string{
+		"AWSSecretAccessKey",
+		"credentials.0.pin",
+		"credentials.0.user",
+		"database.apiKey",
+		"database.password",
+		"githubToken",
+		`smtp\.password`,
+		"stripe-api-key",
+	}
+	if got := yv.SecretsPaths(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := yv.SecretsPaths("tokens", "*host*"); !reflect.DeepEqual(got, []string{"database.host", "tokens.0", "tokens.1"}) {
+		t.Errorf("Unexpected paths for custom patterns: %v", got)
+	}
+}
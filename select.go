@@ -0,0 +1,174 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Select returns a new document containing only the given dot-separated
+// paths, keeping the structure around them. A "*" in a segment matches any
+// run of characters, so "containers.*.image" selects the image of every
+// container. Selected array elements keep their order but are compacted.
+// Usage: view := deploy.Select("metadata.name", "spec.template.spec.containers.*.image")
+func (yv *YAMLValue) Select(paths ...string) *YAMLValue {
+	selected, ok := selectPaths(yv.data, splitPaths(paths))
+	if !ok {
+		selected = map[string]interface{}{}
+	}
+	return &YAMLValue{data: selected, opts: yv.opts, directives: yv.directives}
+}
+
+// selectPaths copies the parts of data matched by paths
+func selectPaths(data interface{}, paths [][]string) (interface{}, bool) {
+	for _, path := range paths {
+		if len(path) == 0 {
+			return deepCopy(data), true
+		}
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, child := range v {
+			if selected, ok := selectPaths(child, matchingTails(paths, k)); ok {
+				result[k] = selected
+			}
+		}
+		return result, len(result) > 0
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{})
+		for k, child := range v {
+			if selected, ok := selectPaths(child, matchingTails(paths, fmt.Sprintf("%v", k))); ok {
+				result[k] = selected
+			}
+		}
+		return result, len(result) > 0
+	case []interface{}:
+		var result []interface{}
+		for i, child := range v {
+			if selected, ok := selectPaths(child, matchingTails(paths, strconv.Itoa(i))); ok {
+				result = append(result, selected)
+			}
+		}
+		return result, len(result) > 0
+	}
+	return nil, false
+}
+
+// Without returns a copy of the document with the given dot-separated paths
+// removed. Paths use the same "*" wildcards as Select; removed array
+// elements are dropped and the remaining ones shift down, keeping their
+// styles and tags.
+// Usage: clean := live.Without("status", "metadata.managedFields", "metadata.annotations.kubectl*")
+func (yv *YAMLValue) Without(paths ...string) *YAMLValue {
+	meta := &docMeta{}
+	if yv.meta != nil {
+		meta.graft(yv.meta, yv.at, "")
+	}
+	data := deepCopy(yv.data)
+	data = removePaths(data, splitPaths(paths), meta, "")
+	return &YAMLValue{data: data, opts: yv.opts, directives: yv.directives, meta: meta}
+}
+
+// removePaths deletes the parts of data, which is at path, matched by paths
+// in place, dropping or moving their node details in meta
+func removePaths(data interface{}, paths [][]string, meta *docMeta, path string) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if tails, remove := splitTails(matchingTails(paths, k)); remove {
+				delete(v, k)
+				meta.forget(childPath(path, k))
+			} else if len(tails) > 0 {
+				v[k] = removePaths(child, tails, meta, childPath(path, k))
+			}
+		}
+	case map[interface{}]interface{}:
+		for k, child := range v {
+			if tails, remove := splitTails(matchingTails(paths, fmt.Sprintf("%v", k))); remove {
+				delete(v, k)
+				meta.forget(childPath(path, k))
+			} else if len(tails) > 0 {
+				v[k] = removePaths(child, tails, meta, childPath(path, k))
+			}
+		}
+	case []interface{}:
+		kept := v[:0]
+		from := make([]int, 0, len(v))
+		for i, child := range v {
+			tails, remove := splitTails(matchingTails(paths, strconv.Itoa(i)))
+			if remove {
+				continue
+			}
+			if len(tails) > 0 {
+				child = removePaths(child, tails, meta, childPath(path, i))
+			}
+			kept = append(kept, child)
+			from = append(from, i)
+		}
+		meta.reindex(path, from)
+		return kept
+	}
+	return data
+}
+
+// splitTails reports whether any tail is empty, meaning the value itself
+// matched, and returns the tails unchanged otherwise
+func splitTails(tails [][]string) ([][]string, bool) {
+	for _, tail := range tails {
+		if len(tail) == 0 {
+			return nil, true
+		}
+	}
+	return tails, false
+}
+
+// splitPaths splits dot-separated paths into segments
+func splitPaths(paths []string) [][]string {
+	split := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		var segments []string
+		for _, part := range strings.Split(path, ".") {
+			if part != "" {
+				segments = append(segments, part)
+			}
+		}
+		split = append(split, segments)
+	}
+	return split
+}
+
+// matchingTails returns the remaining segments of paths whose first
+// segment matches key
+func matchingTails(paths [][]string, key string) [][]string {
+	var tails [][]string
+	for _, path := range paths {
+		if len(path) > 0 && matchSegment(path[0], key) {
+			tails = append(tails, path[1:])
+		}
+	}
+	return tails
+}
+
+// matchSegment matches key against a pattern where "*" matches any run of
+// characters, including dots and slashes
+func matchSegment(pattern, key string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == key
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(key, part)
+		if i < 0 {
+			return false
+		}
+		key = key[i+len(part):]
+	}
+	return len(key) >= len(last) && strings.HasSuffix(key, last)
+}
@@ -0,0 +1,109 @@
+package easyyaml
+
+import "testing"
+
+func TestSelect(t *testing.T) {
+	yv, _ := Loads(`
+metadata:
+  name: web
+  labels: {app: web}
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:1
+        - name: sidecar
+          image: proxy:2
+`)
+
+	view := yv.Select("metadata.name", "spec.replicas", "spec.template.spec.containers.*.image")
+	out, _ := view.WithIndent(2).Dumps()
+	expected := `metadata:
+  name: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - image: app:1
+        - image: proxy:2
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	view.Path("metadata").Set("name", "changed")
+	if yv.Path("metadata.name").AsString() != "web" {
+		t.Errorf("Expected Select to copy values, original changed to %v", yv.Path("metadata.name").Raw())
+	}
+
+	if whole := yv.Select("metadata"); !whole.Path("metadata.labels.app").Exists() {
+		t.Errorf("Expected whole subtree selected, got %v", whole.Raw())
+	}
+	if none := yv.Select("missing.path"); none.Len() != 0 {
+		t.Errorf("Expected empty document, got %v", none.Raw())
+	}
+}
+
+func TestMatchSegment(t *testing.T) {
+	tests := []struct {
+		pattern, key string
+		expected     bool
+	}{
+		{"name", "name", true},
+		{"name", "names", false},
+		{"*", "anything", true},
+		{"kubectl*", "kubectl.kubernetes.io/last-applied-configuration", true},
+		{"*-config", "app-config", true},
+		{"a*b*c", "a1b2c", true},
+		{"a*b*c", "a1c2b", false},
+	}
+	for _, tt := range tests {
+		if got := matchSegment(tt.pattern, tt.key); got != tt.expected {
+			t.Errorf("Expected matchSegment(%q, %q) = %v, got %v", tt.pattern, tt.key, tt.expected, got)
+		}
+	}
+}
+
+func TestWithout(t *testing.T) {
+	yv, _ := Loads(`
+metadata:
+  name: web
+  managedFields: [{manager: kubectl}]
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+    team: payments
+spec:
+  ports:
+    - port: 80
+      nodePort: 30080
+    - port: 443
+      nodePort: 30443
+status:
+  ready: true
+`)
+
+	clean := yv.Without("status", "metadata.managedFields", "metadata.annotations.kubectl*", "spec.ports.*.nodePort")
+	out, _ := clean.WithIndent(2).Dumps()
+	expected := `metadata:
+  annotations:
+    team: payments
+  name: web
+spec:
+  ports:
+    - port: 80
+    - port: 443
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+	if !yv.Has("status") || !yv.Path("spec.ports.0").Has("nodePort") {
+		t.Errorf("Expected original unchanged, got %v", yv.Raw())
+	}
+
+	if trimmed := yv.Without("spec.ports.0"); trimmed.Path("spec.ports").Len() != 1 || trimmed.Path("spec.ports.0.port").AsInt() != 443 {
+		t.Errorf("Expected first port removed, got %v", trimmed.Path("spec.ports").Raw())
+	}
+}
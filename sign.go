@@ -0,0 +1,81 @@
+package easyyaml
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// SignatureKey is the top-level key Sign stores the signature under
+const SignatureKey = "x-easyyaml-signature"
+
+// Verifier checks a signature over a message, the canonical form of a
+// document. Verifiers for RSA or ECDSA keys must hash it with SHA-256 first.
+type Verifier interface {
+	Verify(message, signature []byte) error
+}
+
+// VerifierFunc adapts a function to the Verifier interface
+type VerifierFunc func(message, signature []byte) error
+
+// Verify calls f(message, signature)
+func (f VerifierFunc) Verify(message, signature []byte) error {
+	return f(message, signature)
+}
+
+// Ed25519Verifier returns a Verifier for signatures made by Sign with the
+// private key matching pub
+func Ed25519Verifier(pub ed25519.PublicKey) Verifier {
+	return VerifierFunc(func(message, signature []byte) error {
+		if !ed25519.Verify(pub, message, signature) {
+			return ErrBadSignature
+		}
+		return nil
+	})
+}
+
+// Sign signs the canonical form of doc (see Checksum), which keeps the type
+// of every scalar, and stores the base64 signature under SignatureKey. Ed25519 signers sign the message
+// itself; other signers, such as RSA or ECDSA keys, sign its SHA-256 digest.
+// Usage: easyyaml.Sign(policy, ed25519PrivateKey)
+func Sign(doc *YAMLValue, signer crypto.Signer) error {
+	message, err := doc.canonical(ChecksumKey, SignatureKey)
+	if err != nil {
+		return err
+	}
+
+	var sig []byte
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		sig, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(message)
+		sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sign document: %w", err)
+	}
+	return doc.Set(SignatureKey, base64.StdEncoding.EncodeToString(sig))
+}
+
+// Verify checks the signature stored under SignatureKey against the
+// canonical form of doc. It returns ErrKeyNotFound when the document is not
+// signed, or the verifier's error, which wraps ErrBadSignature for
+// Ed25519Verifier, when it does not match.
+func Verify(doc *YAMLValue, verifier Verifier) error {
+	stored, ok := doc.Lookup(SignatureKey)
+	if !ok {
+		return opError("verify", SignatureKey, ErrKeyNotFound, "")
+	}
+	sig, err := base64.StdEncoding.DecodeString(stored.AsString())
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	message, err := doc.canonical(ChecksumKey, SignatureKey)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(message, sig)
+}
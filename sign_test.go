@@ -0,0 +1,82 @@
+package easyyaml
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	policy, _ := Loads("rules:\n  - allow: read\n  - deny: write\n")
+	if err := Verify(policy, Ed25519Verifier(pub)); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound for unsigned document, got %v", err)
+	}
+	if err := Sign(policy, priv); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	// A checksum added afterwards does not invalidate the signature
+	policy.AddChecksum("sha256")
+
+	out, _ := policy.Dumps()
+	reloaded, _ := Loads(out)
+	if err := Verify(reloaded, Ed25519Verifier(pub)); err != nil {
+		t.Errorf("Expected signature to verify, got %v", err)
+	}
+	if err := reloaded.VerifyChecksum(); err != nil {
+		t.Errorf("Expected checksum to verify, got %v", err)
+	}
+
+	reloaded.SetPath("rules.1.deny", "none")
+	if err := Verify(reloaded, Ed25519Verifier(pub)); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Expected ErrBadSignature, got %v", err)
+	}
+}
+
+func TestSignECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	doc := Object(KV("name", "app"))
+	if err := Sign(doc, key); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	verifier := VerifierFunc(func(message, signature []byte) error {
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature) {
+			return ErrBadSignature
+		}
+		return nil
+	})
+	if err := Verify(doc, verifier); err != nil {
+		t.Errorf("Expected ECDSA signature to verify, got %v", err)
+	}
+}
+
+func TestVerifyTypeChange(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy, _ := Loads("replicas: 1\nenabled: true\n")
+	if err := Sign(policy, priv); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	for key, value := range map[string]interface{}{"replicas": 1.0, "enabled": "true"} {
+		tampered := policy.Clone()
+		tampered.Set(key, value)
+		if err := Verify(tampered, Ed25519Verifier(pub)); !errors.Is(err, ErrBadSignature) {
+			t.Errorf("Expected ErrBadSignature after changing the type of %s, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// PatchDirective is the key of a list item that, with the value "delete",
+// removes the item with the same merge key, as in Kubernetes strategic merge
+// patches
+const PatchDirective = "$patch"
+
+// MergeKeyAnnotation is the key of a list item that declares the list's merge
+// key inline, e.g. [{$mergeKey: name}, {name: app, image: v2}]. The item is
+// removed when merging.
+const MergeKeyAnnotation = "$mergeKey"
+
+// MergeStrategic deep-merges other into the value like Merge, except that
+// lists of objects with a merge key are merged element by element: items
+// of other replace or merge into the item with the same key value and new
+// items are appended. Merge keys are given by keys, which maps dot-separated
+// list paths ("*" matches any segment) to the key field, or inline with a
+// MergeKeyAnnotation item. A null value in other deletes its key, and a
+// list item with "$patch: delete" deletes the item with its key. Conflicts,
+// such as items without the key or duplicate keys, are returned as a
+// *MultiError after merging the rest.
+// Usage: err := deploy.MergeStrategic(patch, map[string]string{"spec.containers": "name", "spec.containers.*.env": "name"})
+func (yv *YAMLValue) MergeStrategic(other *YAMLValue, keys map[string]string) error {
+	logMutation("merge_strategic", "keys", other.Len())
+	if err := yv.checkFrozen("merge", nil); err != nil {
+		return err
+	}
+	yv.invalidate()
+	c := &ErrorCollector{}
+	if !yv.IsObject() {
+		c.Add("merge", "", opError("merge", nil, ErrNotAnObject, typeName(yv.data)))
+		return c.Err()
+	}
+	(&strategicMerge{keys: keys, c: c}).mergeObject(yv, other, "")
+	return c.Err()
+}
+
+// strategicMerge holds the merge keys and collects conflicts
+type strategicMerge struct {
+	keys map[string]string
+	c    *ErrorCollector
+}
+
+// mergeObject merges other into the object yv, which is at path
+func (s *strategicMerge) mergeObject(yv, other *YAMLValue, path string) {
+	if !other.IsObject() {
+		s.c.Add("merge", path, opError("merge", nil, ErrTypeMismatch, "can only merge with another object"))
+		return
+	}
+	for _, item := range other.ItemsOrdered() {
+		childPath := joinPath(path, fmt.Sprintf("%v", item.Key))
+		current := yv.Get(item.Key)
+		value := item.Value
+		switch {
+		case value.IsNull():
+			yv.deleteKey(item.Key)
+			continue
+		case current.IsObject() && value.IsObject():
+			s.mergeObject(current, value, childPath)
+			continue
+		case value.IsArray():
+			if key, items := s.mergeKey(value, childPath); key != "" {
+				if !current.IsArray() {
+					if err := yv.set(item.Key, []interface{}{}); err != nil {
+						s.c.Add("merge", childPath, err)
+						continue
+					}
+					current = yv.Get(item.Key)
+				}
+				s.mergeList(current, items, key, childPath)
+				continue
+			}
+		}
+		s.c.Add("merge", childPath, yv.set(item.Key, deepCopy(value.data)))
+	}
+}
+
+// mergeKey returns the merge key for the list at path, if any, and its items
+// without an inline annotation
+func (s *strategicMerge) mergeKey(list *YAMLValue, path string) (string, []interface{}) {
+	items := list.data.([]interface{})
+	if len(items) > 0 {
+		first := &YAMLValue{data: items[0]}
+		if key := first.Get(MergeKeyAnnotation); key.IsString() && first.Len() == 1 {
+			return key.AsString(), items[1:]
+		}
+	}
+	segments := splitPaths([]string{path})[0]
+	for pattern, key := range s.keys {
+		if pathMatches(splitPaths([]string{pattern})[0], segments) {
+			return key, items
+		}
+	}
+	return "", items
+}
+
+// mergeList merges the items of a patch list by key into the list, which
+// is at path. Kept items keep their styles and tags at their new positions.
+func (s *strategicMerge) mergeList(list *YAMLValue, patch []interface{}, key, path string) {
+	base := list.data.([]interface{})
+	// slots are the items of the merged list before deletions are dropped,
+	// with the index each had in base, or -1 for a new item
+	type slot struct {
+		from    int
+		data    interface{}
+		deleted bool
+	}
+	slots := make([]*slot, len(base))
+	index := make(map[string]int, len(base))
+	for i, item := range base {
+		slots[i] = &slot{from: i, data: item}
+		id, ok := listItemKey(item, key)
+		if !ok {
+			continue
+		}
+		if first, dup := index[id]; dup {
+			s.c.Add("merge", joinPath(path, strconv.Itoa(i)), fmt.Errorf("%w: duplicate %s %q, also at item %d", ErrKeyExists, key, id, first))
+			continue
+		}
+		index[id] = i
+	}
+
+	type update struct {
+		slot *slot
+		item interface{}
+	}
+	var updates []update
+	for i, item := range patch {
+		id, ok := listItemKey(item, key)
+		if !ok {
+			s.c.Add("merge", joinPath(path, strconv.Itoa(i)), fmt.Errorf("%w: list item has no merge key %q", ErrKeyNotFound, key))
+			continue
+		}
+		at, exists := index[id]
+		if (&YAMLValue{data: item}).Get(PatchDirective).AsString() == "delete" {
+			if exists {
+				slots[at].deleted = true
+				delete(index, id)
+			}
+			continue
+		}
+		if !exists {
+			at = len(slots)
+			index[id] = at
+			slots = append(slots, &slot{from: -1, data: emptyLike(item)})
+		}
+		updates = append(updates, update{slot: slots[at], item: item})
+	}
+
+	merged := make([]interface{}, 0, len(slots))
+	from := make([]int, 0, len(slots))
+	position := make(map[*slot]int, len(slots))
+	for _, sl := range slots {
+		if sl.deleted {
+			continue
+		}
+		position[sl] = len(merged)
+		merged = append(merged, sl.data)
+		from = append(from, sl.from)
+	}
+	list.meta.reindex(list.at, from)
+	list.replaceData(merged)
+
+	for _, u := range updates {
+		i, kept := position[u.slot]
+		if !kept {
+			continue
+		}
+		s.mergeObject(list.Get(i), &YAMLValue{data: u.item}, joinPath(path, strconv.Itoa(i)))
+	}
+}
+
+// listItemKey returns the merge key value of a list item
+func listItemKey(item interface{}, key string) (string, bool) {
+	value, ok := (&YAMLValue{data: item}).Lookup(key)
+	if !ok || value.IsObject() || value.IsArray() {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+// emptyLike returns an empty map of the same kind as data
+func emptyLike(data interface{}) interface{} {
+	if _, ok := data.(map[interface{}]interface{}); ok {
+		return map[interface{}]interface{}{}
+	}
+	return map[string]interface{}{}
+}
+
+// pathMatches checks path segments against pattern segments, each of which
+// may use "*" wildcards
+func pathMatches(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i := range pattern {
+		if !matchSegment(pattern[i], path[i]) {
+			return false
+		}
+	}
+	return true
+}
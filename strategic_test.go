@@ -0,0 +1,85 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMergeStrategic(t *testing.T) {
+	base, _ := Loads(`
+spec:
+  containers:
+    - name: app
+      image: app:1
+      env:
+        - {name: A, value: "1"}
+        - {name: B, value: "2"}
+    - name: sidecar
+      image: proxy:1
+  volumes: [{name: data}]
+`)
+	patch, _ := Loads(`
+spec:
+  containers:
+    - name: app
+      image: app:2
+      env:
+        - {name: B, value: "20"}
+        - {name: C, value: "3"}
+    - name: debug
+      image: busybox
+  volumes:
+    - {$mergeKey: name}
+    - {name: cache}
+`)
+
+	err := base.MergeStrategic(patch, map[string]string{
+		"spec.containers":       "name",
+		"spec.containers.*.env": "name",
+	})
+	if err != nil {
+		t.Fatalf("MergeStrategic failed: %v", err)
+	}
+
+	out, _ := base.WithIndent(2).Dumps()
+	expected := `spec:
+  containers:
+    - env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "20"
+        - name: C
+          value: "3"
+      image: app:2
+      name: app
+    - image: proxy:1
+      name: sidecar
+    - image: busybox
+      name: debug
+  volumes:
+    - name: data
+    - name: cache
+`
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	conflicts, _ := Loads("items:\n  - {id: 1}\n  - {id: 1}\n")
+	bad, _ := Loads("items:\n  - {id: 2}\n  - {name: x}\n")
+	err = conflicts.MergeStrategic(bad, map[string]string{"items": "id"})
+	if !errors.Is(err, ErrKeyExists) || !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Expected duplicate and missing key conflicts, got %v", err)
+	}
+	expectedErr := []string{
+		`merge items.1: key already exists: duplicate id "1", also at item 0`,
+		`merge items.1: key not found: list item has no merge key "id"`,
+	}
+	if err.Error() != strings.Join(expectedErr, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%v", strings.Join(expectedErr, "\n"), err)
+	}
+	if conflicts.Get("items").Len() != 3 {
+		t.Errorf("Expected the mergeable item appended, got %v", conflicts.Get("items").Raw())
+	}
+}
@@ -0,0 +1,432 @@
+package easyyaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScalarStyle is the way a scalar is written in YAML source
+type ScalarStyle int
+
+const (
+	// PlainStyle is an unquoted scalar, e.g. name: app
+	PlainStyle ScalarStyle = iota
+	// SingleQuotedStyle is a scalar in single quotes, e.g. answer: 'yes'
+	SingleQuotedStyle
+	// DoubleQuotedStyle is a scalar in double quotes, e.g. version: "1.0"
+	DoubleQuotedStyle
+	// LiteralStyle is a block scalar that keeps line breaks, started with |
+	LiteralStyle
+	// FoldedStyle is a block scalar that folds line breaks, started with >
+	FoldedStyle
+)
+
+// String returns the style's name, e.g. "double-quoted"
+func (s ScalarStyle) String() string {
+	switch s {
+	case SingleQuotedStyle:
+		return "single-quoted"
+	case DoubleQuotedStyle:
+		return "double-quoted"
+	case LiteralStyle:
+		return "literal"
+	case FoldedStyle:
+		return "folded"
+	}
+	return "plain"
+}
+
+// docMeta holds per-path node details of a loaded document that its Go
+// values cannot carry. It is shared by every value retrieved from the
+// document, each of which knows its path. Paths are built with childPath,
+// so a key containing a dot does not collide with nested keys.
+type docMeta struct {
+	styles map[string]ScalarStyle
+	tags   map[string]string
+	// node is the parsed document, kept by WithNode
+	node *yaml.Node
+	// frozen is set by Freeze
+	frozen bool
+}
+
+// Style returns how the scalar was written in the source, or the style set
+// with SetStyle. It is PlainStyle for objects, arrays and values built in
+// code.
+// Usage: if cfg.Get("answer").Style() != easyyaml.PlainStyle { /* keep the quotes */ }
+func (yv *YAMLValue) Style() ScalarStyle {
+	if yv.meta == nil {
+		return PlainStyle
+	}
+	return yv.meta.styles[yv.at]
+}
+
+// SetStyle sets how a string scalar is written by Dump. Quoted and block
+// styles only apply to strings; other scalars are always written plain, and
+// PlainStyle still quotes strings that would otherwise read as another type.
+// It returns an error wrapping ErrTypeMismatch for anything but a string.
+// Usage: cfg.Get("answer").SetStyle(easyyaml.DoubleQuotedStyle)
+func (yv *YAMLValue) SetStyle(style ScalarStyle) error {
+	if err := yv.checkFrozen("set_style", nil); err != nil {
+		return err
+	}
+	if _, ok := yv.data.(string); !ok {
+		return opError("set_style", nil, ErrTypeMismatch, "cannot style "+typeName(yv.data))
+	}
+	logMutation("set_style", "style", style.String())
+	if yv.meta == nil {
+		yv.meta = &docMeta{}
+	}
+	if yv.meta.styles == nil {
+		yv.meta.styles = make(map[string]ScalarStyle)
+	}
+	if style == PlainStyle {
+		delete(yv.meta.styles, yv.at)
+	} else {
+		yv.meta.styles[yv.at] = style
+	}
+	return nil
+}
+
+// recordMeta collects the styles of the non-plain scalars and the explicit
+// tags of a document
+func recordMeta(node *yaml.Node) *docMeta {
+	meta := &docMeta{}
+	var visit func(n *yaml.Node, path string)
+	visit = func(n *yaml.Node, path string) {
+		if tag, ok := explicitTag(n); ok {
+			if meta.tags == nil {
+				meta.tags = make(map[string]string)
+			}
+			meta.tags[path] = tag
+		}
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, child := range n.Content {
+				visit(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				visit(n.Content[i+1], childPath(path, n.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, child := range n.Content {
+				visit(child, childPath(path, i))
+			}
+		case yaml.ScalarNode:
+			if style := scalarStyle(n.Style); style != PlainStyle {
+				if meta.styles == nil {
+					meta.styles = make(map[string]ScalarStyle)
+				}
+				meta.styles[path] = style
+			}
+		}
+	}
+	visit(node, "")
+	return meta
+}
+
+// scalarStyle converts yaml.v3 style bits to a ScalarStyle
+func scalarStyle(style yaml.Style) ScalarStyle {
+	switch {
+	case style&yaml.SingleQuotedStyle != 0:
+		return SingleQuotedStyle
+	case style&yaml.DoubleQuotedStyle != 0:
+		return DoubleQuotedStyle
+	case style&yaml.LiteralStyle != 0:
+		return LiteralStyle
+	case style&yaml.FoldedStyle != 0:
+		return FoldedStyle
+	}
+	return PlainStyle
+}
+
+// nodeStyle converts a ScalarStyle to yaml.v3 style bits
+func nodeStyle(style ScalarStyle) yaml.Style {
+	switch style {
+	case SingleQuotedStyle:
+		return yaml.SingleQuotedStyle
+	case DoubleQuotedStyle:
+		return yaml.DoubleQuotedStyle
+	case LiteralStyle:
+		return yaml.LiteralStyle
+	case FoldedStyle:
+		return yaml.FoldedStyle
+	}
+	return 0
+}
+
+// marshalStyled marshals the value like marshalOrdered, applying the
+// recorded scalar styles
+func (yv *YAMLValue) marshalStyled(opts Options) ([]byte, error) {
+	if yv.meta.isEmpty() {
+		return marshalOrdered(yv.data, opts)
+	}
+	node, err := encodeOrdered(yv.data, opts)
+	if err != nil {
+		return nil, err
+	}
+	yv.meta.apply(node, yv.at)
+	return marshalIndent(node, opts.Indent)
+}
+
+// clone returns an independent copy for a cloned document
+func (m *docMeta) clone() *docMeta {
+	if m == nil {
+		return nil
+	}
+	c := &docMeta{node: m.node}
+	if m.styles != nil {
+		c.styles = make(map[string]ScalarStyle, len(m.styles))
+		for path, style := range m.styles {
+			c.styles[path] = style
+		}
+	}
+	if m.tags != nil {
+		c.tags = make(map[string]string, len(m.tags))
+		for path, tag := range m.tags {
+			c.tags[path] = tag
+		}
+	}
+	return c
+}
+
+// sameDetails reports whether m and other record the same styles and tags
+func (m *docMeta) sameDetails(other *docMeta) bool {
+	if m.isEmpty() || other.isEmpty() {
+		return m.isEmpty() && other.isEmpty()
+	}
+	if len(m.styles) != len(other.styles) {
+		return false
+	}
+	for path, style := range m.styles {
+		if other.styles[path] != style {
+			return false
+		}
+	}
+	if len(m.tags) != len(other.tags) {
+		return false
+	}
+	for path, tag := range m.tags {
+		if other.tags[path] != tag {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty reports whether there is nothing to apply when dumping
+func (m *docMeta) isEmpty() bool {
+	return m == nil || len(m.styles) == 0 && len(m.tags) == 0
+}
+
+// apply sets the recorded styles on the string scalars of an encoded node
+// tree rooted at path, and the recorded tags on any node
+func (m *docMeta) apply(node *yaml.Node, path string) {
+	if tag, ok := m.tags[path]; ok && node.Kind != yaml.DocumentNode {
+		defer applyTag(node, tag)
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			m.apply(child, path)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			m.apply(node.Content[i+1], childPath(path, node.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			m.apply(child, childPath(path, i))
+		}
+	case yaml.ScalarNode:
+		if style, ok := m.styles[path]; ok && node.Tag == "!!str" {
+			node.Style = nodeStyle(style)
+		}
+	}
+}
+
+// graft copies the details recorded at from and below it in src to the
+// same places under to, for a value copied from another document
+func (m *docMeta) graft(src *docMeta, from, to string) {
+	moved := func(p string) (string, bool) {
+		if p == from {
+			return to, true
+		}
+		if from == "" {
+			return joinPath(to, p), true
+		}
+		if strings.HasPrefix(p, from+".") {
+			return joinPath(to, p[len(from)+1:]), true
+		}
+		return "", false
+	}
+	for p, style := range src.styles {
+		if dst, ok := moved(p); ok {
+			if m.styles == nil {
+				m.styles = make(map[string]ScalarStyle)
+			}
+			m.styles[dst] = style
+		}
+	}
+	for p, tag := range src.tags {
+		if dst, ok := moved(p); ok {
+			if m.tags == nil {
+				m.tags = make(map[string]string)
+			}
+			m.tags[dst] = tag
+		}
+	}
+}
+
+// forget drops the details recorded at path and below it, for a value that
+// is being replaced
+func (m *docMeta) forget(path string) {
+	if m == nil {
+		return
+	}
+	if path == "" {
+		m.styles, m.tags = nil, nil
+		return
+	}
+	prefix := path + "."
+	for p := range m.styles {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(m.styles, p)
+		}
+	}
+	for p := range m.tags {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(m.tags, p)
+		}
+	}
+}
+
+// reindex moves the details recorded for the items of the array at path,
+// and below them, to the items' new positions after items were removed,
+// inserted or reordered. from holds the old index of each new item, or -1
+// for an item that was not there before; details of old items missing from
+// from are dropped.
+func (m *docMeta) reindex(path string, from []int) {
+	if m.isEmpty() {
+		return
+	}
+	to := make(map[int]int, len(from))
+	for i, old := range from {
+		if old >= 0 {
+			to[old] = i
+		}
+	}
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+	// moved returns where a recorded path goes, and whether it is below an
+	// item of the array at all
+	moved := func(p string) (string, bool, bool) {
+		if p == path || !strings.HasPrefix(p, prefix) {
+			return "", false, false
+		}
+		rest := p[len(prefix):]
+		segment, tail, _ := strings.Cut(rest, ".")
+		old, err := strconv.Atoi(segment)
+		if err != nil {
+			return "", false, false
+		}
+		i, ok := to[old]
+		if !ok {
+			return "", false, true
+		}
+		dst := prefix + strconv.Itoa(i)
+		if tail != "" {
+			dst += "." + tail
+		}
+		return dst, true, true
+	}
+	styles := make(map[string]ScalarStyle)
+	for p, style := range m.styles {
+		if dst, keep, below := moved(p); below {
+			delete(m.styles, p)
+			if keep {
+				styles[dst] = style
+			}
+		}
+	}
+	for p, style := range styles {
+		m.styles[p] = style
+	}
+	tags := make(map[string]string)
+	for p, tag := range m.tags {
+		if dst, keep, below := moved(p); below {
+			delete(m.tags, p)
+			if keep {
+				tags[dst] = tag
+			}
+		}
+	}
+	for p, tag := range tags {
+		m.tags[p] = tag
+	}
+}
+
+// without returns the indices 0 to n-1 except skip, for reindex after the
+// item at skip was removed
+func without(n, skip int) []int {
+	from := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if i != skip {
+			from = append(from, i)
+		}
+	}
+	return from
+}
+
+// inserted returns the old index of each item of an array of n items after
+// a new item is inserted at index at, for reindex
+func inserted(n, at int) []int {
+	from := make([]int, 0, n+1)
+	for i := 0; i < n; i++ {
+		if i == at {
+			from = append(from, -1)
+		}
+		from = append(from, i)
+	}
+	if at >= n {
+		from = append(from, -1)
+	}
+	return from
+}
+
+// pathEscaper escapes the keys of paths built with childPath
+var pathEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`)
+
+// childPath appends a key to the path of a value within its document. Dots
+// and backslashes in the key are escaped with a backslash, so the paths of
+// "a.b" and of b within a stay apart.
+func childPath(base string, key interface{}) string {
+	return joinPath(base, pathEscaper.Replace(fmt.Sprintf("%v", key)))
+}
+
+// splitChildPath splits a path built with childPath into its keys
+func splitChildPath(path string) []string {
+	var keys []string
+	var key strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path):
+			i++
+			key.WriteByte(path[i])
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+	if path != "" {
+		keys = append(keys, key.String())
+	}
+	return keys
+}
@@ -0,0 +1,162 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStyle(t *testing.T) {
+	yv, _ := Loads("plain: text\nsingle: 'yes'\ndouble: \"1.0\"\nliteral: |\n  a\n  b\nfolded: >\n  c\nlist:\n  - \"x\"\n  - y\nnum: 1\n")
+	expected := map[string]ScalarStyle{
+		"plain":   PlainStyle,
+		"single":  SingleQuotedStyle,
+		"double":  DoubleQuotedStyle,
+		"literal": LiteralStyle,
+		"folded":  FoldedStyle,
+		"list.0":  DoubleQuotedStyle,
+		"list.1":  PlainStyle,
+		"num":     PlainStyle,
+		"list":    PlainStyle,
+	}
+	for path, want := range expected {
+		if got := yv.Path(path).Style(); got != want {
+			t.Errorf("%s: expected %v, got %v", path, want, got)
+		}
+	}
+
+	yv.Delete("folded")
+	yv.Path("list").Set(1, "z")
+	out, _ := yv.Dumps()
+	want := "double: \"1.0\"\nlist:\n    - \"x\"\n    - z\nliteral: |\n    a\n    b\nnum: 1\nplain: text\nsingle: 'yes'\n"
+	if out != want {
+		t.Errorf("Expected styles kept on dump, got %q", out)
+	}
+}
+
+func TestSetStyle(t *testing.T) {
+	yv, _ := Loads("name: app\nanswer: 'no'\nport: 80\n")
+	if err := yv.Get("name").SetStyle(DoubleQuotedStyle); err != nil {
+		t.Fatalf("SetStyle failed: %v", err)
+	}
+	yv.Get("answer").SetStyle(PlainStyle)
+	if err := yv.Get("port").SetStyle(SingleQuotedStyle); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for an int, got %v", err)
+	}
+
+	out, _ := yv.Dumps()
+	if out != "answer: \"no\"\nname: \"app\"\nport: 80\n" {
+		t.Errorf("Unexpected output: %q", out)
+	}
+	if yv.Get("name").Style() != DoubleQuotedStyle {
+		t.Errorf("Expected Style to report the set style, got %v", yv.Get("name").Style())
+	}
+
+	clone := yv.Clone()
+	clone.Get("name").SetStyle(SingleQuotedStyle)
+	if yv.Get("name").Style() != DoubleQuotedStyle {
+		t.Error("Expected clone styles to be independent")
+	}
+
+	yv.Set("name", "other")
+	if yv.Get("name").Style() != PlainStyle {
+		t.Error("Expected Set to clear the style of the replaced value")
+	}
+
+	built := NewObject()
+	built.Set("version", "2")
+	built.Get("version").SetStyle(SingleQuotedStyle)
+	if out, _ := built.Dumps(); out != "version: '2'\n" {
+		t.Errorf("Expected style on a built document, got %q", out)
+	}
+}
+
+func TestStyleDottedKeys(t *testing.T) {
+	yv, _ := Loads("a.b: 'q'\na:\n  b: plain\n")
+	out, _ := yv.Dumps()
+	if want := "a:\n    b: plain\na.b: 'q'\n"; out != want {
+		t.Errorf("Expected %q, got %q", want, out)
+	}
+	if yv.Get("a.b").Style() != SingleQuotedStyle || yv.Path("a.b").Style() != PlainStyle {
+		t.Error("Expected the dotted key and the nested key to keep their own styles")
+	}
+	if got := splitChildPath(childPath(childPath("", `x\y.z`), 0)); len(got) != 2 || got[0] != `x\y.z` || got[1] != "0" {
+		t.Errorf("Unexpected split: %q", got)
+	}
+}
+
+func TestSetStyleFidelity(t *testing.T) {
+	yv, _ := Loads("# config\nname: app\n", WithFidelity())
+	if err := yv.Get("name").SetStyle(DoubleQuotedStyle); err != nil {
+		t.Fatalf("SetStyle failed: %v", err)
+	}
+	if out, _ := yv.Dumps(); out != "name: \"app\"\n" {
+		t.Errorf("Expected a style change to count as a modification, got %q", out)
+	}
+	if err := yv.Get("name").SetStyle(PlainStyle); err != nil {
+		t.Fatalf("SetStyle failed: %v", err)
+	}
+	if out, _ := yv.Dumps(); out != "# config\nname: app\n" {
+		t.Errorf("Expected the original text once the style is restored, got %q", out)
+	}
+}
+
+func TestStyleArrayShifts(t *testing.T) {
+	src := "- !Ref A\n- plain\n- 'quoted'\n- x\n"
+	yv, _ := Loads(src)
+	if err := yv.Delete(0); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if out, _ := yv.Dumps(); out != "- plain\n- 'quoted'\n- x\n" {
+		t.Errorf("Expected styles and tags to move with the items, got %q", out)
+	}
+
+	nested, _ := Loads("list:\n  - !Ref A\n  - 'b'\n  - c\n")
+	if err := nested.Get("list").Delete(0); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if out, _ := nested.Dumps(); out != "list:\n    - 'b'\n    - c\n" {
+		t.Errorf("Expected Delete on a child list to update the parent, got %q", out)
+	}
+
+	pruned, _ := Loads("- null\n- !Ref A\n- null\n- 'b'\n")
+	pruned.Prune(PruneOptions{Nulls: true})
+	if out, _ := pruned.Dumps(); out != "- !Ref A\n- 'b'\n" {
+		t.Errorf("Expected Prune to move styles and tags, got %q", out)
+	}
+
+	base, _ := Loads(src)
+	if out, _ := base.Without("1").Dumps(); out != "- !Ref A\n- 'quoted'\n- x\n" {
+		t.Errorf("Expected Without to move styles and tags, got %q", out)
+	}
+
+	patch, _ := Loads(`[{op: remove, path: /0}, {op: add, path: /0, value: new}]`)
+	patched, err := ApplyPatch(base, patch, JSONPatch)
+	if err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if out, _ := patched.Dumps(); out != "- new\n- plain\n- 'quoted'\n- x\n" {
+		t.Errorf("Expected patch operations to move styles and tags, got %q", out)
+	}
+}
+
+func TestStyleStrategicMerge(t *testing.T) {
+	yv, _ := Loads("items:\n  - name: a\n    ref: !Ref A\n  - name: b\n    value: '1'\n")
+	patch, _ := Loads("items:\n  - {name: a, $patch: delete}\n  - {name: c, value: x}\n")
+	if err := yv.MergeStrategic(patch, map[string]string{"items": "name"}); err != nil {
+		t.Fatalf("MergeStrategic failed: %v", err)
+	}
+	if out, _ := yv.Dumps(); out != "items:\n    - name: b\n      value: '1'\n    - name: c\n      value: x\n" {
+		t.Errorf("Expected kept items to keep their styles, got %q", out)
+	}
+}
+
+func TestRenameKeyStyle(t *testing.T) {
+	yv, _ := Loads("old: 'v'\n")
+	if err := yv.RenameKey("", "old", "new"); err != nil {
+		t.Fatalf("RenameKey failed: %v", err)
+	}
+	yv.Set("old", "w")
+	if out, _ := yv.Dumps(); out != "new: 'v'\nold: w\n" {
+		t.Errorf("Expected the style to follow the renamed key, got %q", out)
+	}
+}
@@ -0,0 +1,152 @@
+package easyyaml
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToTable renders a sequence of mappings as an aligned text table. Columns
+// are dot-separated paths into each item; when none are given, the sorted
+// union of the items' top-level keys is used.
+// Usage: hosts.ToTable("name", "address", "port")
+func (yv *YAMLValue) ToTable(columns ...string) string {
+	header, rows := yv.tableCells(columns)
+	if len(header) == 0 {
+		return ""
+	}
+
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		var line strings.Builder
+		for i, cell := range cells {
+			line.WriteString(cell)
+			if i < len(cells)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+			}
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+
+	upper := make([]string, len(header))
+	for i, h := range header {
+		upper[i] = strings.ToUpper(h)
+	}
+	writeRow(upper)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+// ToMarkdownTable renders a sequence of mappings as a Markdown table,
+// selecting columns the same way as ToTable
+func (yv *YAMLValue) ToMarkdownTable(columns ...string) string {
+	header, rows := yv.tableCells(columns)
+	if len(header) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for _, cell := range cells {
+			sb.WriteString(" ")
+			sb.WriteString(strings.ReplaceAll(cell, "|", "\\|"))
+			sb.WriteString(" |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(header)
+	sb.WriteString("|")
+	for range header {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+// tableCells resolves the header and cell text for each item of an array
+func (yv *YAMLValue) tableCells(columns []string) ([]string, [][]string) {
+	items := yv.AsArray()
+
+	if len(columns) == 0 {
+		seen := make(map[string]bool)
+		for _, item := range items {
+			item.eachChild(func(key string, child *YAMLValue) {
+				if !seen[key] {
+					seen[key] = true
+					columns = append(columns, key)
+				}
+			})
+		}
+		sort.Strings(columns)
+	}
+
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := make([]string, len(columns))
+		for j, col := range columns {
+			row[j] = tableCell(item.Path(col))
+		}
+		rows[i] = row
+	}
+	return columns, rows
+}
+
+// tableCell formats a value for a single-line table cell
+func tableCell(v *YAMLValue) string {
+	if v.IsNull() {
+		return ""
+	}
+	if v.IsObject() || v.IsArray() {
+		out, err := yamlFlow(v.data)
+		if err == nil {
+			return out
+		}
+	}
+	return strings.ReplaceAll(v.AsString(), "\n", " ")
+}
+
+// yamlFlow marshals a value as single-line flow-style YAML
+func yamlFlow(data interface{}) (string, error) {
+	var node yaml.Node
+	if err := node.Encode(data); err != nil {
+		return "", err
+	}
+	setFlowStyle(&node)
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// setFlowStyle marks a node and its children as flow style
+func setFlowStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+	}
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
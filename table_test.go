@@ -0,0 +1,55 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+const hostsYAML = `
+- name: web
+  address: 10.0.0.1
+  port: 80
+- name: database
+  address: 10.0.0.2
+  port: 5432
+  tags: [primary, ssd]
+`
+
+func TestToTable(t *testing.T) {
+	yv, err := Loads(hostsYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	expected := "NAME      PORT\n" +
+		"web       80\n" +
+		"database  5432\n"
+	if got := yv.ToTable("name", "port"); got != expected {
+		t.Errorf("Expected table:\n%s\ngot:\n%s", expected, got)
+	}
+
+	expected = "ADDRESS   NAME      PORT  TAGS\n" +
+		"10.0.0.1  web       80\n" +
+		"10.0.0.2  database  5432  [primary, ssd]\n"
+	if got := yv.ToTable(); got != expected {
+		t.Errorf("Expected table:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if NewObject().ToTable() != "" {
+		t.Error("Expected empty table for non-array value")
+	}
+}
+
+func TestToMarkdownTable(t *testing.T) {
+	yv, err := Loads(hostsYAML)
+	if err != nil {
+		t.Fatalf("Failed to load YAML: %v", err)
+	}
+
+	expected := "| name | port |\n" +
+		"| --- | --- |\n" +
+		"| web | 80 |\n" +
+		"| database | 5432 |\n"
+	if got := yv.ToMarkdownTable("name", "port"); got != expected {
+		t.Errorf("Expected table:\n%s\ngot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,72 @@
+package easyyaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithTabRepair makes a load that fails because lines are indented with
+// tabs replace each leading tab with width spaces and try again, recording
+// a Warning for every repaired line. Input that parses as-is is never
+// changed, so tabs in block scalars of valid documents are kept.
+// Usage: doc, err := easyyaml.LoadFile("user.yaml", easyyaml.WithTabRepair(2))
+func WithTabRepair(width int) LoadOption {
+	return func(opts *Options) {
+		opts.TabWidth = width
+	}
+}
+
+// repairTabs replaces leading tabs with width spaces when input does not
+// parse, returning the repaired input and a warning per changed line. Input
+// that parses, or has no leading tabs, is returned unchanged.
+func repairTabs(input []byte, width int) ([]byte, []Warning) {
+	if width <= 0 || !hasLeadingTab(input) || parses(input) {
+		return input, nil
+	}
+
+	var warnings []Warning
+	spaces := bytes.Repeat([]byte(" "), width)
+	lines := bytes.SplitAfter(input, []byte("\n"))
+	for i, line := range lines {
+		indent := len(line) - len(bytes.TrimLeft(line, " \t"))
+		tabs := bytes.Count(line[:indent], []byte("\t"))
+		if tabs == 0 {
+			continue
+		}
+		fixed := bytes.ReplaceAll(line[:indent], []byte("\t"), spaces)
+		lines[i] = append(fixed, line[indent:]...)
+		warnings = append(warnings, Warning{Kind: WarnRepairedTab, Line: i + 1, Message: fmt.Sprintf("replaced %d leading tab(s) with %d spaces each", tabs, width)})
+	}
+	return bytes.Join(lines, nil), warnings
+}
+
+// hasLeadingTab reports whether any line of input has a tab in its indentation
+func hasLeadingTab(input []byte) bool {
+	for _, line := range bytes.Split(input, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if bytes.IndexByte(line[:len(line)-len(trimmed)], '\t') >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// parses reports whether every document of input is valid YAML
+func parses(input []byte) bool {
+	stripped, _ := extractDirectives(input)
+	dec := yaml.NewDecoder(bytes.NewReader(stripped))
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestWithTabRepair(t *testing.T) {
+	input := []byte("server:\n\thost: localhost\n\tports:\n\t\t- 80\n  \t- 443\n")
+	if _, err := Load(input); err == nil {
+		t.Fatal("Expected tab-indented input to fail without repair")
+	}
+
+	yv, err := Load(input, WithTabRepair(2))
+	if err != nil {
+		t.Fatalf("Load with tab repair failed: %v", err)
+	}
+	if yv.Path("server.host").AsString() != "localhost" || yv.Path("server.ports.1").AsInt() != 443 {
+		t.Errorf("Expected repaired structure, got %v", yv.Raw())
+	}
+
+	warnings := yv.Warnings()
+	lines := []int{2, 3, 4, 5}
+	if len(warnings) != len(lines) {
+		t.Fatalf("Expected %d warnings, got %v", len(lines), warnings)
+	}
+	for i, line := range lines {
+		if warnings[i].Line != line {
+			t.Errorf("Expected warning %d at line %d, got %d", i, line, warnings[i].Line)
+		}
+	}
+	if got := warnings[2].String(); got != "line 4: replaced 2 leading tab(s) with 2 spaces each" {
+		t.Errorf("Unexpected warning text: %s", got)
+	}
+}
+
+func TestWithTabRepairKeepsValidInput(t *testing.T) {
+	input := []byte("script: |\n  make\n  \techo done\n")
+	yv, err := Load(input, WithTabRepair(4))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if yv.Get("script").AsString() != "make\n\techo done\n" {
+		t.Errorf("Expected block scalar tabs kept, got %q", yv.Get("script").AsString())
+	}
+	if len(yv.Warnings()) != 0 {
+		t.Errorf("Expected no warnings, got %v", yv.Warnings())
+	}
+}
+
+func TestWithTabRepairLoadAll(t *testing.T) {
+	docs, err := LoadAll([]byte("a: 1\n---\nb:\n\tc: 2\n"), WithTabRepair(2))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	if docs.Get(1).Path("b.c").AsInt() != 2 {
+		t.Errorf("Expected b.c = 2, got %v", docs.Get(1).Raw())
+	}
+	if w := docs.Warnings(); len(w) != 1 || w[0].Line != 4 {
+		t.Errorf("Expected one warning at line 4, got %v", w)
+	}
+}
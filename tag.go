@@ -0,0 +1,160 @@
+package easyyaml
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tag returns the value's YAML tag: the explicit tag it was loaded or set
+// with, such as "!MyType" or "!!binary", otherwise the tag its type
+// resolves to, one of "!!str", "!!int", "!!float", "!!bool", "!!null",
+// "!!timestamp", "!!map" or "!!seq"
+// Usage: if point.Tag() == "!Point" { /* decode a point */ }
+func (yv *YAMLValue) Tag() string {
+	if yv.meta != nil {
+		if tag, ok := yv.meta.tags[yv.at]; ok {
+			return tag
+		}
+	}
+	return resolvedTag(yv.data)
+}
+
+// SetTag sets an explicit tag that Dump writes before the value, such as
+// "!MyType" for an application-defined type. A standard "!!" tag must match
+// the value's type, except "!!binary", which writes a string base64-encoded.
+// An empty tag removes the explicit tag.
+// Usage: point := easyyaml.Object(easyyaml.KV("x", 1), easyyaml.KV("y", 2)); point.SetTag("!Point")
+func (yv *YAMLValue) SetTag(tag string) error {
+	if err := yv.checkFrozen("set_tag", tag); err != nil {
+		return err
+	}
+	if tag != "" && !strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "tag:") {
+		return opError("set_tag", tag, ErrTypeMismatch, "tag must start with ! or tag:")
+	}
+	if strings.HasPrefix(tag, "!!") && tag != resolvedTag(yv.data) {
+		if _, isString := yv.data.(string); tag != "!!binary" || !isString {
+			return opError("set_tag", tag, ErrTypeMismatch, "cannot tag "+typeName(yv.data))
+		}
+	}
+	logMutation("set_tag", "tag", tag)
+	if yv.meta == nil {
+		yv.meta = &docMeta{}
+	}
+	if yv.meta.tags == nil {
+		yv.meta.tags = make(map[string]string)
+	}
+	if tag == "" {
+		delete(yv.meta.tags, yv.at)
+	} else {
+		yv.meta.tags[yv.at] = tag
+	}
+	return nil
+}
+
+// resolvedTag returns the standard tag of a decoded value
+func resolvedTag(data interface{}) string {
+	switch data.(type) {
+	case nil:
+		return "!!null"
+	case string:
+		return "!!str"
+	case bool:
+		return "!!bool"
+	case int, int64, uint64:
+		return "!!int"
+	case float64:
+		return "!!float"
+	case time.Time:
+		return "!!timestamp"
+	case []byte:
+		return "!!binary"
+	case []interface{}:
+		return "!!seq"
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "!!map"
+	}
+	return ""
+}
+
+// explicitTag returns the tag of a node written with one, unless it is a
+// standard tag its value resolves to without it
+func explicitTag(n *yaml.Node) (string, bool) {
+	if n.Style&yaml.TaggedStyle == 0 {
+		return "", false
+	}
+	switch n.Tag {
+	case "!!str", "!!int", "!!float", "!!bool", "!!null", "!!timestamp", "!!map", "!!seq":
+		return "", false
+	}
+	return n.Tag, true
+}
+
+// applyTag writes an explicit tag on an encoded node
+func applyTag(n *yaml.Node, tag string) {
+	if tag == "!!binary" && n.Kind == yaml.ScalarNode && n.Tag == "!!str" {
+		n.Value = base64.StdEncoding.EncodeToString([]byte(n.Value))
+		n.Style = 0
+	}
+	n.Tag = tag
+}
+
+// binaryData is a byte slice held in a document, which encodes as a base64
+// !!binary scalar rather than as a sequence of numbers
+type binaryData []byte
+
+// MarshalYAML implements yaml.Marshaler
+func (b binaryData) MarshalYAML() (interface{}, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!binary", Value: base64.StdEncoding.EncodeToString(b)}, nil
+}
+
+// withBinary returns data with its byte slices wrapped as binaryData for
+// encoding, copying only the containers that hold one
+func withBinary(data interface{}) (interface{}, bool) {
+	switch v := data.(type) {
+	case []byte:
+		return binaryData(v), true
+	case map[string]interface{}:
+		var out map[string]interface{}
+		for k, child := range v {
+			if wrapped, ok := withBinary(child); ok {
+				if out == nil {
+					out = make(map[string]interface{}, len(v))
+					for k2, c2 := range v {
+						out[k2] = c2
+					}
+				}
+				out[k] = wrapped
+			}
+		}
+		return out, out != nil
+	case map[interface{}]interface{}:
+		var out map[interface{}]interface{}
+		for k, child := range v {
+			if wrapped, ok := withBinary(child); ok {
+				if out == nil {
+					out = make(map[interface{}]interface{}, len(v))
+					for k2, c2 := range v {
+						out[k2] = c2
+					}
+				}
+				out[k] = wrapped
+			}
+		}
+		return out, out != nil
+	case []interface{}:
+		var out []interface{}
+		for i, child := range v {
+			if wrapped, ok := withBinary(child); ok {
+				if out == nil {
+					out = append([]interface{}(nil), v...)
+				}
+				out[i] = wrapped
+			}
+		}
+		return out, out != nil
+	}
+	return data, false
+}
@@ -0,0 +1,67 @@
+package easyyaml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTag(t *testing.T) {
+	yv, _ := Loads("name: app\nport: 80\nratio: 0.5\non: true\nnone: null\nlist: [1]\npoint: !Point {x: 1}\ntyped: !MyType foo\ndata: !!binary aGVsbG8=\nforced: !!str 12\n")
+	expected := map[string]string{
+		"name":   "!!str",
+		"port":   "!!int",
+		"ratio":  "!!float",
+		"on":     "!!bool",
+		"none":   "!!null",
+		"list":   "!!seq",
+		"":       "!!map",
+		"point":  "!Point",
+		"typed":  "!MyType",
+		"data":   "!!binary",
+		"forced": "!!str",
+	}
+	for path, want := range expected {
+		if got := yv.Path(path).Tag(); got != want {
+			t.Errorf("%q: expected %s, got %s", path, want, got)
+		}
+	}
+
+	out, _ := yv.Dumps()
+	back, err := Loads(out)
+	if err != nil {
+		t.Fatalf("Reload failed: %v\n%s", err, out)
+	}
+	if back.Get("point").Tag() != "!Point" || back.Get("typed").Tag() != "!MyType" || back.Get("data").AsString() != "hello" {
+		t.Errorf("Expected tags kept on dump, got:\n%s", out)
+	}
+}
+
+func TestSetTag(t *testing.T) {
+	point := Object(KV("x", 1), KV("y", 2))
+	if err := point.SetTag("!Point"); err != nil {
+		t.Fatalf("SetTag failed: %v", err)
+	}
+	doc := NewObject()
+	doc.Set("origin", point)
+	doc.Set("blob", "hi")
+	doc.Get("blob").SetTag("!!binary")
+
+	out, _ := doc.Dumps()
+	if out != "blob: !!binary aGk=\norigin: !Point\n    x: 1\n    \"y\": 2\n" {
+		t.Errorf("Unexpected output: %q", out)
+	}
+	if out, _ := point.Dumps(); out != "!Point\nx: 1\n\"y\": 2\n" {
+		t.Errorf("Unexpected root output: %q", out)
+	}
+
+	if err := doc.Get("blob").SetTag("!!int"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for a mismatched standard tag, got %v", err)
+	}
+	if err := doc.Get("blob").SetTag("Point"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for a tag without !, got %v", err)
+	}
+	doc.Get("origin").SetTag("")
+	if doc.Get("origin").Tag() != "!!map" {
+		t.Errorf("Expected the explicit tag removed, got %s", doc.Get("origin").Tag())
+	}
+}
@@ -0,0 +1,139 @@
+package easyyaml
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Span is a single traced operation. Its methods mirror the subset of
+// OpenTelemetry's trace.Span that easyyaml uses, so an adapter is a few lines.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans. Wrap an OpenTelemetry tracer to export easyyaml
+// spans alongside the rest of a request trace.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+var tracerState = struct {
+	sync.RWMutex
+	tracer Tracer
+}{}
+
+// SetTracer installs a tracer for heavy operations (loading, merging and
+// validating).
+// Pass nil to disable tracing, which is the default.
+func SetTracer(tracer Tracer) {
+	tracerState.Lock()
+	defer tracerState.Unlock()
+	tracerState.tracer = tracer
+}
+
+// noopSpan is used when no tracer is installed
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+
+// startSpan starts a span with the installed tracer, or a no-op span
+func startSpan(ctx context.Context, name string) (context.Context, Span) {
+	tracerState.RLock()
+	tracer := tracerState.tracer
+	tracerState.RUnlock()
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return tracer.Start(ctx, name)
+}
+
+// endSpan records err on the span, if any, and ends it
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// LoadContext is like Load but parents its tracing span on ctx
+func LoadContext(ctx context.Context, yamlBytes []byte, opts ...LoadOption) (*YAMLValue, error) {
+	_, span := startSpan(ctx, "easyyaml.Load")
+	span.SetAttribute("yaml.size", len(yamlBytes))
+	yv, err := load(yamlBytes, loadOptions(opts))
+	endSpan(span, err)
+	return yv, err
+}
+
+// LoadFileContext is like LoadFile but parents its tracing spans on ctx
+func LoadFileContext(ctx context.Context, filename string, opts ...LoadOption) (*YAMLValue, error) {
+	ctx, span := startSpan(ctx, "easyyaml.LoadFile")
+	span.SetAttribute("yaml.file", filename)
+	yv, err := loadFile(ctx, filename, opts)
+	endSpan(span, err)
+	return yv, err
+}
+
+// LoadAllContext is like LoadAll but parents its tracing span on ctx
+func LoadAllContext(ctx context.Context, yamlBytes []byte, opts ...LoadOption) (*Documents, error) {
+	_, span := startSpan(ctx, "easyyaml.LoadAll")
+	span.SetAttribute("yaml.size", len(yamlBytes))
+	docs, err := loadAll(yamlBytes, loadOptions(opts))
+	if err == nil {
+		span.SetAttribute("yaml.documents", docs.Len())
+	}
+	endSpan(span, err)
+	return docs, err
+}
+
+// MergeContext is like Merge but parents its tracing span on ctx
+func (yv *YAMLValue) MergeContext(ctx context.Context, other *YAMLValue) error {
+	_, span := startSpan(ctx, "easyyaml.Merge")
+	span.SetAttribute("yaml.keys", other.Len())
+	logMutation("merge", "keys", other.Len())
+	err := yv.merge(other)
+	endSpan(span, err)
+	return err
+}
+
+// ValidateContext is like Validate but parents its tracing span on ctx
+func (yv *YAMLValue) ValidateContext(ctx context.Context, schema *YAMLValue) error {
+	_, span := startSpan(ctx, "easyyaml.Validate")
+	c := yv.validate(schema)
+	span.SetAttribute("yaml.violations", c.Len())
+	err := c.Err()
+	endSpan(span, err)
+	return err
+}
+
+// ConstrainWithContext is like ConstrainWith but parents its tracing spans
+// on ctx
+func (yv *YAMLValue) ConstrainWithContext(ctx context.Context, example interface{}) error {
+	ctx, span := startSpan(ctx, "easyyaml.ConstrainWith")
+	span.SetAttribute("yaml.type", fmt.Sprintf("%T", example))
+	err := yv.ValidateContext(ctx, SchemaFromStruct(example))
+	endSpan(span, err)
+	return err
+}
+
+// ValidateContext is like Validate but parents its tracing spans on ctx
+func (r *SchemaRegistry) ValidateContext(ctx context.Context, doc *YAMLValue) error {
+	ctx, span := startSpan(ctx, "easyyaml.SchemaRegistry.Validate")
+	err := r.validate(ctx, doc, span)
+	endSpan(span, err)
+	return err
+}
+
+// ValidateAllContext is like ValidateAll but parents its tracing spans on
+// ctx
+func (r *SchemaRegistry) ValidateAllContext(ctx context.Context, docs *Documents) error {
+	ctx, span := startSpan(ctx, "easyyaml.SchemaRegistry.ValidateAll")
+	span.SetAttribute("yaml.documents", docs.Len())
+	err := r.validateAll(ctx, docs)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,113 @@
+package easyyaml
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type spanKey struct{}
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *recordedSpan) RecordError(err error)                      { s.err = err }
+func (s *recordedSpan) End()                                       { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attrs: make(map[string]interface{})}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanKey{}, name), span
+}
+
+func TestTracing(t *testing.T) {
+	tracer := &recordingTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(filename, []byte("a: 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	yv, err := LoadFileContext(context.WithValue(context.Background(), spanKey{}, "request"), filename)
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+	if err := yv.Merge(NewObject()); err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if _, err := Loads("a: [1"); err == nil {
+		t.Fatal("Expected parse error")
+	}
+
+	if len(tracer.spans) != 4 {
+		t.Fatalf("Expected 4 spans, got %d", len(tracer.spans))
+	}
+
+	file, load, merge, failed := tracer.spans[0], tracer.spans[1], tracer.spans[2], tracer.spans[3]
+	if file.name != "easyyaml.LoadFile" || file.parent != "request" || file.attrs["yaml.file"] != filename {
+		t.Errorf("Unexpected LoadFile span: %+v", file)
+	}
+	if load.name != "easyyaml.Load" || load.parent != "easyyaml.LoadFile" || load.attrs["yaml.size"] != 5 {
+		t.Errorf("Unexpected Load span: %+v", load)
+	}
+	if merge.name != "easyyaml.Merge" || !merge.ended {
+		t.Errorf("Unexpected Merge span: %+v", merge)
+	}
+	if failed.err == nil || !failed.ended {
+		t.Errorf("Expected failed parse to record its error: %+v", failed)
+	}
+}
+
+func TestTracingValidation(t *testing.T) {
+	tracer := &recordingTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	schema, _ := Loads("type: object\nrequired: [name]\n")
+	registry := NewSchemaRegistry()
+	registry.Register("Service", schema)
+	docs, _ := LoadAlls("kind: Service\nname: a\n---\nkind: Service\n")
+	tracer.spans = nil
+
+	ctx := context.WithValue(context.Background(), spanKey{}, "request")
+	if err := registry.ValidateAllContext(ctx, docs); err == nil {
+		t.Fatal("Expected a validation error")
+	}
+	if len(tracer.spans) != 5 {
+		t.Fatalf("Expected 5 spans, got %d", len(tracer.spans))
+	}
+	all, doc, validate, failed := tracer.spans[0], tracer.spans[1], tracer.spans[2], tracer.spans[4]
+	if all.name != "easyyaml.SchemaRegistry.ValidateAll" || all.parent != "request" || all.attrs["yaml.documents"] != 2 || all.err == nil {
+		t.Errorf("Unexpected ValidateAll span: %+v", all)
+	}
+	if doc.name != "easyyaml.SchemaRegistry.Validate" || doc.parent != all.name || doc.attrs["yaml.kind"] != "Service" {
+		t.Errorf("Unexpected registry Validate span: %+v", doc)
+	}
+	if validate.name != "easyyaml.Validate" || validate.parent != doc.name || validate.attrs["yaml.violations"] != 0 {
+		t.Errorf("Unexpected Validate span: %+v", validate)
+	}
+	if failed.attrs["yaml.violations"] != 1 || failed.err == nil || !failed.ended {
+		t.Errorf("Expected the failed validation to record its error: %+v", failed)
+	}
+
+	cfg, _ := Loads("name: api\n")
+	tracer.spans = nil
+	cfg.ConstrainWith(struct{ Name string }{})
+	if len(tracer.spans) != 2 || tracer.spans[0].name != "easyyaml.ConstrainWith" || tracer.spans[1].parent != "easyyaml.ConstrainWith" {
+		t.Errorf("Expected ConstrainWith and Validate spans, got %d", len(tracer.spans))
+	}
+}
@@ -0,0 +1,63 @@
+package easyyaml
+
+import (
+	"sort"
+	"strconv"
+)
+
+// UnknownKeys returns the sorted paths of keys in the value that the
+// reference document, typically the defaults, does not have, so typos like
+// time_out for timeout can be reported at load time. Only the topmost
+// unknown key of a subtree is listed. Array items are checked against the
+// reference item at the same index, or its first item when the reference
+// array is shorter. Values whose reference is a scalar, null or an empty
+// object or array are not checked further.
+// Usage: for _, path := range cfg.UnknownKeys(defaults) { log.Printf("unknown setting %s", path) }
+func (yv *YAMLValue) UnknownKeys(reference *YAMLValue) []string {
+	var unknown []string
+	collectUnknownKeys(yv, reference, "", &unknown)
+	sort.Strings(unknown)
+	return unknown
+}
+
+// collectUnknownKeys appends the unknown keys of value at path to unknown
+func collectUnknownKeys(value, reference *YAMLValue, path string, unknown *[]string) {
+	if reference.Len() == 0 {
+		return
+	}
+	switch {
+	case value.IsObject() && reference.IsObject():
+		value.eachChild(func(key string, child *YAMLValue) {
+			childPath := joinPath(path, key)
+			ref, ok := reference.lookupKeyString(key)
+			if !ok {
+				*unknown = append(*unknown, childPath)
+				return
+			}
+			collectUnknownKeys(child, ref, childPath, unknown)
+		})
+	case value.IsArray() && reference.IsArray():
+		for i, item := range value.AsArray() {
+			ref := reference.Get(i)
+			if !ref.Exists() {
+				ref = reference.Get(0)
+			}
+			collectUnknownKeys(item, ref, joinPath(path, strconv.Itoa(i)), unknown)
+		}
+	}
+}
+
+// lookupKeyString finds an object entry by its key formatted as a string,
+// which is how eachChild reports keys of either map kind
+func (yv *YAMLValue) lookupKeyString(key string) (*YAMLValue, bool) {
+	if value, ok := yv.Lookup(key); ok {
+		return value, true
+	}
+	var found *YAMLValue
+	yv.eachChild(func(k string, child *YAMLValue) {
+		if k == key {
+			found = child
+		}
+	})
+	return found, found != nil
+}
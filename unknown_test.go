@@ -0,0 +1,45 @@
+package easyyaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	defaults, _ := Loads(`server:
+  host: localhost
+  timeout: 30
+  tls: null
+listeners:
+  - name: http
+    port: 80
+labels: {}
+1: one
+`)
+	cfg, _ := Loads(`server:
+  host: example.com
+  time_out: 60
+  tls:
+    cert: a.pem
+listeners:
+  - name: http
+    prot: 8080
+  - name: https
+    port: 443
+    extra:
+      nested: true
+labels:
+  team: core
+1: uno
+2: dos
+colour: blue
+`)
+
+	expected := []string{"2", "colour", "listeners.0.prot", "listeners.1.extra", "server.time_out"}
+	if got := cfg.UnknownKeys(defaults); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := defaults.UnknownKeys(defaults); len(got) != 0 {
+		t.Errorf("Expected no unknown keys against itself, got %v", got)
+	}
+}
@@ -0,0 +1,265 @@
+package easyyaml
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate checks the value against a JSON Schema and returns a *MultiError
+// listing every violation with its path, each wrapping ErrSchemaViolation.
+// It supports type, enum, const, required, properties,
+// additionalProperties, items, minItems, maxItems, uniqueItems, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength,
+// pattern, allOf, anyOf and oneOf, plus x-kubernetes-list-type "map".
+// Usage: if err := cfg.Validate(schema); err != nil { log.Fatal(err) }
+func (yv *YAMLValue) Validate(schema *YAMLValue) error {
+	return yv.ValidateContext(context.Background(), schema)
+}
+
+// validate implements Validate without tracing and returns the collected
+// violations
+func (yv *YAMLValue) validate(schema *YAMLValue) *ErrorCollector {
+	c := &ErrorCollector{}
+	validateData(yv.data, schema, "", c)
+	logWarn("easyyaml: validation failed", c.Err(), "violations", c.Len())
+	return c
+}
+
+// violation records a schema violation at path
+func violation(c *ErrorCollector, path string, format string, args ...interface{}) {
+	c.Add("validate", path, fmt.Errorf("%w: %s", ErrSchemaViolation, fmt.Sprintf(format, args...)))
+}
+
+// validateData checks data against schema, recording violations in c
+func validateData(data interface{}, schema *YAMLValue, path string, c *ErrorCollector) {
+	if b, ok := schema.data.(bool); ok {
+		if !b {
+			violation(c, path, "no value is allowed here")
+		}
+		return
+	}
+	value := &YAMLValue{data: data}
+
+	if t := schema.Get("type"); t.Exists() {
+		var types []string
+		if t.IsArray() {
+			types, _ = t.AsStringSlice()
+		} else {
+			types = []string{t.AsString()}
+		}
+		matched := false
+		for _, typ := range types {
+			if matchesType(data, typ) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			violation(c, path, "expected %s, got %s", strings.Join(types, " or "), jsonTypeName(data))
+			return
+		}
+	}
+	if enum := schema.Get("enum"); enum.IsArray() {
+		found := false
+		for _, allowed := range enum.AsArray() {
+			if sameValue(data, allowed.data) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violation(c, path, "%s is not one of %s", value.AsString(), enum.AsString())
+		}
+	}
+	if constant, ok := schema.Lookup("const"); ok && !sameValue(data, constant.data) {
+		violation(c, path, "expected %s, got %s", constant.AsString(), value.AsString())
+	}
+
+	validateCombinators(data, schema, path, c)
+
+	switch {
+	case value.IsObject():
+		validateObject(value, schema, path, c)
+	case value.IsArray():
+		validateArray(value, schema, path, c)
+	case value.IsString():
+		validateString(value.AsString(), schema, path, c)
+	default:
+		if n, ok := toFloat(data); ok && !value.IsBool() {
+			validateNumber(n, schema, path, c)
+		}
+	}
+}
+
+// validateCombinators checks allOf, anyOf and oneOf
+func validateCombinators(data interface{}, schema *YAMLValue, path string, c *ErrorCollector) {
+	for _, sub := range schema.Get("allOf").AsArray() {
+		validateData(data, sub, path, c)
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		alternatives := schema.Get(key)
+		if !alternatives.IsArray() {
+			continue
+		}
+		passed := 0
+		for _, sub := range alternatives.AsArray() {
+			trial := &ErrorCollector{}
+			validateData(data, sub, path, trial)
+			if trial.Len() == 0 {
+				passed++
+			}
+		}
+		switch {
+		case passed == 0:
+			violation(c, path, "does not match any schema in %s", key)
+		case key == "oneOf" && passed > 1:
+			violation(c, path, "matches %d schemas in oneOf, expected exactly one", passed)
+		}
+	}
+}
+
+// validateObject checks required, properties and additionalProperties
+func validateObject(value *YAMLValue, schema *YAMLValue, path string, c *ErrorCollector) {
+	required, _ := schema.Get("required").AsStringSlice()
+	for _, name := range required {
+		if !value.Has(name) {
+			violation(c, joinPath(path, name), "required property is missing")
+		}
+	}
+	properties := schema.Get("properties")
+	additional, hasAdditional := schema.Lookup("additionalProperties")
+	value.eachChild(func(key string, child *YAMLValue) {
+		childPath := joinPath(path, key)
+		if propSchema, ok := properties.Lookup(key); ok {
+			validateData(child.data, propSchema, childPath, c)
+			return
+		}
+		if hasAdditional {
+			if allowed, isBool := additional.data.(bool); isBool && !allowed {
+				violation(c, childPath, "additional property is not allowed")
+				return
+			}
+			validateData(child.data, additional, childPath, c)
+		}
+	})
+}
+
+// validateArray checks items, minItems, maxItems and uniqueItems
+func validateArray(value *YAMLValue, schema *YAMLValue, path string, c *ErrorCollector) {
+	items := value.data.([]interface{})
+	if min, ok := schema.Lookup("minItems"); ok && len(items) < min.AsInt() {
+		violation(c, path, "expected at least %d items, got %d", min.AsInt(), len(items))
+	}
+	if max, ok := schema.Lookup("maxItems"); ok && len(items) > max.AsInt() {
+		violation(c, path, "expected at most %d items, got %d", max.AsInt(), len(items))
+	}
+	if schema.Get("uniqueItems").AsBool() {
+		for i := 1; i < len(items); i++ {
+			for j := 0; j < i; j++ {
+				if sameValue(items[i], items[j]) {
+					violation(c, joinPath(path, fmt.Sprint(i)), "duplicate of item %d", j)
+					break
+				}
+			}
+		}
+	}
+	if schema.Get("x-kubernetes-list-type").AsString() == "map" {
+		validateListMapKeys(items, schema.Get("x-kubernetes-list-map-keys"), path, c)
+	}
+	if itemSchema, ok := schema.Lookup("items"); ok && !itemSchema.IsArray() {
+		for i, item := range items {
+			validateData(item, itemSchema, joinPath(path, fmt.Sprint(i)), c)
+		}
+	}
+}
+
+// validateListMapKeys checks that the items of a Kubernetes map list have
+// unique values for the list's key fields
+func validateListMapKeys(items []interface{}, keys *YAMLValue, path string, c *ErrorCollector) {
+	names, _ := keys.AsStringSlice()
+	seen := make(map[string]int, len(items))
+	for i, item := range items {
+		parts := make([]string, len(names))
+		for j, name := range names {
+			parts[j] = (&YAMLValue{data: item}).Get(name).AsString()
+		}
+		id := strings.Join(parts, "\x00")
+		if first, ok := seen[id]; ok {
+			violation(c, joinPath(path, fmt.Sprint(i)), "duplicate %s of item %d", strings.Join(names, ", "), first)
+			continue
+		}
+		seen[id] = i
+	}
+}
+
+// validateString checks minLength, maxLength and pattern
+func validateString(s string, schema *YAMLValue, path string, c *ErrorCollector) {
+	length := utf8.RuneCountInString(s)
+	if min, ok := schema.Lookup("minLength"); ok && length < min.AsInt() {
+		violation(c, path, "expected at least %d characters, got %d", min.AsInt(), length)
+	}
+	if max, ok := schema.Lookup("maxLength"); ok && length > max.AsInt() {
+		violation(c, path, "expected at most %d characters, got %d", max.AsInt(), length)
+	}
+	if pattern := schema.Get("pattern"); pattern.IsString() {
+		re, err := regexp.Compile(pattern.AsString())
+		if err != nil {
+			violation(c, path, "invalid pattern %q: %v", pattern.AsString(), err)
+		} else if !re.MatchString(s) {
+			violation(c, path, "%q does not match pattern %q", s, pattern.AsString())
+		}
+	}
+}
+
+// validateNumber checks minimum, maximum and their exclusive forms
+func validateNumber(n float64, schema *YAMLValue, path string, c *ErrorCollector) {
+	if min, ok := schema.Lookup("minimum"); ok && n < min.AsFloat() {
+		violation(c, path, "%v is less than the minimum %v", n, min.AsFloat())
+	}
+	if max, ok := schema.Lookup("maximum"); ok && n > max.AsFloat() {
+		violation(c, path, "%v is greater than the maximum %v", n, max.AsFloat())
+	}
+	if min, ok := schema.Lookup("exclusiveMinimum"); ok && !min.IsBool() && n <= min.AsFloat() {
+		violation(c, path, "%v is not greater than %v", n, min.AsFloat())
+	}
+	if max, ok := schema.Lookup("exclusiveMaximum"); ok && !max.IsBool() && n >= max.AsFloat() {
+		violation(c, path, "%v is not less than %v", n, max.AsFloat())
+	}
+}
+
+// matchesType checks data against a JSON Schema type name
+func matchesType(data interface{}, typ string) bool {
+	actual := jsonTypeName(data)
+	switch typ {
+	case "number":
+		return actual == "integer" || actual == "number"
+	case "integer":
+		if actual == "number" {
+			f, _ := toFloat(data)
+			return f == math.Trunc(f)
+		}
+	}
+	return actual == typ
+}
+
+// jsonTypeName returns the JSON Schema type of data
+func jsonTypeName(data interface{}) string {
+	if name := typeName(data); name != "number" {
+		return name
+	}
+	return scalarSchemaType(data)
+}
+
+// sameValue is like valuesEqual but also treats objects with the same
+// entries as equal regardless of their Go map type
+func sameValue(a, b interface{}) bool {
+	if _, ok := toFloat(a); ok {
+		return valuesEqual(a, b)
+	}
+	return reflect.DeepEqual(jsonCompatible(a), jsonCompatible(b))
+}
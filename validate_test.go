@@ -0,0 +1,77 @@
+package easyyaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	schema, _ := Loads(`
+type: object
+required: [name, port]
+additionalProperties: false
+properties:
+  name: {type: string, minLength: 2, pattern: "^[a-z]+$"}
+  port: {type: integer, minimum: 1, maximum: 65535}
+  mode: {enum: [dev, prod]}
+  ratio: {type: number, exclusiveMaximum: 1}
+  tags:
+    type: array
+    maxItems: 2
+    uniqueItems: true
+    items: {type: string}
+  backend:
+    oneOf:
+      - {type: string}
+      - {type: object, required: [url]}
+`)
+
+	valid, _ := Loads("name: api\nport: 8080\nmode: prod\nratio: 0.5\ntags: [a, b]\nbackend: {url: x}\n")
+	if err := valid.Validate(schema); err != nil {
+		t.Errorf("Expected valid document, got %v", err)
+	}
+
+	invalid, _ := Loads(`
+name: A
+port: 70000
+mode: test
+ratio: 1
+tags: [a, a, 3]
+backend: {}
+extra: true
+`)
+	err := invalid.Validate(schema)
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Expected a MultiError, got %v", err)
+	}
+	if !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("Expected ErrSchemaViolation, got %v", err)
+	}
+
+	expected := []string{
+		"validate backend: schema violation: does not match any schema in oneOf",
+		"validate extra: schema violation: additional property is not allowed",
+		"validate mode: schema violation: test is not one of [dev, prod]",
+		"validate name: schema violation: expected at least 2 characters, got 1",
+		`validate name: schema violation: "A" does not match pattern "^[a-z]+$"`,
+		"validate port: schema violation: 70000 is greater than the maximum 65535",
+		"validate ratio: schema violation: 1 is not less than 1",
+		"validate tags: schema violation: expected at most 2 items, got 3",
+		"validate tags.1: schema violation: duplicate of item 0",
+		"validate tags.2: schema violation: expected string, got integer",
+	}
+	if got := err.Error(); got != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), got)
+	}
+
+	missing, _ := Loads("name: api\n")
+	if err := missing.Validate(schema); err == nil || err.Error() != "validate port: schema violation: required property is missing" {
+		t.Errorf("Expected missing port, got %v", err)
+	}
+
+	if err := NewArray().Validate(schema); err == nil || err.Error() != "validate: schema violation: expected object, got array" {
+		t.Errorf("Expected root type error, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package easyyaml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WarningKind classifies a Warning
+type WarningKind int
+
+const (
+	// WarnRepairedTab marks a line whose indentation tabs were replaced by
+	// WithTabRepair
+	WarnRepairedTab WarningKind = iota
+	// WarnDuplicateKey marks a mapping key that was defined again later in
+	// the same mapping, with WithDuplicateKeys
+	WarnDuplicateKey
+	// WarnDeprecatedSyntax marks YAML 1.1 forms that still parse but are not
+	// YAML 1.2, such as 0755 octals
+	WarnDeprecatedSyntax
+	// WarnAmbiguousType marks a plain scalar that other YAML parsers or
+	// readers are likely to type differently, such as yes or 1.10
+	WarnAmbiguousType
+	// WarnDeprecatedKey marks a value that MigrateKeys moved from a
+	// deprecated path
+	WarnDeprecatedKey
+)
+
+// String returns the kind's name, e.g. "duplicate-key"
+func (k WarningKind) String() string {
+	switch k {
+	case WarnRepairedTab:
+		return "repaired-tab"
+	case WarnDuplicateKey:
+		return "duplicate-key"
+	case WarnDeprecatedSyntax:
+		return "deprecated-syntax"
+	case WarnAmbiguousType:
+		return "ambiguous-type"
+	case WarnDeprecatedKey:
+		return "deprecated-key"
+	}
+	return "unknown"
+}
+
+// Warning is an advisory recorded while loading that did not stop the parse
+type Warning struct {
+	Kind WarningKind
+	// Line is the 1-based input line the warning refers to
+	Line int
+	// Path is the dot-separated path of the value, or empty for line-level
+	// warnings
+	Path    string
+	Message string
+}
+
+// String formats the warning as "line N: message"
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// Warnings returns the advisories recorded when the value was loaded
+func (yv *YAMLValue) Warnings() []Warning {
+	return append([]Warning(nil), yv.warnings...)
+}
+
+// WithWarnings makes a load inspect every scalar and record deprecated
+// syntax and ambiguous types in Warnings
+// Usage: doc, err := easyyaml.LoadFile("app.yaml", easyyaml.WithWarnings())
+func WithWarnings() LoadOption {
+	return func(opts *Options) {
+		opts.Warnings = true
+	}
+}
+
+// WithDuplicateKeys makes a load accept mappings that define a key more
+// than once, keeping the last value and recording a Warning for each
+// earlier definition
+func WithDuplicateKeys() LoadOption {
+	return func(opts *Options) {
+		opts.AllowDuplicateKeys = true
+	}
+}
+
+var (
+	// octal11Pattern matches YAML 1.1 octals, which YAML 1.2 writes as 0o755
+	octal11Pattern = regexp.MustCompile(`^[-+]?0[0-7]+$`)
+	// sexagesimalPattern matches YAML 1.1 base 60 numbers such as 1:30
+	sexagesimalPattern = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?$`)
+	// versionPattern matches floats with trailing zeros that read like
+	// version numbers, such as 1.10
+	versionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+0$`)
+)
+
+// bool11Words are plain scalars that YAML 1.1 reads as booleans
+var bool11Words = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+}
+
+// inspectNode removes duplicate mapping keys when opts allow them and, with
+// opts.Warnings, checks every scalar, returning the warnings found
+func inspectNode(node *yaml.Node, opts Options) []Warning {
+	if !opts.Warnings && !opts.AllowDuplicateKeys {
+		return nil
+	}
+	var warnings []Warning
+	var visit func(n *yaml.Node, path string)
+	visit = func(n *yaml.Node, path string) {
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, child := range n.Content {
+				visit(child, path)
+			}
+		case yaml.MappingNode:
+			if opts.AllowDuplicateKeys {
+				warnings = append(warnings, dropDuplicateKeys(n, path)...)
+			}
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, keyPath := n.Content[i], joinPath(path, n.Content[i].Value)
+				if opts.Warnings && key.Kind == yaml.ScalarNode {
+					if w, ok := scalarWarning(key, keyPath); ok {
+						warnings = append(warnings, w)
+					}
+				}
+				visit(n.Content[i+1], keyPath)
+			}
+		case yaml.SequenceNode:
+			for i, child := range n.Content {
+				visit(child, joinPath(path, strconv.Itoa(i)))
+			}
+		case yaml.ScalarNode:
+			if opts.Warnings {
+				if w, ok := scalarWarning(n, path); ok {
+					warnings = append(warnings, w)
+				}
+			}
+		}
+	}
+	visit(node, "")
+	return warnings
+}
+
+// dropDuplicateKeys removes all but the last entry for each key of a
+// mapping node, returning a warning for each removed entry
+func dropDuplicateKeys(n *yaml.Node, path string) []Warning {
+	last := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if key := n.Content[i]; key.Kind == yaml.ScalarNode {
+			last[key.Tag+":"+key.Value] = key
+		}
+	}
+	if len(last)*2 == len(n.Content) {
+		return nil
+	}
+
+	var warnings []Warning
+	kept := n.Content[:0]
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if final, ok := last[key.Tag+":"+key.Value]; ok && final != key {
+			warnings = append(warnings, Warning{
+				Kind:    WarnDuplicateKey,
+				Line:    key.Line,
+				Path:    joinPath(path, key.Value),
+				Message: fmt.Sprintf("key %q is defined again at line %d; the later value is used", key.Value, final.Line),
+			})
+			continue
+		}
+		kept = append(kept, key, value)
+	}
+	n.Content = kept
+	return warnings
+}
+
+// scalarWarning checks a plain scalar for YAML 1.1 forms and values whose
+// type is easy to misread
+func scalarWarning(n *yaml.Node, path string) (Warning, bool) {
+	if n.Style != 0 {
+		return Warning{}, false
+	}
+	w := Warning{Line: n.Line, Path: path}
+	value := n.Value
+	switch {
+	case n.Tag == "!!int" && octal11Pattern.MatchString(value):
+		w.Kind = WarnDeprecatedSyntax
+		digits := strings.TrimLeft(value, "+-")
+		w.Message = fmt.Sprintf("%s is a YAML 1.1 octal; write %s0o%s", value, value[:len(value)-len(digits)], digits[1:])
+	case (n.Tag == "!!int" || n.Tag == "!!float") && strings.Contains(value, "_"):
+		w.Kind = WarnDeprecatedSyntax
+		w.Message = fmt.Sprintf("digit separators in %s are YAML 1.1 only", value)
+	case n.Tag == "!!str" && bool11Words[strings.ToLower(value)]:
+		w.Kind = WarnAmbiguousType
+		w.Message = fmt.Sprintf("%s is a string here but a boolean in YAML 1.1; quote it", value)
+	case n.Tag == "!!str" && sexagesimalPattern.MatchString(value):
+		w.Kind = WarnAmbiguousType
+		w.Message = fmt.Sprintf("%s is a string here but a base 60 number in YAML 1.1; quote it", value)
+	case n.Tag == "!!float" && versionPattern.MatchString(value):
+		w.Kind = WarnAmbiguousType
+		w.Message = fmt.Sprintf("%s is read as the number %s; quote it if it is a version", value, strings.TrimRight(strings.TrimRight(value, "0"), "."))
+	default:
+		return Warning{}, false
+	}
+	return w, true
+}
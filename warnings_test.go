@@ -0,0 +1,95 @@
+package easyyaml
+
+import (
+	"testing"
+)
+
+func TestWithWarnings(t *testing.T) {
+	input := []byte(`on:
+  push: yes
+mode: 0755
+size: 1_000
+duration: 1:30
+version: 1.10
+ratio: 1.5
+quoted: "yes"
+tagged: !!str no
+`)
+	yv, err := Load(input, WithWarnings())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := []struct {
+		kind WarningKind
+		line int
+		path string
+	}{
+		{WarnAmbiguousType, 1, "on"},
+		{WarnAmbiguousType, 2, "on.push"},
+		{WarnDeprecatedSyntax, 3, "mode"},
+		{WarnDeprecatedSyntax, 4, "size"},
+		{WarnAmbiguousType, 5, "duration"},
+		{WarnAmbiguousType, 6, "version"},
+	}
+	warnings := yv.Warnings()
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i, want := range expected {
+		got := warnings[i]
+		if got.Kind != want.kind || got.Line != want.line || got.Path != want.path {
+			t.Errorf("Expected %v at line %d (%s), got %v at line %d (%s)", want.kind, want.line, want.path, got.Kind, got.Line, got.Path)
+		}
+	}
+	if got := warnings[2].String(); got != "line 3: 0755 is a YAML 1.1 octal; write 0o755" {
+		t.Errorf("Unexpected warning text: %s", got)
+	}
+
+	plain, _ := Load(input)
+	if len(plain.Warnings()) != 0 {
+		t.Errorf("Expected no warnings without WithWarnings, got %v", plain.Warnings())
+	}
+}
+
+func TestWithDuplicateKeys(t *testing.T) {
+	input := []byte("name: a\nport: 80\nname: b\nnested:\n  x: 1\n  x: 2\n")
+	if _, err := Load(input); err == nil {
+		t.Fatal("Expected duplicate keys to fail by default")
+	}
+
+	yv, err := Load(input, WithDuplicateKeys())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if yv.Get("name").AsString() != "b" || yv.Path("nested.x").AsInt() != 2 {
+		t.Errorf("Expected later values to win, got %v", yv.Raw())
+	}
+	warnings := yv.Warnings()
+	if len(warnings) != 2 || warnings[0].Kind != WarnDuplicateKey || warnings[0].Line != 1 || warnings[1].Path != "nested.x" {
+		t.Fatalf("Expected duplicate-key warnings for name and nested.x, got %v", warnings)
+	}
+	if warnings[0].String() != `line 1: key "name" is defined again at line 3; the later value is used` {
+		t.Errorf("Unexpected warning text: %s", warnings[0])
+	}
+}
+
+func TestDocumentsWarnings(t *testing.T) {
+	docs, err := LoadAll([]byte("a: yes\n---\nb:\n\tc: 0644\n"), WithWarnings(), WithTabRepair(2))
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	warnings := docs.Warnings()
+	kinds := []WarningKind{WarnAmbiguousType, WarnRepairedTab, WarnDeprecatedSyntax}
+	if len(warnings) != len(kinds) {
+		t.Fatalf("Expected %d warnings, got %v", len(kinds), warnings)
+	}
+	for i, kind := range kinds {
+		if warnings[i].Kind != kind {
+			t.Errorf("Expected warning %d to be %v, got %v", i, kind, warnings[i].Kind)
+		}
+	}
+	if len(docs.Get(1).Warnings()) != 1 {
+		t.Errorf("Expected one warning on the second document, got %v", docs.Get(1).Warnings())
+	}
+}
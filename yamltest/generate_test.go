@@ -0,0 +1,54 @@
+package yamltest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+func TestGenerateRoundTrips(t *testing.T) {
+	for seed := int64(0); seed < 300; seed++ {
+		doc := Generate(rand.New(rand.NewSource(seed)), GenOptions{})
+		out, err := doc.Dumps()
+		if err != nil {
+			t.Fatalf("seed %d: Dumps failed: %v", seed, err)
+		}
+		again, err := easyyaml.Loads(out)
+		if err != nil {
+			t.Fatalf("seed %d: output does not parse: %v\n%s", seed, err, out)
+		}
+		if differences := Diff(doc, again); len(differences) > 0 {
+			t.Fatalf("seed %d: round trip changed the document: %v\n%s", seed, differences, out)
+		}
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	a := Generate(rand.New(rand.NewSource(1)), GenOptions{MaxDepth: 2})
+	b := Generate(rand.New(rand.NewSource(1)), GenOptions{MaxDepth: 2})
+	if !reflect.DeepEqual(a.Raw(), b.Raw()) {
+		t.Errorf("Expected the same document for the same seed")
+	}
+
+	for seed := int64(0); seed < 50; seed++ {
+		doc := Generate(rand.New(rand.NewSource(seed)), GenOptions{MaxKeys: 3, Types: []string{"integer"}})
+		if doc.Len() > 3 {
+			t.Errorf("Expected at most 3 keys, got %d", doc.Len())
+		}
+		for _, v := range doc.Values() {
+			if _, ok := v.Raw().(int); !ok {
+				t.Errorf("Expected only integers, got %v", v.Raw())
+			}
+		}
+	}
+}
+
+func TestTrickyInputsParse(t *testing.T) {
+	for i, input := range TrickyInputs() {
+		if _, err := easyyaml.LoadAlls(input); err != nil {
+			t.Errorf("Tricky input %d does not parse: %v\n%s", i, err, input)
+		}
+	}
+}
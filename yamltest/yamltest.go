@@ -0,0 +1,229 @@
+// Package yamltest provides test helpers for code that produces YAML:
+// structural assertions with readable, path-based differences, and golden
+// files.
+//
+// Typical use:
+//
+//	var update = flag.Bool("update", false, "rewrite golden files")
+//
+//	func TestRender(t *testing.T) {
+//		yamltest.AssertEqual(t, "replicas: 3\n", got, yamltest.IgnorePaths("metadata.creationTimestamp"))
+//		yamltest.MatchGolden(t, got, "testdata/out.yaml", update)
+//	}
+package yamltest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+	"gopkg.in/yaml.v3"
+)
+
+// Option adjusts a comparison
+type Option func(*config)
+
+type config struct {
+	ignore []string
+}
+
+// IgnorePaths leaves the given dot-separated paths out of the comparison.
+// A "*" in a segment matches any run of characters, as in
+// easyyaml.YAMLValue.Without.
+func IgnorePaths(paths ...string) Option {
+	return func(c *config) {
+		c.ignore = append(c.ignore, paths...)
+	}
+}
+
+// AssertEqual compares want and got structurally, so key order, quoting
+// and formatting do not matter, and reports each difference with its path.
+// Either side may be a *easyyaml.YAMLValue, YAML text as a string or
+// []byte, or any Go value. It returns whether they were equal.
+func AssertEqual(t testing.TB, want, got interface{}, opts ...Option) bool {
+	t.Helper()
+	wantValue, err := toValue(want)
+	if err != nil {
+		t.Errorf("yamltest: cannot read want: %v", err)
+		return false
+	}
+	gotValue, err := toValue(got)
+	if err != nil {
+		t.Errorf("yamltest: cannot read got: %v", err)
+		return false
+	}
+
+	differences := Diff(wantValue, gotValue, opts...)
+	if len(differences) == 0 {
+		return true
+	}
+	t.Errorf("YAML documents differ:\n  %s", strings.Join(differences, "\n  "))
+	return false
+}
+
+// MatchGolden compares doc with the golden file at path like AssertEqual.
+// When update is non-nil and true, it writes doc to the file instead,
+// creating directories as needed.
+func MatchGolden(t testing.TB, doc interface{}, path string, update *bool, opts ...Option) bool {
+	t.Helper()
+	value, err := toValue(doc)
+	if err != nil {
+		t.Errorf("yamltest: cannot read doc: %v", err)
+		return false
+	}
+
+	if update != nil && *update {
+		out, err := value.Dump()
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(path), 0755)
+		}
+		if err == nil {
+			err = os.WriteFile(path, out, 0644)
+		}
+		if err != nil {
+			t.Errorf("yamltest: failed to update golden file: %v", err)
+			return false
+		}
+		return true
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("yamltest: failed to read golden file (run with the update flag to create it): %v", err)
+		return false
+	}
+	want, err := easyyaml.Load(golden)
+	if err != nil {
+		t.Errorf("yamltest: golden file %s is not valid YAML: %v", path, err)
+		return false
+	}
+	differences := Diff(want, value, opts...)
+	if len(differences) == 0 {
+		return true
+	}
+	t.Errorf("YAML differs from golden file %s:\n  %s", path, strings.Join(differences, "\n  "))
+	return false
+}
+
+// Diff lists the structural differences between want and got, one line
+// per path, sorted by path
+func Diff(want, got *easyyaml.YAMLValue, opts ...Option) []string {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.ignore) > 0 {
+		want, got = want.Without(c.ignore...), got.Without(c.ignore...)
+	}
+
+	var differences []string
+	diffData(canonical(want.Raw()), canonical(got.Raw()), "", &differences)
+	return differences
+}
+
+// toValue converts an argument of AssertEqual to a YAMLValue
+func toValue(v interface{}) (*easyyaml.YAMLValue, error) {
+	switch v := v.(type) {
+	case *easyyaml.YAMLValue:
+		return v, nil
+	case string:
+		return easyyaml.Loads(v)
+	case []byte:
+		return easyyaml.Load(v)
+	}
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return easyyaml.Load(out)
+}
+
+// canonical round-trips data through YAML so that equal documents have
+// equal Go representations
+func canonical(data interface{}) interface{} {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var result interface{}
+	if err := yaml.Unmarshal(out, &result); err != nil {
+		return data
+	}
+	return result
+}
+
+// diffData appends a line to out for every path where want and got differ
+func diffData(want, got interface{}, path string, out *[]string) {
+	if reflect.DeepEqual(want, got) {
+		return
+	}
+	label := path
+	if label == "" {
+		label = "(root)"
+	}
+
+	wm, wantMap := want.(map[string]interface{})
+	gm, gotMap := got.(map[string]interface{})
+	if wantMap && gotMap {
+		keys := make(map[string]bool)
+		for k := range wm {
+			keys[k] = true
+		}
+		for k := range gm {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			wv, inWant := wm[k]
+			gv, inGot := gm[k]
+			switch {
+			case !inGot:
+				*out = append(*out, fmt.Sprintf("%s: missing, want %s", child, format(wv)))
+			case !inWant:
+				*out = append(*out, fmt.Sprintf("%s: unexpected %s", child, format(gv)))
+			default:
+				diffData(wv, gv, child, out)
+			}
+		}
+		return
+	}
+
+	ws, wantList := want.([]interface{})
+	gs, gotList := got.([]interface{})
+	if wantList && gotList {
+		if len(ws) != len(gs) {
+			*out = append(*out, fmt.Sprintf("%s: want %d items, got %d", label, len(ws), len(gs)))
+		}
+		for i := 0; i < len(ws) && i < len(gs); i++ {
+			child := strconv.Itoa(i)
+			if path != "" {
+				child = path + "." + child
+			}
+			diffData(ws[i], gs[i], child, out)
+		}
+		return
+	}
+	*out = append(*out, fmt.Sprintf("%s: want %s, got %s", label, format(want), format(got)))
+}
+
+// format renders a value on one line for a difference report
+func format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return easyyaml.New(v).WithStringFormat(easyyaml.StringFlow).AsString()
+}
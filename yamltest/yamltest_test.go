@@ -0,0 +1,89 @@
+package yamltest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/javanhut/easyyaml"
+)
+
+// recorder captures failures instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqual(t *testing.T) {
+	want := `
+metadata:
+  name: web
+  creationTimestamp: "2024-01-01"
+spec:
+  replicas: 3
+  ports: [80, 443]
+`
+	got, _ := easyyaml.Loads(`
+spec: {ports: [80, 443], replicas: 3}
+metadata: {creationTimestamp: "2025-06-01", name: web}
+`)
+
+	r := &recorder{}
+	if !AssertEqual(r, want, got, IgnorePaths("metadata.creationTimestamp")) {
+		t.Errorf("Expected equal documents, got %v", r.errors)
+	}
+
+	other := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "api", "labels": map[string]interface{}{"a": "b"}},
+		"spec":     map[string]interface{}{"replicas": 3, "ports": []int{80}},
+	}
+	r = &recorder{}
+	if AssertEqual(r, want, other, IgnorePaths("metadata.creationTimestamp")) {
+		t.Fatalf("Expected documents to differ")
+	}
+	expected := `YAML documents differ:
+  metadata.labels: unexpected {a: b}
+  metadata.name: want "web", got "api"
+  spec.ports: want 2 items, got 1`
+	if len(r.errors) != 1 || r.errors[0] != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, strings.Join(r.errors, "\n"))
+	}
+}
+
+func TestMatchGolden(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "testdata", "out.yaml")
+	doc, _ := easyyaml.Loads("b: 2\na: [1, 2]\n")
+
+	r := &recorder{}
+	if MatchGolden(r, doc, golden, nil) {
+		t.Errorf("Expected a missing golden file to fail")
+	}
+
+	update := true
+	if !MatchGolden(r, doc, golden, &update) {
+		t.Fatalf("Expected update to succeed, got %v", r.errors)
+	}
+	if _, err := os.Stat(golden); err != nil {
+		t.Fatalf("Expected golden file written: %v", err)
+	}
+
+	update = false
+	r = &recorder{}
+	if !MatchGolden(r, "a: [1, 2]\nb: 2\n", golden, &update) {
+		t.Errorf("Expected match, got %v", r.errors)
+	}
+	if MatchGolden(r, "a: [1, 3]\nb: 2\n", golden, &update) {
+		t.Errorf("Expected mismatch")
+	}
+	if len(r.errors) != 1 || !strings.HasSuffix(r.errors[0], "a.1: want 2, got 3") {
+		t.Errorf("Unexpected report: %v", r.errors)
+	}
+}